@@ -2,11 +2,41 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/imotkin/L0/internal/app"
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "dlq":
+			if err := app.RunDLQ(os.Args[2:]); err != nil {
+				log.Fatalf("Failed to run dlq command: %v\n", err)
+			}
+
+			return
+		case "cache":
+			if err := app.RunCache(os.Args[2:]); err != nil {
+				log.Fatalf("Failed to run cache command: %v\n", err)
+			}
+
+			return
+		case "export":
+			if err := app.RunExport(os.Args[2:]); err != nil {
+				log.Fatalf("Failed to run export command: %v\n", err)
+			}
+
+			return
+		case "rotate-keys":
+			if err := app.RunRotateKeys(os.Args[2:]); err != nil {
+				log.Fatalf("Failed to run rotate-keys command: %v\n", err)
+			}
+
+			return
+		}
+	}
+
 	if err := app.Run(); err != nil {
 		log.Fatalf("Failed to start app: %v\n", err)
 	}
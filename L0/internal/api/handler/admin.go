@@ -0,0 +1,250 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/imotkin/L0/internal/broker"
+	"github.com/imotkin/L0/internal/cache"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+const (
+	defaultDLQLimit = 50
+	maxDLQLimit     = 500
+)
+
+type Admin struct {
+	responder
+	dlq      broker.DeadLetterQueue
+	cache    cache.Cache[uuid.UUID, entity.Order]
+	notFound cache.Cache[uuid.UUID, struct{}]
+	mc       metrics.Metrics
+}
+
+type AdminOption func(*Admin)
+
+// WithNegativeCache flushes the missing orders remembered by the service
+// together with the cached ones.
+func WithNegativeCache(notFound cache.Cache[uuid.UUID, struct{}]) AdminOption {
+	return func(a *Admin) {
+		a.notFound = notFound
+	}
+}
+
+func NewAdmin(
+	log logger.Logger,
+	dlq broker.DeadLetterQueue,
+	cache cache.Cache[uuid.UUID, entity.Order],
+	mc metrics.Metrics,
+	opts ...AdminOption,
+) *Admin {
+	a := &Admin{
+		responder: responder{log: log.With("source", "admin-handler")},
+		dlq:       dlq,
+		cache:     cache,
+		mc:        mc,
+	}
+
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+func (a *Admin) ListDLQ() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mc.IncRequests()
+
+		limit := defaultDLQLimit
+
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err == nil && (n < 1 || n > maxDLQLimit) {
+				err = fmt.Errorf("limit %d is out of range", n)
+			}
+
+			if err != nil {
+				msg := fmt.Sprintf("limit must be a number from 1 to %d", maxDLQLimit)
+				a.error(w, msg, http.StatusBadRequest, err)
+				return
+			}
+
+			limit = n
+		}
+
+		letters, err := a.dlq.List(r.Context(), limit)
+		if err != nil {
+			a.error(w, "failed to list dlq messages", http.StatusInternalServerError, err)
+			return
+		}
+
+		a.response(w, letters, http.StatusOK)
+	})
+}
+
+func (a *Admin) GetDLQ() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mc.IncRequests()
+
+		partition, offset, err := parseDLQPosition(r)
+		if err != nil {
+			a.error(w, "invalid dlq message position", http.StatusBadRequest, err)
+			return
+		}
+
+		letter, err := a.dlq.Get(r.Context(), partition, offset)
+		if err != nil {
+			a.dlqError(w, err, partition, offset)
+			return
+		}
+
+		a.response(w, letter, http.StatusOK)
+	})
+}
+
+func (a *Admin) ReplayDLQ() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mc.IncRequests()
+
+		partition, offset, err := parseDLQPosition(r)
+		if err != nil {
+			a.error(w, "invalid dlq message position", http.StatusBadRequest, err)
+			return
+		}
+
+		value, err := io.ReadAll(r.Body)
+		if err != nil {
+			a.error(w, "failed to read request body", http.StatusBadRequest, err)
+			return
+		}
+
+		if len(value) == 0 {
+			value = nil // replay the original message
+		} else if !json.Valid(value) {
+			a.error(w, "edited message is not a valid json", http.StatusBadRequest, errors.New("invalid json"))
+			return
+		}
+
+		err = a.dlq.Replay(r.Context(), partition, offset, value)
+		if err != nil {
+			a.dlqError(w, err, partition, offset)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (a *Admin) dlqError(w http.ResponseWriter, err error, partition int32, offset int64) {
+	if errors.Is(err, broker.ErrDeadLetterNotFound) {
+		msg := fmt.Sprintf("dlq message %d/%d is not found", partition, offset)
+		a.error(w, msg, http.StatusNotFound, err)
+		return
+	}
+
+	a.error(w, "failed to get dlq message", http.StatusInternalServerError, err)
+}
+
+func (a *Admin) CacheStats() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mc.IncRequests()
+
+		a.response(w, newCacheStatsResponse(a.cache.Stats()), http.StatusOK)
+	})
+}
+
+// GetCached returns the cached order without updating its recency.
+func (a *Admin) GetCached() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mc.IncRequests()
+
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			a.error(w, "invalid order id", http.StatusBadRequest, err)
+			return
+		}
+
+		order, ok := a.cache.Peek(id)
+		if !ok {
+			msg := fmt.Sprintf("order %q is not cached", id)
+			a.error(w, msg, http.StatusNotFound, entity.ErrOrderNotFound)
+			return
+		}
+
+		a.response(w, order, http.StatusOK)
+	})
+}
+
+func (a *Admin) PurgeCached() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mc.IncRequests()
+
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			a.error(w, "invalid order id", http.StatusBadRequest, err)
+			return
+		}
+
+		if !a.cache.Delete(id) {
+			msg := fmt.Sprintf("order %q is not cached", id)
+			a.error(w, msg, http.StatusNotFound, entity.ErrOrderNotFound)
+			return
+		}
+
+		a.log.Info("order was purged from cache", "uid", id)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func (a *Admin) FlushCache() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a.mc.IncRequests()
+
+		var err error
+
+		if p, ok := a.cache.(cache.Purger); ok {
+			err = p.Purge()
+		} else {
+			a.cache.Clear()
+		}
+
+		// orders added by other instances must not stay missing here
+		if a.notFound != nil {
+			a.notFound.Clear()
+		}
+
+		if err != nil {
+			a.error(w, "failed to flush cache", http.StatusInternalServerError, err)
+			return
+		}
+
+		a.log.Info("cache was flushed")
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
+
+func parseDLQPosition(r *http.Request) (int32, int64, error) {
+	partition, err := strconv.ParseInt(r.PathValue("partition"), 10, 32)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse partition: %w", err)
+	}
+
+	offset, err := strconv.ParseInt(r.PathValue("offset"), 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("parse offset: %w", err)
+	}
+
+	return int32(partition), offset, nil
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/broker"
+	"github.com/imotkin/L0/internal/cache"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+func TestReplayDLQ(t *testing.T) {
+	cases := []struct {
+		partition string
+		body      string
+		value     []byte
+		err       error
+		code      int
+	}{
+		{
+			partition: "1",
+			code:      http.StatusNoContent,
+		},
+		{
+			partition: "1",
+			body:      `{"order_uid":"fixed"}`,
+			value:     []byte(`{"order_uid":"fixed"}`),
+			code:      http.StatusNoContent,
+		},
+		{
+			partition: "1",
+			err:       broker.ErrDeadLetterNotFound,
+			code:      http.StatusNotFound,
+		},
+		{
+			partition: "1",
+			body:      `{"order_uid":`,
+			code:      http.StatusBadRequest,
+		},
+		{
+			partition: "first",
+			code:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run("", func(t *testing.T) {
+			var (
+				ctrl = gomock.NewController(t)
+				dlq  = broker.NewMockDeadLetterQueue(ctrl)
+				mc   = metrics.NewMockMetrics(ctrl)
+				a    = NewAdmin(logger.NewNoOp(), dlq, nil, mc)
+			)
+
+			mc.EXPECT().IncRequests()
+
+			if tt.code != http.StatusBadRequest {
+				dlq.EXPECT().Replay(gomock.Any(), int32(1), int64(10), tt.value).Return(tt.err)
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/admin/dlq/1/10/replay", strings.NewReader(tt.body))
+			r.SetPathValue("partition", tt.partition)
+			r.SetPathValue("offset", "10")
+
+			w := httptest.NewRecorder()
+
+			a.ReplayDLQ().ServeHTTP(w, r)
+
+			require.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestCacheAdmin(t *testing.T) {
+	orders, err := cache.New[uuid.UUID, entity.Order](10)
+	require.NoError(t, err)
+
+	notFound, err := cache.New[uuid.UUID, struct{}](10)
+	require.NoError(t, err)
+
+	var (
+		ctrl  = gomock.NewController(t)
+		mc    = metrics.NewMockMetrics(ctrl)
+		a     = NewAdmin(logger.NewNoOp(), nil, orders, mc, WithNegativeCache(notFound))
+		order = entity.Order{UID: uuid.New(), TrackNumber: "WBILMTESTTRACK"}
+	)
+
+	mc.EXPECT().IncRequests().AnyTimes()
+
+	orders.Set(order.UID, order)
+	orders.Get(order.UID)
+	orders.Get(uuid.New())
+
+	serve := func(h http.Handler, method, id string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(method, "/admin/cache", nil)
+		r.SetPathValue("id", id)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		return w
+	}
+
+	w := serve(a.CacheStats(), http.MethodGet, "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var stats CacheStatsResponse
+
+	err = json.NewDecoder(w.Body).Decode(&stats)
+	require.NoError(t, err)
+	require.Equal(t, CacheStatsResponse{
+		Stats:    cache.Stats{Hits: 1, Misses: 1, Size: 1, Capacity: 10, Bytes: int64(order.Size())},
+		HitRatio: 0.5,
+	}, stats)
+
+	w = serve(a.GetCached(), http.MethodGet, order.UID.String())
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), order.TrackNumber)
+
+	// inspecting a key doesn't count as a hit
+	require.Equal(t, uint64(1), orders.Stats().Hits)
+
+	require.Equal(t, http.StatusBadRequest, serve(a.GetCached(), http.MethodGet, "first").Code)
+	require.Equal(t, http.StatusNotFound, serve(a.GetCached(), http.MethodGet, uuid.NewString()).Code)
+
+	require.Equal(t, http.StatusNoContent, serve(a.PurgeCached(), http.MethodDelete, order.UID.String()).Code)
+	require.Equal(t, http.StatusNotFound, serve(a.PurgeCached(), http.MethodDelete, order.UID.String()).Code)
+
+	orders.Set(order.UID, order)
+	notFound.Set(uuid.New(), struct{}{})
+
+	require.Equal(t, http.StatusNoContent, serve(a.FlushCache(), http.MethodDelete, "").Code)
+	require.Zero(t, orders.Len())
+	require.Zero(t, notFound.Len(), "missing orders are flushed too")
+}
+
+// failingCache fails to clear the shared cache.
+type failingCache struct {
+	cache.Cache[uuid.UUID, entity.Order]
+}
+
+func (failingCache) Purge() error {
+	return errors.New("connection refused")
+}
+
+func TestFlushCacheFailed(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		mc   = metrics.NewMockMetrics(ctrl)
+		a    = NewAdmin(logger.NewNoOp(), nil, failingCache{}, mc)
+		w    = httptest.NewRecorder()
+	)
+
+	mc.EXPECT().IncRequests()
+
+	a.FlushCache().ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/admin/cache", nil))
+
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
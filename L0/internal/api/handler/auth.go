@@ -0,0 +1,84 @@
+package handler
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/logger"
+)
+
+var errForbidden = errors.New("forbidden")
+
+// Auth authorizes requests by the role of the caller. Without auth enabled
+// every request passes.
+type Auth struct {
+	responder
+	auth         auth.Authenticator
+	enabled      bool
+	metricsToken [sha256.Size]byte
+	metrics      bool
+}
+
+func NewAuth(log logger.Logger, cfg *auth.Config, a auth.Authenticator) *Auth {
+	au := &Auth{
+		responder: responder{log: log.With("source", "auth")},
+		auth:      a,
+		enabled:   cfg.Enabled,
+	}
+
+	if cfg.Metrics != nil && cfg.Metrics.Token != "" {
+		au.metricsToken = sha256.Sum256([]byte(cfg.Metrics.Token))
+		au.metrics = true
+	}
+
+	return au
+}
+
+// Require passes requests of callers granted the role and keeps the caller
+// in the request context.
+func (a *Auth) Require(role auth.Role, next http.Handler) http.Handler {
+	if !a.enabled {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id, err := a.auth.Authenticate(r)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			a.error(w, "authentication is required", http.StatusUnauthorized, err)
+			return
+		}
+
+		if !id.Role.Allows(role) {
+			a.error(w, fmt.Sprintf("role %q is required", role), http.StatusForbidden, errForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(auth.WithIdentity(r.Context(), id)))
+	})
+}
+
+// Metrics protects the metrics with their own bearer token when it's set.
+func (a *Auth) Metrics(next http.Handler) http.Handler {
+	if !a.metrics {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		hash := sha256.Sum256([]byte(token))
+
+		if !ok || subtle.ConstantTimeCompare(hash[:], a.metricsToken[:]) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			a.error(w, "authentication is required", http.StatusUnauthorized, auth.ErrInvalidCredentials)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
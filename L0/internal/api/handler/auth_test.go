@@ -0,0 +1,142 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/logger"
+)
+
+func TestAuthRequire(t *testing.T) {
+	var (
+		ctrl   = gomock.NewController(t)
+		authn  = auth.NewMockAuthenticator(ctrl)
+		called bool
+		next   = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			id, ok := auth.FromContext(r.Context())
+			require.True(t, ok)
+			require.Equal(t, "ops", id.Subject)
+
+			called = true
+		})
+	)
+
+	tests := []struct {
+		name     string
+		identity auth.Identity
+		err      error
+		code     int
+	}{
+		{
+			name: "no credentials",
+			err:  auth.ErrNoCredentials,
+			code: http.StatusUnauthorized,
+		},
+		{
+			name:     "lower role",
+			identity: auth.Identity{Subject: "ops", Role: auth.RoleViewer},
+			code:     http.StatusForbidden,
+		},
+		{
+			name:     "required role",
+			identity: auth.Identity{Subject: "ops", Role: auth.RoleSupport},
+			code:     http.StatusOK,
+		},
+		{
+			name:     "higher role",
+			identity: auth.Identity{Subject: "ops", Role: auth.RoleAdmin},
+			code:     http.StatusOK,
+		},
+	}
+
+	h := NewAuth(logger.NewNoOp(), &auth.Config{Enabled: true}, authn).Require(auth.RoleSupport, next)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called = false
+
+			authn.EXPECT().Authenticate(gomock.Any()).Return(tt.identity, tt.err)
+
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+			require.Equal(t, tt.code, w.Code)
+			require.Equal(t, tt.code == http.StatusOK, called)
+
+			if tt.code == http.StatusUnauthorized {
+				require.Equal(t, "Bearer", w.Header().Get("WWW-Authenticate"))
+			}
+		})
+	}
+}
+
+func TestAuthDisabled(t *testing.T) {
+	var (
+		log    = logger.NewNoOp()
+		called bool
+		next   = http.HandlerFunc(func(http.ResponseWriter, *http.Request) { called = true })
+	)
+
+	// the authenticator isn't called without auth enabled
+	h := NewAuth(log, &auth.Config{}, nil).Require(auth.RoleAdmin, next)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/cache", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.True(t, called)
+}
+
+func TestAuthMetrics(t *testing.T) {
+	next := http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+
+	tests := []struct {
+		name   string
+		token  string
+		header string
+		code   int
+	}{
+		{
+			name: "public without token",
+			code: http.StatusOK,
+		},
+		{
+			name:   "valid token",
+			token:  "metrics-token-0123456789",
+			header: "Bearer metrics-token-0123456789",
+			code:   http.StatusOK,
+		},
+		{
+			name:   "wrong token",
+			token:  "metrics-token-0123456789",
+			header: "Bearer metrics-token-9876543210",
+			code:   http.StatusUnauthorized,
+		},
+		{
+			name:  "no token",
+			token: "metrics-token-0123456789",
+			code:  http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &auth.Config{Metrics: &auth.MetricsAuth{Token: tt.token}}
+
+			r := httptest.NewRequest(http.MethodGet, "/metrics", nil)
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+
+			w := httptest.NewRecorder()
+			NewAuth(logger.NewNoOp(), cfg, nil).Metrics(next).ServeHTTP(w, r)
+
+			require.Equal(t, tt.code, w.Code)
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 )
@@ -43,3 +44,59 @@ func (c *Config) Validate() error {
 		),
 	)
 }
+
+// LimitConfig protects the API from abusive clients. Every client, the
+// subject of its credentials or an IP address, has a token bucket refilled
+// with Rate tokens per second up to Burst, and a request takes the cost of
+// its route. Zero values disable the limits. With TrustProxy the address is
+// taken from X-Forwarded-For behind TrustedProxies proxies, 1 by default.
+type LimitConfig struct {
+	Rate           float64       `koanf:"rate"`
+	Burst          int           `koanf:"burst"`
+	ClientTTL      time.Duration `koanf:"client_ttl"`
+	TrustProxy     bool          `koanf:"trust_proxy"`
+	TrustedProxies int           `koanf:"trusted_proxies"`
+	MaxConcurrent  int           `koanf:"max_concurrent"`
+	MaxBodyBytes   int64         `koanf:"max_body_bytes"`
+	Routes         []RouteLimit  `koanf:"routes"`
+}
+
+func (c *LimitConfig) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Rate, validation.Min(0.0)),
+		validation.Field(&c.Burst, validation.Required.When(c.Rate > 0), validation.Min(0)),
+		validation.Field(&c.ClientTTL, validation.Required.When(c.Rate > 0), validation.Min(time.Duration(0))),
+		validation.Field(&c.TrustedProxies, validation.Min(0)),
+		validation.Field(&c.MaxConcurrent, validation.Min(0)),
+		validation.Field(&c.MaxBodyBytes, validation.Min(int64(0))),
+		validation.Field(&c.Routes, validation.Each(validation.By(func(value any) error {
+			route, _ := value.(RouteLimit)
+			return route.validate(c.Rate > 0, c.Burst)
+		}))),
+	)
+}
+
+// RouteLimit overrides the limits of the route, Pattern is the pattern of
+// the route in the router, e.g. "GET /orders". Routes cost 1 by default,
+// cost 0 exempts the route from the rate limit.
+type RouteLimit struct {
+	Pattern       string `koanf:"pattern"`
+	Cost          *int   `koanf:"cost"`
+	MaxConcurrent int    `koanf:"max_concurrent"`
+	MaxBodyBytes  int64  `koanf:"max_body_bytes"`
+}
+
+func (r RouteLimit) validate(limited bool, burst int) error {
+	cost := validation.Min(0)
+	if limited {
+		// requests costing more than the bucket holds would never pass
+		cost = validation.Max(burst).Error("must be no greater than burst")
+	}
+
+	return validation.ValidateStruct(&r,
+		validation.Field(&r.Pattern, validation.Required),
+		validation.Field(&r.Cost, validation.Min(0), cost),
+		validation.Field(&r.MaxConcurrent, validation.Min(0)),
+		validation.Field(&r.MaxBodyBytes, validation.Min(int64(0))),
+	)
+}
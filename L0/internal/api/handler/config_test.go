@@ -0,0 +1,71 @@
+package handler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimitConfigValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   LimitConfig
+		valid bool
+	}{
+		{
+			name:  "no limits",
+			cfg:   LimitConfig{},
+			valid: true,
+		},
+		{
+			name: "rate limits",
+			cfg: LimitConfig{Rate: 20, Burst: 40, ClientTTL: 10 * time.Minute, Routes: []RouteLimit{
+				{Pattern: "GET /orders/export", Cost: cost(20), MaxConcurrent: 2},
+				{Pattern: "/metrics", Cost: cost(0)},
+			}},
+			valid: true,
+		},
+		{
+			name:  "no burst",
+			cfg:   LimitConfig{Rate: 20, ClientTTL: time.Minute},
+			valid: false,
+		},
+		{
+			name:  "no client ttl",
+			cfg:   LimitConfig{Rate: 20, Burst: 40},
+			valid: false,
+		},
+		{
+			name:  "cost over burst",
+			cfg:   LimitConfig{Rate: 20, Burst: 10, ClientTTL: time.Minute, Routes: []RouteLimit{{Pattern: "GET /orders", Cost: cost(20)}}},
+			valid: false,
+		},
+		{
+			name:  "cost without rate",
+			cfg:   LimitConfig{Routes: []RouteLimit{{Pattern: "GET /orders", Cost: cost(20)}}},
+			valid: true,
+		},
+		{
+			name:  "negative cost",
+			cfg:   LimitConfig{Routes: []RouteLimit{{Pattern: "GET /orders", Cost: cost(-1)}}},
+			valid: false,
+		},
+		{
+			name:  "no pattern",
+			cfg:   LimitConfig{Routes: []RouteLimit{{MaxBodyBytes: 1024}}},
+			valid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
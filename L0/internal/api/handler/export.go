@@ -0,0 +1,87 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/export"
+	"github.com/imotkin/L0/internal/pii"
+)
+
+// Export streams the orders matching the listing filters as a file. The
+// status can't be changed once rows were sent, so a later error aborts the
+// connection and the client gets a truncated response instead of a file
+// that looks complete.
+func (h *Handler) Export() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mc.IncRequests()
+
+		query, format, err := parseExportQuery(r.URL.Query())
+		if err != nil {
+			h.error(w, "invalid export query", http.StatusBadRequest, err)
+			return
+		}
+
+		// exports take longer than the server write timeout
+		_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+
+		out := &trackingWriter{ResponseWriter: w}
+
+		ew, err := export.NewWriter(format, out)
+		if err != nil {
+			h.error(w, "failed to create export writer", http.StatusInternalServerError, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", export.ContentType(format))
+		w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="orders.%s"`, format))
+
+		write := ew.Write
+		if pii.Masked(r.Context()) {
+			write = func(order entity.Order) error { return ew.Write(pii.MaskOrder(order)) }
+		}
+
+		err = h.s.Export(r.Context(), query, write)
+		if err != nil && !out.written {
+			// the buffered rows are dropped, only the error is sent
+			out.discard = true
+		}
+
+		if cerr := ew.Close(); err == nil {
+			err = cerr
+		}
+
+		if err == nil {
+			return
+		}
+
+		if !out.written {
+			w.Header().Del("Content-Type")
+			w.Header().Del("Content-Disposition")
+			h.error(w, "failed to export orders", http.StatusInternalServerError, err)
+			return
+		}
+
+		h.log.Error(err, "failed to export orders")
+		panic(http.ErrAbortHandler)
+	})
+}
+
+// trackingWriter reports whether the response body was started.
+type trackingWriter struct {
+	http.ResponseWriter
+	written bool
+	discard bool
+}
+
+func (t *trackingWriter) Write(p []byte) (int, error) {
+	if t.discard {
+		return len(p), nil
+	}
+
+	t.written = true
+
+	return t.ResponseWriter.Write(p)
+}
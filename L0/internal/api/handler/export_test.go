@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/service"
+)
+
+func TestExport(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		s    = service.NewMockService(ctrl)
+		mc   = metrics.NewMockMetrics(ctrl)
+		h    = New(logger.NewNoOp(), s, mc)
+	)
+
+	mc.EXPECT().IncRequests().AnyTimes()
+
+	serve := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.Export().ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return w
+	}
+
+	orders := func(n int, err error) func(context.Context, repo.ListQuery, func(entity.Order) error) error {
+		return func(_ context.Context, _ repo.ListQuery, fn func(entity.Order) error) error {
+			for range n {
+				if err := fn(entity.Order{UID: uuid.New(), Items: []entity.Item{{Name: "Mascaras"}}}); err != nil {
+					return err
+				}
+			}
+
+			return err
+		}
+	}
+
+	s.EXPECT().Export(gomock.Any(), repo.ListQuery{Locale: "en"}, gomock.Any()).DoAndReturn(orders(2, nil))
+
+	w := serve("/orders/export?locale=en&limit=10")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="orders.csv"`, w.Header().Get("Content-Disposition"))
+	require.Len(t, strings.Split(strings.TrimSpace(w.Body.String()), "\n"), 3)
+
+	require.Equal(t, http.StatusBadRequest, serve("/orders/export?format=xml").Code)
+	require.Equal(t, http.StatusBadRequest, serve("/orders/export?from=yesterday").Code)
+
+	// nothing was sent yet, so the error is reported as usual
+	s.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(orders(1, errors.New("connection reset")))
+
+	w = serve("/orders/export?format=ndjson")
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.Empty(t, w.Header().Get("Content-Disposition"))
+
+	s.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(orders(100, errors.New("connection reset")))
+
+	require.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		serve("/orders/export?format=ndjson")
+	})
+}
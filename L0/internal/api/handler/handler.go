@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"html/template"
@@ -11,17 +12,23 @@ import (
 	"github.com/imotkin/L0/internal/entity"
 	"github.com/imotkin/L0/internal/logger"
 	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/pii"
+	"github.com/imotkin/L0/internal/repo"
 	"github.com/imotkin/L0/internal/service"
 )
 
 type Handler struct {
-	s   service.Service
-	log logger.Logger
-	mc  metrics.Metrics
+	responder
+	s  service.Service
+	mc metrics.Metrics
 }
 
 func New(log logger.Logger, s service.Service, mc metrics.Metrics) *Handler {
-	return &Handler{s: s, log: log.With("source", "handler"), mc: mc}
+	return &Handler{
+		responder: responder{log: log.With("source", "handler")},
+		s:         s,
+		mc:        mc,
+	}
 }
 
 func (h *Handler) GetOrder() http.Handler {
@@ -48,6 +55,10 @@ func (h *Handler) GetOrder() http.Handler {
 			return
 		}
 
+		if pii.Masked(r.Context()) {
+			order = pii.MaskOrder(order)
+		}
+
 		h.response(w, order, http.StatusOK)
 	})
 }
@@ -56,13 +67,173 @@ func (h *Handler) GetList() http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		h.mc.IncRequests()
 
-		orders, err := h.s.List(r.Context())
+		query, err := parseListQuery(r.URL.Query())
+		if err != nil {
+			h.error(w, "invalid list query", http.StatusBadRequest, err)
+			return
+		}
+
+		page, err := h.s.List(r.Context(), query)
 		if err != nil {
 			h.error(w, "failed to get orders list", http.StatusInternalServerError, err)
 			return
 		}
 
-		h.response(w, orders, http.StatusOK)
+		maskOrders(r.Context(), page.Orders)
+
+		h.response(w, newListResponse(page), http.StatusOK)
+	})
+}
+
+func (h *Handler) Search() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mc.IncRequests()
+
+		query, err := parseSearchQuery(r.URL.Query())
+		if err != nil {
+			h.error(w, "invalid search query", http.StatusBadRequest, err)
+			return
+		}
+
+		page, err := h.s.Search(r.Context(), query)
+		if err != nil {
+			if errors.Is(err, repo.ErrEncryptedField) {
+				msg := fmt.Sprintf("field %q is encrypted and can't be searched", query.Field)
+				h.error(w, msg, http.StatusBadRequest, err)
+				return
+			}
+
+			h.error(w, "failed to search orders", http.StatusInternalServerError, err)
+			return
+		}
+
+		maskOrders(r.Context(), page.Orders)
+
+		h.response(w, newSearchResponse(page), http.StatusOK)
+	})
+}
+
+func (h *Handler) AddOrders() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mc.IncRequests()
+
+		orders, batch, err := decodeOrders(r.Body)
+		if err != nil {
+			h.error(w, "invalid request body", bodyErrorCode(err), err)
+			return
+		}
+
+		results := make([]service.Result, 0, len(orders))
+
+		for _, order := range orders {
+			res, err := h.s.Submit(r.Context(), order)
+			if err != nil {
+				if !batch {
+					h.error(w, "failed to add order", http.StatusInternalServerError, err)
+					return
+				}
+
+				h.log.Error(err, "failed to add order", "uid", order.UID)
+
+				res = service.Result{
+					UID:    order.UID,
+					Status: service.SubmitFailed,
+					Error:  "failed to add order",
+				}
+			}
+
+			results = append(results, res)
+		}
+
+		if !batch {
+			h.response(w, results[0], submitCode(results[0].Status))
+			return
+		}
+
+		h.response(w, results, http.StatusOK)
+	})
+}
+
+func submitCode(status service.SubmitStatus) int {
+	switch status {
+	case service.SubmitCreated:
+		return http.StatusCreated
+	case service.SubmitInvalid:
+		return http.StatusUnprocessableEntity
+	default:
+		return http.StatusOK
+	}
+}
+
+func (h *Handler) UpdateStatus() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mc.IncRequests()
+
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			h.error(w, "invalid order id", http.StatusBadRequest, err)
+			return
+		}
+
+		var req StatusRequest
+
+		err = json.NewDecoder(r.Body).Decode(&req)
+		if err != nil {
+			h.error(w, "invalid request body", bodyErrorCode(err), err)
+			return
+		}
+
+		change := entity.StatusChange{
+			OrderUID: id,
+			To:       req.Status,
+			Comment:  req.Comment,
+		}
+
+		err = change.Validate()
+		if err != nil {
+			h.error(w, fmt.Sprintf("invalid status change: %v", err), http.StatusBadRequest, err)
+			return
+		}
+
+		change, err = h.s.UpdateStatus(r.Context(), change)
+		if err != nil {
+			switch {
+			case errors.Is(err, entity.ErrOrderNotFound):
+				h.error(w, fmt.Sprintf("order %q is not found", id), http.StatusNotFound, err)
+			case errors.Is(err, entity.ErrInvalidTransition):
+				h.error(w, fmt.Sprintf("status can't be changed to %q", req.Status), http.StatusConflict, err)
+			default:
+				h.error(w, "failed to update order status", http.StatusInternalServerError, err)
+			}
+			return
+		}
+
+		h.response(w, change, http.StatusOK)
+	})
+}
+
+func (h *Handler) StatusHistory() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h.mc.IncRequests()
+
+		id, err := uuid.Parse(r.PathValue("id"))
+		if err != nil {
+			h.error(w, "invalid order id", http.StatusBadRequest, err)
+			return
+		}
+
+		history, err := h.s.StatusHistory(r.Context(), id)
+		if err != nil {
+			if errors.Is(err, entity.ErrOrderNotFound) {
+				h.error(w, fmt.Sprintf("order %q is not found", id), http.StatusNotFound, err)
+				return
+			}
+
+			h.error(w, "failed to get status history", http.StatusInternalServerError, err)
+			return
+		}
+
+		h.response(w, history, http.StatusOK)
 	})
 }
 
@@ -8,9 +8,14 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 
+	"github.com/imotkin/L0/internal/entity"
 	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/service"
 )
 
 func TestErrorResponse(t *testing.T) {
@@ -103,3 +108,62 @@ func TestResponse(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateStatus(t *testing.T) {
+	id := uuid.New()
+
+	cases := []struct {
+		body string
+		err  error
+		code int
+	}{
+		{
+			body: `{"status":"paid"}`,
+			code: http.StatusOK,
+		},
+		{
+			body: `{"status":"delivered"}`,
+			err:  entity.ErrInvalidTransition,
+			code: http.StatusConflict,
+		},
+		{
+			body: `{"status":"paid"}`,
+			err:  entity.ErrOrderNotFound,
+			code: http.StatusNotFound,
+		},
+		{
+			body: `{"status":"unknown"}`,
+			code: http.StatusBadRequest,
+		},
+		{
+			body: `{"status":`,
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run("", func(t *testing.T) {
+			var (
+				ctrl = gomock.NewController(t)
+				s    = service.NewMockService(ctrl)
+				mc   = metrics.NewMockMetrics(ctrl)
+				h    = New(logger.NewNoOp(), s, mc)
+			)
+
+			mc.EXPECT().IncRequests()
+
+			if tt.code != http.StatusBadRequest {
+				s.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Return(entity.StatusChange{}, tt.err)
+			}
+
+			r := httptest.NewRequest(http.MethodPatch, "/order/"+id.String()+"/status", strings.NewReader(tt.body))
+			r.SetPathValue("id", id.String())
+
+			w := httptest.NewRecorder()
+
+			h.UpdateStatus().ServeHTTP(w, r)
+
+			require.Equal(t, tt.code, w.Code)
+		})
+	}
+}
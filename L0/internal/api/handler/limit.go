@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/time/rate"
+
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+const (
+	rejectRate        = "rate"
+	rejectConcurrency = "concurrency"
+	rejectBody        = "body"
+
+	// unmatched is the route of requests that match no pattern.
+	unmatched = "unmatched"
+)
+
+// Limiter rejects requests of clients exceeding their rate, requests over
+// the concurrency limits and requests with too large bodies. Limits are
+// applied before the router, so they also protect the authentication.
+type Limiter struct {
+	responder
+	cfg *LimitConfig
+	mc  metrics.Metrics
+
+	routes map[string]routeLimit
+	global chan struct{}
+
+	inFlight atomic.Int64
+
+	mu      sync.Mutex
+	clients map[string]*client
+
+	now func() time.Time
+}
+
+type routeLimit struct {
+	cost     int
+	sem      chan struct{}
+	maxBytes int64
+}
+
+type client struct {
+	limiter *rate.Limiter
+	seen    time.Time
+}
+
+func NewLimiter(log logger.Logger, cfg *LimitConfig, mc metrics.Metrics) *Limiter {
+	l := &Limiter{
+		responder: responder{log: log.With("source", "limiter")},
+		cfg:       cfg,
+		mc:        mc,
+		routes:    make(map[string]routeLimit, len(cfg.Routes)),
+		clients:   make(map[string]*client),
+		now:       time.Now,
+	}
+
+	if cfg.MaxConcurrent > 0 {
+		l.global = make(chan struct{}, cfg.MaxConcurrent)
+	}
+
+	for _, r := range cfg.Routes {
+		limit := routeLimit{cost: 1, maxBytes: r.MaxBodyBytes}
+
+		if r.Cost != nil {
+			limit.cost = *r.Cost
+		}
+
+		if r.MaxConcurrent > 0 {
+			limit.sem = make(chan struct{}, r.MaxConcurrent)
+		}
+
+		l.routes[r.Pattern] = limit
+	}
+
+	return l
+}
+
+// Wrap limits the requests to the routes of the mux, the limits of a route
+// are looked up by the pattern the request matches.
+func (l *Limiter) Wrap(mux *http.ServeMux) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pattern := mux.Handler(r)
+		if pattern == "" {
+			pattern = unmatched
+		}
+
+		route, ok := l.routes[pattern]
+		if !ok {
+			route = routeLimit{cost: 1}
+		}
+
+		maxBytes := l.cfg.MaxBodyBytes
+		if route.maxBytes > 0 {
+			maxBytes = route.maxBytes
+		}
+
+		if maxBytes > 0 {
+			if r.ContentLength > maxBytes {
+				l.reject(w, pattern, rejectBody, http.StatusRequestEntityTooLarge, 0,
+					fmt.Sprintf("request body is larger than %d bytes", maxBytes))
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		}
+
+		if delay := l.reserve(r, route.cost); delay > 0 {
+			l.reject(w, pattern, rejectRate, http.StatusTooManyRequests, delay, "rate limit is exceeded")
+			return
+		}
+
+		if !acquire(l.global) {
+			l.reject(w, pattern, rejectConcurrency, http.StatusTooManyRequests, time.Second, "server is overloaded")
+			return
+		}
+		defer release(l.global)
+
+		if !acquire(route.sem) {
+			l.reject(w, pattern, rejectConcurrency, http.StatusTooManyRequests, time.Second, "route is overloaded")
+			return
+		}
+		defer release(route.sem)
+
+		l.mc.SetInFlight(int(l.inFlight.Add(1)))
+		defer func() { l.mc.SetInFlight(int(l.inFlight.Add(-1))) }()
+
+		mux.ServeHTTP(w, r)
+	})
+}
+
+// reserve takes the cost from the bucket of the client, it returns how long
+// the client has to wait if the bucket has not enough tokens. Rejected
+// requests take nothing.
+func (l *Limiter) reserve(r *http.Request, cost int) time.Duration {
+	if l.cfg.Rate <= 0 || cost == 0 {
+		return 0
+	}
+
+	var (
+		key = l.clientKey(r)
+		now = l.now()
+	)
+
+	l.mu.Lock()
+
+	c, ok := l.clients[key]
+	if !ok {
+		c = &client{limiter: rate.NewLimiter(rate.Limit(l.cfg.Rate), l.cfg.Burst)}
+		l.clients[key] = c
+	}
+
+	c.seen = now
+
+	l.mu.Unlock()
+
+	res := c.limiter.ReserveN(now, cost)
+
+	delay := res.DelayFrom(now)
+	if delay > 0 {
+		res.CancelAt(now)
+	}
+
+	return delay
+}
+
+// clientKey identifies the client by the subject of its credentials or by
+// the address. The credentials aren't verified here, it's left to the auth
+// middleware, so the limits are checked before the costly verification.
+func (l *Limiter) clientKey(r *http.Request) string {
+	if subject, ok := auth.Subject(r); ok {
+		return "subject:" + subject
+	}
+
+	return "ip:" + l.clientIP(r)
+}
+
+// clientIP takes the address from X-Forwarded-For when the proxies are
+// trusted. Every proxy appends the address of its peer, so the client is the
+// entry the first of the trusted proxies appended, the entries on the left of
+// it are set by the client.
+func (l *Limiter) clientIP(r *http.Request) string {
+	if l.cfg.TrustProxy {
+		var hops []string
+
+		for _, v := range r.Header.Values("X-Forwarded-For") {
+			for hop := range strings.SplitSeq(v, ",") {
+				hops = append(hops, strings.TrimSpace(hop))
+			}
+		}
+
+		if len(hops) > 0 {
+			proxies := max(l.cfg.TrustedProxies, 1)
+			return hops[max(len(hops)-proxies, 0)]
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
+
+func (l *Limiter) reject(w http.ResponseWriter, route, reason string, code int, retry time.Duration, msg string) {
+	l.mc.IncRejected(route, reason)
+	l.log.Debug("request was rejected", "route", route, "reason", reason)
+
+	if retry > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retry.Seconds()))))
+	}
+
+	l.response(w, ErrorMessage{
+		Message:       msg,
+		StatusCode:    code,
+		StatusMessage: http.StatusText(code),
+	}, code)
+}
+
+// Run drops the buckets of clients idle for longer than the client TTL
+// until ctx is done. A dropped client starts again with a full bucket, so
+// the TTL has to be longer than the time the bucket takes to refill.
+func (l *Limiter) Run(ctx context.Context) {
+	if l.cfg.Rate <= 0 {
+		return
+	}
+
+	ticker := time.NewTicker(l.cfg.ClientTTL / 2)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			l.mc.SetRateLimitClients(l.cleanup())
+		}
+	}
+}
+
+// cleanup drops the idle clients and returns the number of the rest.
+func (l *Limiter) cleanup() int {
+	deadline := l.now().Add(-l.cfg.ClientTTL)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for key, c := range l.clients {
+		if c.seen.Before(deadline) {
+			delete(l.clients, key)
+		}
+	}
+
+	return len(l.clients)
+}
+
+// acquire takes a slot of the semaphore without waiting, nil semaphores are
+// unlimited.
+func acquire(sem chan struct{}) bool {
+	if sem == nil {
+		return true
+	}
+
+	select {
+	case sem <- struct{}{}:
+		return true
+	default:
+		return false
+	}
+}
+
+func release(sem chan struct{}) {
+	if sem != nil {
+		<-sem
+	}
+}
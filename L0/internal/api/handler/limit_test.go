@@ -0,0 +1,247 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+func cost(n int) *int {
+	return &n
+}
+
+func TestLimiterRate(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		mc   = metrics.NewMockMetrics(ctrl)
+		l    = NewLimiter(logger.NewNoOp(), &LimitConfig{
+			Rate:      1,
+			Burst:     2,
+			ClientTTL: time.Minute,
+			Routes: []RouteLimit{
+				{Pattern: "GET /orders", Cost: cost(2)},
+				{Pattern: "/metrics", Cost: cost(0)},
+			},
+		}, mc)
+		now = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+		mux = http.NewServeMux()
+		ok  = http.HandlerFunc(func(http.ResponseWriter, *http.Request) {})
+	)
+
+	mc.EXPECT().SetInFlight(gomock.Any()).AnyTimes()
+
+	l.now = func() time.Time { return now }
+
+	mux.Handle("GET /orders", ok)
+	mux.Handle("GET /order/{id}", ok)
+	mux.Handle("/metrics", ok)
+
+	h := l.Wrap(mux)
+
+	send := func(target, key, addr string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r.RemoteAddr = addr
+
+		if key != "" {
+			r.Header.Set(auth.HeaderAPIKey, key)
+		}
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		return w
+	}
+
+	require.Equal(t, http.StatusOK, send("/orders", "first-key-0123456789", "10.0.0.1:1000").Code)
+
+	mc.EXPECT().IncRejected("GET /orders", rejectRate)
+
+	w := send("/orders", "first-key-0123456789", "10.0.0.1:1000")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "2", w.Header().Get("Retry-After"))
+	require.JSONEq(t, `{"message":"rate limit is exceeded","statusCode":429,"statusMessage":"Too Many Requests"}`, w.Body.String())
+
+	require.Equal(t, http.StatusOK, send("/orders", "second-key-0123456789", "10.0.0.1:1000").Code, "clients are limited separately")
+
+	mc.EXPECT().IncRejected("GET /orders", rejectRate)
+	require.Equal(t, http.StatusTooManyRequests, send("/orders", "first-key-0123456789", "10.0.0.9:1000").Code,
+		"clients with credentials are limited on every address")
+
+	require.Equal(t, http.StatusOK, send("/metrics", "first-key-0123456789", "10.0.0.1:1000").Code, "free routes aren't limited")
+
+	now = now.Add(time.Second)
+
+	require.Equal(t, http.StatusOK, send("/order/1", "first-key-0123456789", "10.0.0.1:1000").Code, "routes cost 1 by default")
+
+	mc.EXPECT().IncRejected("GET /order/{id}", rejectRate)
+	require.Equal(t, http.StatusTooManyRequests, send("/order/1", "first-key-0123456789", "10.0.0.1:1000").Code)
+
+	now = now.Add(2 * time.Second)
+
+	require.Equal(t, http.StatusOK, send("/orders", "first-key-0123456789", "10.0.0.1:1000").Code, "the bucket is refilled")
+
+	// clients without credentials are limited by the address
+	require.Equal(t, http.StatusOK, send("/orders", "", "10.0.0.2:1000").Code)
+
+	mc.EXPECT().IncRejected("GET /orders", rejectRate)
+	require.Equal(t, http.StatusTooManyRequests, send("/orders", "", "10.0.0.2:2000").Code)
+
+	mc.EXPECT().IncRejected("unmatched", rejectRate).Times(2)
+	require.Equal(t, http.StatusNotFound, send("/unknown", "", "10.0.0.3:1000").Code)
+	require.Equal(t, http.StatusNotFound, send("/unknown", "", "10.0.0.3:1000").Code)
+	require.Equal(t, http.StatusTooManyRequests, send("/unknown", "", "10.0.0.3:1000").Code)
+	require.Equal(t, http.StatusTooManyRequests, send("/unknown", "", "10.0.0.3:1000").Code)
+
+	require.Equal(t, 4, l.cleanup())
+
+	now = now.Add(time.Minute + time.Second)
+
+	require.Equal(t, http.StatusOK, send("/orders", "first-key-0123456789", "10.0.0.1:1000").Code)
+	require.Equal(t, 1, l.cleanup(), "idle clients are dropped")
+}
+
+func TestLimiterClientIP(t *testing.T) {
+	l := NewLimiter(logger.NewNoOp(), &LimitConfig{}, nil)
+
+	r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	r.RemoteAddr = "10.0.0.2:1000"
+	r.Header.Add("X-Forwarded-For", "198.51.100.7, 192.0.2.1")
+	r.Header.Add("X-Forwarded-For", "10.0.0.1")
+
+	require.Equal(t, "ip:10.0.0.2", l.clientKey(r), "proxy headers aren't trusted by default")
+
+	l.cfg.TrustProxy = true
+	require.Equal(t, "ip:10.0.0.1", l.clientKey(r), "the entry of the only proxy")
+
+	l.cfg.TrustedProxies = 2
+	require.Equal(t, "ip:192.0.2.1", l.clientKey(r))
+
+	l.cfg.TrustedProxies = 5
+	require.Equal(t, "ip:198.51.100.7", l.clientKey(r))
+
+	r.Header.Set(auth.HeaderAPIKey, "first-key-0123456789")
+
+	subject, _ := auth.Subject(r)
+	require.Equal(t, "subject:"+subject, l.clientKey(r), "clients with credentials are limited by the subject")
+}
+
+func TestLimiterBody(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		mc   = metrics.NewMockMetrics(ctrl)
+		l    = NewLimiter(logger.NewNoOp(), &LimitConfig{
+			MaxBodyBytes: 10,
+			Routes: []RouteLimit{
+				{Pattern: "POST /orders", MaxBodyBytes: 100},
+			},
+		}, mc)
+		mux = http.NewServeMux()
+		h   = New(logger.NewNoOp(), nil, nil)
+	)
+
+	mc.EXPECT().SetInFlight(gomock.Any()).AnyTimes()
+
+	mux.Handle("PATCH /order/{id}/status", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.ReadAll(r.Body)
+		if err != nil {
+			h.error(w, "invalid request body", bodyErrorCode(err), err)
+		}
+	}))
+	mux.Handle("POST /orders", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+	}))
+
+	limited := l.Wrap(mux)
+
+	send := func(method, target string, body io.Reader) int {
+		w := httptest.NewRecorder()
+		limited.ServeHTTP(w, httptest.NewRequest(method, target, body))
+
+		return w.Code
+	}
+
+	mc.EXPECT().IncRejected("PATCH /order/{id}/status", rejectBody)
+	require.Equal(t, http.StatusRequestEntityTooLarge, send(http.MethodPatch, "/order/1/status", strings.NewReader(`{"status":"paid"}`)))
+
+	// the length of the streamed body isn't known in advance
+	require.Equal(t, http.StatusRequestEntityTooLarge,
+		send(http.MethodPatch, "/order/1/status", io.MultiReader(strings.NewReader(`{"status":"paid"}`))))
+
+	require.Equal(t, http.StatusOK, send(http.MethodPatch, "/order/1/status", strings.NewReader(`{}`)))
+	require.Equal(t, http.StatusOK, send(http.MethodPost, "/orders", strings.NewReader(`{"status":"paid"}`)))
+}
+
+func TestLimiterConcurrency(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		mc   = metrics.NewMockMetrics(ctrl)
+		l    = NewLimiter(logger.NewNoOp(), &LimitConfig{
+			MaxConcurrent: 2,
+			Routes: []RouteLimit{
+				{Pattern: "GET /orders/export", MaxConcurrent: 1},
+			},
+		}, mc)
+		mux      = http.NewServeMux()
+		started  = make(chan struct{})
+		finished = make(chan struct{})
+		wg       sync.WaitGroup
+	)
+
+	mc.EXPECT().SetInFlight(gomock.Any()).AnyTimes()
+
+	mux.Handle("GET /orders/export", http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		started <- struct{}{}
+		<-finished
+	}))
+	mux.Handle("GET /orders", http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		started <- struct{}{}
+		<-finished
+	}))
+	mux.Handle("GET /order/{id}", http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+
+	h := l.Wrap(mux)
+
+	send := func(target string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return w
+	}
+
+	for _, target := range []string{"/orders/export", "/orders"} {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			send(target)
+		}()
+
+		<-started
+	}
+
+	mc.EXPECT().IncRejected("GET /orders/export", rejectConcurrency)
+	mc.EXPECT().IncRejected("GET /order/{id}", rejectConcurrency)
+
+	w := send("/orders/export")
+	require.Equal(t, http.StatusTooManyRequests, w.Code)
+	require.Equal(t, "1", w.Header().Get("Retry-After"))
+
+	require.Equal(t, http.StatusTooManyRequests, send("/order/1").Code, "the server is full")
+
+	close(finished)
+	wg.Wait()
+
+	require.Equal(t, http.StatusOK, send("/order/1").Code)
+}
@@ -0,0 +1,18 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/pii"
+)
+
+func maskOrders(ctx context.Context, orders []entity.Order) {
+	if !pii.Masked(ctx) {
+		return
+	}
+
+	for i := range orders {
+		orders[i] = pii.MaskOrder(orders[i])
+	}
+}
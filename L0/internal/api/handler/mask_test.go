@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/service"
+)
+
+func TestMaskOrders(t *testing.T) {
+	var (
+		ctrl  = gomock.NewController(t)
+		s     = service.NewMockService(ctrl)
+		mc    = metrics.NewMockMetrics(ctrl)
+		h     = New(logger.NewNoOp(), s, mc)
+		order = entity.Order{
+			UID: uuid.New(),
+			Delivery: entity.Delivery{
+				Name:  "Иван Иванов",
+				Phone: "+79991234599",
+				Email: "ivanov@example.com",
+			},
+		}
+	)
+
+	mc.EXPECT().IncRequests().AnyTimes()
+	s.EXPECT().Get(gomock.Any(), order.UID).Return(order, nil).AnyTimes()
+
+	tests := []struct {
+		name     string
+		identity *auth.Identity
+		delivery entity.Delivery
+	}{
+		{
+			name:     "without auth",
+			delivery: order.Delivery,
+		},
+		{
+			name:     "viewer",
+			identity: &auth.Identity{Subject: "dashboard", Role: auth.RoleViewer},
+			delivery: entity.Delivery{Name: "И*** И*****", Phone: "+7999*****99", Email: "i*****@example.com"},
+		},
+		{
+			name:     "support",
+			identity: &auth.Identity{Subject: "support-team", Role: auth.RoleSupport},
+			delivery: order.Delivery,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/order/"+order.UID.String(), nil)
+			r.SetPathValue("id", order.UID.String())
+
+			if tt.identity != nil {
+				r = r.WithContext(auth.WithIdentity(r.Context(), *tt.identity))
+			}
+
+			w := httptest.NewRecorder()
+			h.GetOrder().ServeHTTP(w, r)
+
+			require.Equal(t, http.StatusOK, w.Code)
+
+			var got entity.Order
+
+			err := json.NewDecoder(w.Body).Decode(&got)
+			require.NoError(t, err)
+			require.Equal(t, tt.delivery, got.Delivery)
+		})
+	}
+
+	orders := []entity.Order{order}
+
+	maskOrders(auth.WithIdentity(context.Background(), *tests[1].identity), orders)
+	require.Equal(t, "+7999*****99", orders[0].Delivery.Phone)
+}
+
+func TestSearchEncryptedField(t *testing.T) {
+	var (
+		ctrl = gomock.NewController(t)
+		s    = service.NewMockService(ctrl)
+		mc   = metrics.NewMockMetrics(ctrl)
+		h    = New(logger.NewNoOp(), s, mc)
+	)
+
+	mc.EXPECT().IncRequests()
+	s.EXPECT().Search(gomock.Any(), gomock.Any()).Return(repo.SearchPage{}, repo.ErrEncryptedField)
+
+	w := httptest.NewRecorder()
+	h.Search().ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/orders/search?q=Иван&field=name", nil))
+
+	require.Equal(t, http.StatusBadRequest, w.Code)
+	require.Contains(t, w.Body.String(), "encrypted")
+}
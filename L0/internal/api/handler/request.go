@@ -0,0 +1,215 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/export"
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/stats"
+)
+
+type StatusRequest struct {
+	Status  entity.Status `json:"status"`
+	Comment string        `json:"comment,omitempty"`
+}
+
+var errEmptyBody = errors.New("empty request body")
+
+func decodeOrders(r io.Reader) (orders []entity.Order, batch bool, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, false, fmt.Errorf("read body: %w", err)
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return nil, false, errEmptyBody
+	}
+
+	if data[0] == '[' {
+		err = json.Unmarshal(data, &orders)
+		if err != nil {
+			return nil, true, fmt.Errorf("decode orders: %w", err)
+		}
+
+		if len(orders) == 0 {
+			return nil, true, errEmptyBody
+		}
+
+		return orders, true, nil
+	}
+
+	var order entity.Order
+
+	err = json.Unmarshal(data, &order)
+	if err != nil {
+		return nil, false, fmt.Errorf("decode order: %w", err)
+	}
+
+	return []entity.Order{order}, false, nil
+}
+
+// bodyErrorCode tells bodies cut by the limit of the Limiter from the
+// malformed ones.
+func bodyErrorCode(err error) int {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return http.StatusRequestEntityTooLarge
+	}
+
+	return http.StatusBadRequest
+}
+
+func parseListQuery(values url.Values) (repo.ListQuery, error) {
+	query := repo.ListQuery{
+		Limit:           repo.DefaultLimit,
+		CustomerID:      values.Get("customer_id"),
+		TrackNumber:     values.Get("track_number"),
+		DeliveryService: values.Get("delivery_service"),
+		Locale:          values.Get("locale"),
+	}
+
+	var err error
+
+	if v := values.Get("limit"); v != "" {
+		query.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			return repo.ListQuery{}, fmt.Errorf("parse limit: %w", err)
+		}
+	}
+
+	if v := values.Get("cursor"); v != "" {
+		query.After, err = repo.ParseCursor(v)
+		if err != nil {
+			return repo.ListQuery{}, fmt.Errorf("parse cursor: %w", err)
+		}
+	}
+
+	if v := values.Get("from"); v != "" {
+		query.From, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return repo.ListQuery{}, fmt.Errorf("parse from: %w", err)
+		}
+	}
+
+	if v := values.Get("to"); v != "" {
+		query.To, err = time.Parse(time.RFC3339, v)
+		if err != nil {
+			return repo.ListQuery{}, fmt.Errorf("parse to: %w", err)
+		}
+	}
+
+	err = query.Validate()
+	if err != nil {
+		return repo.ListQuery{}, fmt.Errorf("validate query: %w", err)
+	}
+
+	return query, nil
+}
+
+// parseExportQuery takes the filters of the listing, exports aren't limited.
+func parseExportQuery(values url.Values) (repo.ListQuery, string, error) {
+	format := values.Get("format")
+	if format == "" {
+		format = export.FormatCSV
+	}
+
+	err := validation.Validate(format, validation.In(export.Formats...))
+	if err != nil {
+		return repo.ListQuery{}, "", fmt.Errorf("validate format: %w", err)
+	}
+
+	values = maps.Clone(values)
+	values.Del("limit")
+
+	query, err := parseListQuery(values)
+	if err != nil {
+		return repo.ListQuery{}, "", err
+	}
+
+	query.Limit = 0
+
+	return query, format, nil
+}
+
+func parseSearchQuery(values url.Values) (repo.SearchQuery, error) {
+	query := repo.SearchQuery{
+		Text:  strings.TrimSpace(values.Get("q")),
+		Field: values.Get("field"),
+		Limit: repo.DefaultLimit,
+	}
+
+	var err error
+
+	if v := values.Get("limit"); v != "" {
+		query.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			return repo.SearchQuery{}, fmt.Errorf("parse limit: %w", err)
+		}
+	}
+
+	if v := values.Get("offset"); v != "" {
+		query.Offset, err = strconv.Atoi(v)
+		if err != nil {
+			return repo.SearchQuery{}, fmt.Errorf("parse offset: %w", err)
+		}
+	}
+
+	err = query.Validate()
+	if err != nil {
+		return repo.SearchQuery{}, fmt.Errorf("validate query: %w", err)
+	}
+
+	return query, nil
+}
+
+func parseStatsQuery(values url.Values, dimension string) (stats.Query, error) {
+	query := stats.Query{
+		Period:    values.Get("period"),
+		Dimension: dimension,
+		Limit:     stats.DefaultLimit,
+	}
+
+	var err error
+
+	if v := values.Get("limit"); v != "" {
+		query.Limit, err = strconv.Atoi(v)
+		if err != nil {
+			return stats.Query{}, fmt.Errorf("parse limit: %w", err)
+		}
+	}
+
+	if v := values.Get("from"); v != "" {
+		query.From, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			return stats.Query{}, fmt.Errorf("parse from: %w", err)
+		}
+	}
+
+	if v := values.Get("to"); v != "" {
+		query.To, err = time.Parse(time.DateOnly, v)
+		if err != nil {
+			return stats.Query{}, fmt.Errorf("parse to: %w", err)
+		}
+	}
+
+	err = query.Validate()
+	if err != nil {
+		return stats.Query{}, fmt.Errorf("validate query: %w", err)
+	}
+
+	return query, nil
+}
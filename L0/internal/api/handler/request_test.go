@@ -0,0 +1,251 @@
+package handler
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/stats"
+)
+
+func TestDecodeOrders(t *testing.T) {
+	cases := []struct {
+		body   string
+		count  int
+		batch  bool
+		failed bool
+	}{
+		{
+			body:  `{"order_uid":"b563feb7-b2b8-4b6c-9f6d-2a4c1b6d6e11"}`,
+			count: 1,
+		},
+		{
+			body:  `  [{"order_uid":"b563feb7-b2b8-4b6c-9f6d-2a4c1b6d6e11"},{"order_uid":"1b1a1c9e-4f0a-4d43-9d2b-8f8c3a3d9a22"}]`,
+			count: 2,
+			batch: true,
+		},
+		{
+			body:   `[]`,
+			batch:  true,
+			failed: true,
+		},
+		{
+			body:   ``,
+			failed: true,
+		},
+		{
+			body:   `{"order_uid":`,
+			failed: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run("", func(t *testing.T) {
+			orders, batch, err := decodeOrders(strings.NewReader(tt.body))
+
+			if tt.failed {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, orders, tt.count)
+			require.Equal(t, tt.batch, batch)
+		})
+	}
+}
+
+func TestParseListQuery(t *testing.T) {
+	cursor := repo.Cursor{
+		DateCreated: time.Date(2025, 4, 10, 10, 0, 0, 123000, time.UTC),
+		ID:          uuid.New(),
+	}
+
+	cases := []struct {
+		query    string
+		expected repo.ListQuery
+		failed   bool
+	}{
+		{
+			query:    "",
+			expected: repo.ListQuery{Limit: repo.DefaultLimit},
+		},
+		{
+			query: "limit=10&customer_id=test&locale=en&delivery_service=DHL&track_number=WB",
+			expected: repo.ListQuery{
+				Limit:           10,
+				CustomerID:      "test",
+				Locale:          "en",
+				DeliveryService: "DHL",
+				TrackNumber:     "WB",
+			},
+		},
+		{
+			query: "from=2025-04-01T00:00:00Z&to=2025-05-01T00:00:00Z&cursor=" + cursor.Encode(),
+			expected: repo.ListQuery{
+				Limit: repo.DefaultLimit,
+				After: &cursor,
+				From:  time.Date(2025, 4, 1, 0, 0, 0, 0, time.UTC),
+				To:    time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			query:  "limit=1000",
+			failed: true,
+		},
+		{
+			query:  "limit=abc",
+			failed: true,
+		},
+		{
+			query:  "cursor=invalid",
+			failed: true,
+		},
+		{
+			query:  "from=2025-05-01T00:00:00Z&to=2025-04-01T00:00:00Z",
+			failed: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run("", func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			got, err := parseListQuery(values)
+
+			if tt.failed {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestParseSearchQuery(t *testing.T) {
+	cases := []struct {
+		query    string
+		expected repo.SearchQuery
+		failed   bool
+	}{
+		{
+			query:    "q=%2B7999",
+			expected: repo.SearchQuery{Text: "+7999", Limit: repo.DefaultLimit},
+		},
+		{
+			query:    "q=+ivanov+&field=email&limit=10&offset=20",
+			expected: repo.SearchQuery{Text: "ivanov", Field: repo.SearchEmail, Limit: 10, Offset: 20},
+		},
+		{
+			query:  "",
+			failed: true,
+		},
+		{
+			query:  "q=a",
+			failed: true,
+		},
+		{
+			query:  "q=ivanov&field=address",
+			failed: true,
+		},
+		{
+			query:  "q=ivanov&offset=-1",
+			failed: true,
+		},
+		{
+			query:  "q=ivanov&limit=1000",
+			failed: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run("", func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			got, err := parseSearchQuery(values)
+
+			if tt.failed {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestParseStatsQuery(t *testing.T) {
+	cases := []struct {
+		query     string
+		dimension string
+		expected  stats.Query
+		failed    bool
+	}{
+		{
+			query:    "",
+			expected: stats.Query{Limit: stats.DefaultLimit},
+		},
+		{
+			query: "from=2026-01-01&to=2026-02-01&period=week&limit=5",
+			expected: stats.Query{
+				From:   time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				To:     time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+				Period: stats.PeriodWeek,
+				Limit:  5,
+			},
+		},
+		{
+			query:     "",
+			dimension: stats.DimensionRegion,
+			expected:  stats.Query{Dimension: stats.DimensionRegion, Limit: stats.DefaultLimit},
+		},
+		{
+			query:     "",
+			dimension: "city",
+			failed:    true,
+		},
+		{
+			query:  "from=01.01.2026",
+			failed: true,
+		},
+		{
+			query:  "from=2026-02-01&to=2026-01-01",
+			failed: true,
+		},
+		{
+			query:  "period=month",
+			failed: true,
+		},
+		{
+			query:  "limit=1000",
+			failed: true,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run("", func(t *testing.T) {
+			values, err := url.ParseQuery(tt.query)
+			require.NoError(t, err)
+
+			got, err := parseStatsQuery(values, tt.dimension)
+
+			if tt.failed {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+}
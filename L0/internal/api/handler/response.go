@@ -3,6 +3,11 @@ package handler
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/imotkin/L0/internal/cache"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/repo"
 )
 
 type ErrorMessage struct {
@@ -11,7 +16,54 @@ type ErrorMessage struct {
 	StatusMessage string `json:"statusMessage"`
 }
 
-func (h *Handler) error(w http.ResponseWriter, msg string, code int, err error) {
+type ListResponse struct {
+	Orders     []entity.Order `json:"orders"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+func newListResponse(page repo.Page) ListResponse {
+	resp := ListResponse{Orders: page.Orders}
+
+	if resp.Orders == nil {
+		resp.Orders = []entity.Order{}
+	}
+
+	if page.Next != nil {
+		resp.NextCursor = page.Next.Encode()
+	}
+
+	return resp
+}
+
+type SearchResponse struct {
+	Orders     []entity.Order `json:"orders"`
+	NextOffset int            `json:"next_offset,omitempty"`
+}
+
+func newSearchResponse(page repo.SearchPage) SearchResponse {
+	resp := SearchResponse{Orders: page.Orders, NextOffset: page.Next}
+
+	if resp.Orders == nil {
+		resp.Orders = []entity.Order{}
+	}
+
+	return resp
+}
+
+type CacheStatsResponse struct {
+	cache.Stats
+	HitRatio float64 `json:"hit_ratio"`
+}
+
+func newCacheStatsResponse(stats cache.Stats) CacheStatsResponse {
+	return CacheStatsResponse{Stats: stats, HitRatio: stats.HitRatio()}
+}
+
+type responder struct {
+	log logger.Logger
+}
+
+func (h *responder) error(w http.ResponseWriter, msg string, code int, err error) {
 	h.log.Error(err, msg)
 	h.response(w, ErrorMessage{
 		Message:       msg,
@@ -20,7 +72,7 @@ func (h *Handler) error(w http.ResponseWriter, msg string, code int, err error)
 	}, code)
 }
 
-func (h *Handler) response(w http.ResponseWriter, v any, code int) {
+func (h *responder) response(w http.ResponseWriter, v any, code int) {
 	w.Header().Add("Content-Type", "application/json")
 	w.WriteHeader(code)
 
@@ -0,0 +1,86 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/stats"
+)
+
+type Stats struct {
+	responder
+	store stats.Store
+	mc    metrics.Metrics
+}
+
+func NewStats(log logger.Logger, store stats.Store, mc metrics.Metrics) *Stats {
+	return &Stats{
+		responder: responder{log: log.With("source", "stats-handler")},
+		store:     store,
+		mc:        mc,
+	}
+}
+
+func (s *Stats) Orders() http.Handler {
+	return s.handle(func(r *http.Request, q stats.Query) (any, error) {
+		points, err := s.store.Timeline(r.Context(), q)
+		if points == nil {
+			points = []stats.Point{}
+		}
+
+		return points, err
+	})
+}
+
+func (s *Stats) Basket() http.Handler {
+	return s.handle(func(r *http.Request, q stats.Query) (any, error) {
+		return s.store.Basket(r.Context(), q)
+	})
+}
+
+func (s *Stats) Breakdown() http.Handler {
+	return s.handle(func(r *http.Request, q stats.Query) (any, error) {
+		return entries(s.store.Breakdown(r.Context(), q))
+	})
+}
+
+func (s *Stats) TopBrands() http.Handler {
+	return s.handle(func(r *http.Request, q stats.Query) (any, error) {
+		return entries(s.store.TopBrands(r.Context(), q))
+	})
+}
+
+func (s *Stats) TopProducts() http.Handler {
+	return s.handle(func(r *http.Request, q stats.Query) (any, error) {
+		return entries(s.store.TopProducts(r.Context(), q))
+	})
+}
+
+func (s *Stats) handle(fn func(r *http.Request, q stats.Query) (any, error)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mc.IncRequests()
+
+		query, err := parseStatsQuery(r.URL.Query(), r.PathValue("dimension"))
+		if err != nil {
+			s.error(w, "invalid stats query", http.StatusBadRequest, err)
+			return
+		}
+
+		v, err := fn(r, query)
+		if err != nil {
+			s.error(w, "failed to get stats", http.StatusInternalServerError, err)
+			return
+		}
+
+		s.response(w, v, http.StatusOK)
+	})
+}
+
+func entries(v []stats.Entry, err error) (any, error) {
+	if v == nil {
+		v = []stats.Entry{}
+	}
+
+	return v, err
+}
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/stats"
+)
+
+func TestStats(t *testing.T) {
+	var (
+		ctrl  = gomock.NewController(t)
+		store = stats.NewMockStore(ctrl)
+		mc    = metrics.NewMockMetrics(ctrl)
+		s     = NewStats(logger.NewNoOp(), store, mc)
+	)
+
+	mc.EXPECT().IncRequests().AnyTimes()
+
+	serve := func(h http.Handler, target, dimension string) *httptest.ResponseRecorder {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		r.SetPathValue("dimension", dimension)
+
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+
+		return w
+	}
+
+	store.EXPECT().Timeline(gomock.Any(), stats.Query{Period: stats.PeriodWeek, Limit: stats.DefaultLimit}).Return(nil, nil)
+
+	w := serve(s.Orders(), "/stats/orders?period=week", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `[]`, w.Body.String())
+
+	store.EXPECT().Basket(gomock.Any(), gomock.Any()).Return(stats.NewBasket(2, 5, 3000), nil)
+
+	w = serve(s.Basket(), "/stats/basket", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `{"orders":2,"items":5,"gmv":3000,"avg_amount":1500,"avg_items":2.5}`, w.Body.String())
+
+	store.EXPECT().Breakdown(gomock.Any(), stats.Query{Dimension: stats.DimensionBank, Limit: 3}).
+		Return([]stats.Entry{{Key: "alpha", Orders: 2, Items: 5, Revenue: 3000}}, nil)
+
+	w = serve(s.Breakdown(), "/stats/breakdown/bank?limit=3", stats.DimensionBank)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.JSONEq(t, `[{"key":"alpha","orders":2,"items":5,"revenue":3000}]`, w.Body.String())
+
+	require.Equal(t, http.StatusBadRequest, serve(s.Breakdown(), "/stats/breakdown/city", "city").Code)
+	require.Equal(t, http.StatusBadRequest, serve(s.TopBrands(), "/stats/top/brands?limit=-1", "").Code)
+
+	store.EXPECT().TopProducts(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	require.Equal(t, http.StatusInternalServerError, serve(s.TopProducts(), "/stats/top/products", "").Code)
+}
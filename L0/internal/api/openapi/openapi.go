@@ -0,0 +1,29 @@
+// Package openapi serves the OpenAPI document of the HTTP API. The client in
+// pkg/client is generated from the same document.
+package openapi
+
+import (
+	_ "embed"
+	"net/http"
+)
+
+//go:embed openapi.json
+var Spec []byte
+
+//go:embed swagger.html
+var ui []byte
+
+func Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write(Spec)
+	})
+}
+
+// UI renders the document with Swagger UI loaded from a CDN.
+func UI() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write(ui)
+	})
+}
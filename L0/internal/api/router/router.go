@@ -4,16 +4,59 @@ import (
 	"net/http"
 
 	"github.com/imotkin/L0/internal/api/handler"
+	"github.com/imotkin/L0/internal/api/openapi"
+	"github.com/imotkin/L0/internal/auth"
 	"github.com/imotkin/L0/internal/metrics"
 )
 
-func New(h *handler.Handler, templatePath string) *http.ServeMux {
+type route struct {
+	pattern string
+	handler http.Handler
+}
+
+func New(h *handler.Handler, a *handler.Admin, st *handler.Stats, au *handler.Auth, templatePath string) *http.ServeMux {
 	r := http.NewServeMux()
 
-	r.Handle("GET /order/{id}", h.GetOrder())
-	r.Handle("GET /orders", h.GetList())
-	r.Handle("GET /search", h.IndexPage(templatePath))
-	r.Handle("/metrics", metrics.Handler())
+	for _, route := range routes(h, a, st, au, templatePath) {
+		r.Handle(route.pattern, route.handler)
+	}
 
 	return r
 }
+
+func routes(h *handler.Handler, a *handler.Admin, st *handler.Stats, au *handler.Auth, templatePath string) []route {
+	var (
+		viewer  = func(h http.Handler) http.Handler { return au.Require(auth.RoleViewer, h) }
+		support = func(h http.Handler) http.Handler { return au.Require(auth.RoleSupport, h) }
+		admin   = func(h http.Handler) http.Handler { return au.Require(auth.RoleAdmin, h) }
+	)
+
+	return []route{
+		{"GET /order/{id}", viewer(h.GetOrder())},
+		{"GET /order/{id}/status", viewer(h.StatusHistory())},
+		{"PATCH /order/{id}/status", support(h.UpdateStatus())},
+		{"GET /orders", support(h.GetList())},
+		{"POST /orders", admin(h.AddOrders())},
+		{"GET /orders/search", support(h.Search())},
+		{"GET /orders/export", admin(h.Export())},
+		{"GET /search", h.IndexPage(templatePath)},
+		{"GET /docs", openapi.UI()},
+		{"GET /openapi.json", openapi.Handler()},
+		{"/metrics", au.Metrics(metrics.Handler())},
+
+		{"GET /stats/orders", viewer(st.Orders())},
+		{"GET /stats/basket", viewer(st.Basket())},
+		{"GET /stats/breakdown/{dimension}", viewer(st.Breakdown())},
+		{"GET /stats/top/brands", viewer(st.TopBrands())},
+		{"GET /stats/top/products", viewer(st.TopProducts())},
+
+		{"GET /admin/dlq", admin(a.ListDLQ())},
+		{"GET /admin/dlq/{partition}/{offset}", admin(a.GetDLQ())},
+		{"POST /admin/dlq/{partition}/{offset}/replay", admin(a.ReplayDLQ())},
+
+		{"GET /admin/cache", admin(a.CacheStats())},
+		{"DELETE /admin/cache", admin(a.FlushCache())},
+		{"GET /admin/cache/{id}", admin(a.GetCached())},
+		{"DELETE /admin/cache/{id}", admin(a.PurgeCached())},
+	}
+}
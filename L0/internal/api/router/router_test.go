@@ -0,0 +1,429 @@
+package router
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/legacy"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/api/handler"
+	"github.com/imotkin/L0/internal/api/openapi"
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/broker"
+	"github.com/imotkin/L0/internal/cache"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/entity/entitytest"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/service"
+	"github.com/imotkin/L0/internal/stats"
+)
+
+var (
+	keys = map[auth.Role]string{
+		auth.RoleViewer:  "viewer-key-0123456789",
+		auth.RoleSupport: "support-key-0123456789",
+		auth.RoleAdmin:   "admin-key-0123456789",
+	}
+	lower = map[auth.Role]auth.Role{
+		auth.RoleSupport: auth.RoleViewer,
+		auth.RoleAdmin:   auth.RoleSupport,
+	}
+)
+
+func testAuth(t *testing.T) *handler.Auth {
+	cfg := &auth.Config{Enabled: true}
+
+	for role, key := range keys {
+		cfg.APIKeys = append(cfg.APIKeys, auth.APIKey{Name: string(role), Key: key, Role: role})
+	}
+
+	a, err := auth.New(cfg)
+	require.NoError(t, err)
+
+	return handler.NewAuth(logger.NewNoOp(), cfg, a)
+}
+
+// TestContract sends requests through the router and checks the requests and
+// responses against the OpenAPI document. Every documented operation must
+// have a successful case, and the route found in the document must be the
+// pattern the router matched. Requests are sent with the key of the role the
+// operation documents, a lower role must be forbidden.
+func TestContract(t *testing.T) {
+	openapi3filter.RegisterBodyDecoder("text/csv", openapi3filter.PlainBodyDecoder)
+	defer openapi3filter.UnregisterBodyDecoder("text/csv")
+
+	ctx := context.Background()
+
+	doc, err := openapi3.NewLoader().LoadFromData(openapi.Spec)
+	require.NoError(t, err)
+	require.NoError(t, doc.Validate(ctx))
+
+	spec, err := legacy.NewRouter(doc)
+	require.NoError(t, err)
+
+	orders, err := cache.New[uuid.UUID, entity.Order](10)
+	require.NoError(t, err)
+
+	var (
+		ctrl  = gomock.NewController(t)
+		s     = service.NewMockService(ctrl)
+		dlq   = broker.NewMockDeadLetterQueue(ctrl)
+		store = stats.NewMockStore(ctrl)
+		mc    = metrics.NewMockMetrics(ctrl)
+		log   = logger.NewNoOp()
+		order = entitytest.Order()
+		mux   = New(
+			handler.New(log, s, mc),
+			handler.NewAdmin(log, dlq, orders, mc),
+			handler.NewStats(log, store, mc),
+			testAuth(t),
+			"../../../template/index.html",
+		)
+	)
+
+	mc.EXPECT().IncRequests().AnyTimes()
+
+	change := entity.StatusChange{
+		OrderUID:  order.UID,
+		From:      entity.StatusCreated,
+		To:        entity.StatusPaid,
+		ChangedAt: order.DateCreated,
+	}
+
+	letter := broker.DeadLetter{
+		Partition:      0,
+		Offset:         5,
+		Key:            order.UID.String(),
+		Value:          `{"order_uid":"` + order.UID.String() + `"}`,
+		ErrorKind:      broker.ErrorValidation,
+		Error:          "track_number: cannot be blank.",
+		OriginalTopic:  "orders",
+		OriginalOffset: 42,
+		FailedAt:       order.DateCreated,
+		Attempts:       1,
+	}
+
+	cases := []struct {
+		method string
+		target string
+		body   string
+		setup  func()
+		code   int
+	}{
+		{
+			method: http.MethodGet,
+			target: "/order/" + order.UID.String(),
+			setup:  func() { s.EXPECT().Get(gomock.Any(), order.UID).Return(order, nil) },
+			code:   http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/order/" + order.UID.String(),
+			setup:  func() { s.EXPECT().Get(gomock.Any(), order.UID).Return(entity.Order{}, entity.ErrOrderNotFound) },
+			code:   http.StatusNotFound,
+		},
+		{
+			method: http.MethodGet,
+			target: "/order/first",
+			code:   http.StatusBadRequest,
+		},
+		{
+			method: http.MethodGet,
+			target: "/order/" + order.UID.String() + "/status",
+			setup: func() {
+				s.EXPECT().StatusHistory(gomock.Any(), order.UID).Return([]entity.StatusChange{change}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			method: http.MethodPatch,
+			target: "/order/" + order.UID.String() + "/status",
+			body:   `{"status":"paid","comment":"оплачен"}`,
+			setup:  func() { s.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Return(change, nil) },
+			code:   http.StatusOK,
+		},
+		{
+			method: http.MethodPatch,
+			target: "/order/" + order.UID.String() + "/status",
+			body:   `{"status":"delivered"}`,
+			setup: func() {
+				s.EXPECT().UpdateStatus(gomock.Any(), gomock.Any()).Return(entity.StatusChange{}, entity.ErrInvalidTransition)
+			},
+			code: http.StatusConflict,
+		},
+		{
+			method: http.MethodGet,
+			target: "/orders?limit=1&locale=en&from=2026-10-01T00:00:00Z",
+			setup: func() {
+				s.EXPECT().List(gomock.Any(), gomock.Any()).Return(repo.Page{
+					Orders: []entity.Order{order},
+					Next:   repo.CursorOf(order),
+				}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/orders?limit=1000",
+			code:   http.StatusBadRequest,
+		},
+		{
+			method: http.MethodPost,
+			target: "/orders",
+			body:   `{"order_uid":"` + order.UID.String() + `"}`,
+			setup: func() {
+				s.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(service.Result{UID: order.UID, Status: service.SubmitCreated}, nil)
+			},
+			code: http.StatusCreated,
+		},
+		{
+			method: http.MethodPost,
+			target: "/orders",
+			body:   `[{"order_uid":"` + order.UID.String() + `"}]`,
+			setup: func() {
+				s.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(service.Result{UID: order.UID, Status: service.SubmitDuplicate}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/orders/search?q=ivanov&field=email",
+			setup: func() {
+				s.EXPECT().Search(gomock.Any(), gomock.Any()).Return(repo.SearchPage{Orders: []entity.Order{order}, Next: 50}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/orders/export?format=csv&locale=en",
+			setup: func() {
+				s.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, _ repo.ListQuery, fn func(entity.Order) error) error {
+						return fn(order)
+					},
+				)
+			},
+			code: http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/stats/orders?from=2026-10-01&period=week",
+			setup: func() {
+				store.EXPECT().Timeline(gomock.Any(), gomock.Any()).Return([]stats.Point{
+					{Date: time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC), Orders: 2, Items: 3, GMV: 3634},
+				}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/stats/basket",
+			setup:  func() { store.EXPECT().Basket(gomock.Any(), gomock.Any()).Return(stats.NewBasket(2, 3, 3634), nil) },
+			code:   http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/stats/breakdown/bank?limit=5",
+			setup: func() {
+				store.EXPECT().Breakdown(gomock.Any(), gomock.Any()).Return([]stats.Entry{
+					{Key: "alpha", Orders: 2, Items: 3, Revenue: 3634},
+				}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/stats/top/brands",
+			setup: func() {
+				store.EXPECT().TopBrands(gomock.Any(), gomock.Any()).Return([]stats.Entry{{Key: "Vivienne Sabo", Items: 3, Revenue: 951}}, nil)
+			},
+			code: http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/stats/top/products",
+			setup:  func() { store.EXPECT().TopProducts(gomock.Any(), gomock.Any()).Return(nil, nil) },
+			code:   http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/admin/dlq?limit=10",
+			setup:  func() { dlq.EXPECT().List(gomock.Any(), 10).Return([]broker.DeadLetter{letter}, nil) },
+			code:   http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/admin/dlq?limit=1000",
+			code:   http.StatusBadRequest,
+		},
+		{
+			method: http.MethodGet,
+			target: "/admin/dlq/0/5",
+			setup:  func() { dlq.EXPECT().Get(gomock.Any(), int32(0), int64(5)).Return(letter, nil) },
+			code:   http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/admin/dlq/0/6",
+			setup: func() {
+				dlq.EXPECT().Get(gomock.Any(), int32(0), int64(6)).Return(broker.DeadLetter{}, broker.ErrDeadLetterNotFound)
+			},
+			code: http.StatusNotFound,
+		},
+		{
+			method: http.MethodPost,
+			target: "/admin/dlq/0/5/replay",
+			body:   `{"order_uid":"` + order.UID.String() + `","track_number":"WBILMTESTTRACK"}`,
+			setup:  func() { dlq.EXPECT().Replay(gomock.Any(), int32(0), int64(5), gomock.Any()).Return(nil) },
+			code:   http.StatusNoContent,
+		},
+		{
+			method: http.MethodGet,
+			target: "/admin/cache",
+			code:   http.StatusOK,
+		},
+		{
+			method: http.MethodGet,
+			target: "/admin/cache/" + order.UID.String(),
+			setup:  func() { orders.Set(order.UID, order) },
+			code:   http.StatusOK,
+		},
+		{
+			method: http.MethodDelete,
+			target: "/admin/cache/" + order.UID.String(),
+			code:   http.StatusNoContent,
+		},
+		{
+			method: http.MethodDelete,
+			target: "/admin/cache/" + order.UID.String(),
+			code:   http.StatusNotFound,
+		},
+		{
+			method: http.MethodDelete,
+			target: "/admin/cache",
+			code:   http.StatusNoContent,
+		},
+	}
+
+	covered := make(map[string]bool)
+
+	for _, tt := range cases {
+		t.Run(tt.method+" "+tt.target, func(t *testing.T) {
+			if tt.setup != nil {
+				tt.setup()
+			}
+
+			r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+			if tt.body != "" {
+				r.Header.Set("Content-Type", "application/json")
+			}
+
+			route, params, err := spec.FindRoute(r)
+			require.NoError(t, err, "operation is not documented")
+
+			_, pattern := mux.Handler(r)
+			require.Equal(t, tt.method+" "+route.Path, pattern)
+
+			role := auth.Role(fmt.Sprint(route.Operation.Extensions["x-required-role"]))
+			require.Contains(t, keys, role, "operation has no required role")
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    r,
+				PathParams: params,
+				Route:      route,
+				Options:    &openapi3filter.Options{AuthenticationFunc: openapi3filter.NoopAuthenticationFunc},
+			}
+
+			send := func(key string) *httptest.ResponseRecorder {
+				r := httptest.NewRequest(tt.method, tt.target, strings.NewReader(tt.body))
+				if key != "" {
+					r.Header.Set(auth.HeaderAPIKey, key)
+				}
+
+				w := httptest.NewRecorder()
+				mux.ServeHTTP(w, r)
+
+				err := openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+					RequestValidationInput: input,
+					Status:                 w.Code,
+					Header:                 w.Header(),
+					Body:                   w.Result().Body,
+				})
+				require.NoError(t, err)
+
+				return w
+			}
+
+			require.Equal(t, http.StatusUnauthorized, send("").Code)
+
+			if below, ok := lower[role]; ok {
+				require.Equal(t, http.StatusForbidden, send(keys[below]).Code)
+			}
+
+			if tt.code < http.StatusBadRequest {
+				require.NoError(t, openapi3filter.ValidateRequest(ctx, input))
+				covered[tt.method+" "+route.Path] = true
+			}
+
+			w := send(keys[role])
+			require.Equal(t, tt.code, w.Code, w.Body.String())
+		})
+	}
+
+	for path, item := range doc.Paths.Map() {
+		for method := range item.Operations() {
+			op := fmt.Sprintf("%s %s", method, path)
+			require.True(t, covered[op], "operation %s has no successful case", op)
+		}
+	}
+}
+
+// TestRoutesDocumented checks the other direction of TestContract: every
+// route of the API is documented.
+func TestRoutesDocumented(t *testing.T) {
+	doc, err := openapi3.NewLoader().LoadFromData(openapi.Spec)
+	require.NoError(t, err)
+
+	// pages and documents served next to the API are not part of it
+	undocumented := map[string]bool{
+		"GET /search":       true,
+		"GET /docs":         true,
+		"GET /openapi.json": true,
+		"/metrics":          true,
+	}
+
+	for _, route := range routes(nil, nil, nil, testAuth(t), "../../../template/index.html") {
+		if undocumented[route.pattern] {
+			continue
+		}
+
+		method, path, _ := strings.Cut(route.pattern, " ")
+
+		item := doc.Paths.Value(path)
+		require.NotNil(t, item, "route %s is not documented", route.pattern)
+		require.NotNil(t, item.GetOperation(method), "route %s is not documented", route.pattern)
+	}
+}
+
+func TestOpenAPI(t *testing.T) {
+	mux := New(nil, nil, nil, testAuth(t), "../../../template/index.html")
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/openapi.json", nil))
+
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	require.JSONEq(t, string(openapi.Spec), w.Body.String())
+}
@@ -0,0 +1,113 @@
+package rpc
+
+import (
+	"context"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/imotkin/L0/internal/auth"
+	orderv1 "github.com/imotkin/L0/pkg/pb/order/v1"
+)
+
+// methodRoles are the lowest roles granted the methods, as in the HTTP API.
+// Methods of other services (health, reflection) are public.
+var methodRoles = map[string]auth.Role{
+	orderv1.OrderService_GetOrder_FullMethodName:    auth.RoleViewer,
+	orderv1.OrderService_ListOrders_FullMethodName:  auth.RoleSupport,
+	orderv1.OrderService_SubmitOrder_FullMethodName: auth.RoleAdmin,
+}
+
+// requestRole returns the role a request needs above the role of its
+// method. Listing without a limit streams all orders, which is an export
+// granted to admins as GET /orders/export.
+func requestRole(req any) (auth.Role, bool) {
+	if r, ok := req.(*orderv1.ListOrdersRequest); ok && r.GetLimit() == 0 {
+		return auth.RoleAdmin, true
+	}
+
+	return "", false
+}
+
+// authorize checks the credentials of the call metadata with the
+// authenticator of the HTTP API and keeps the caller in the context.
+func authorize(ctx context.Context, a auth.Authenticator, method string) (context.Context, error) {
+	role, ok := methodRoles[method]
+	if !ok {
+		return ctx, nil
+	}
+
+	md, _ := metadata.FromIncomingContext(ctx)
+	r := &http.Request{Header: make(http.Header)}
+
+	for _, key := range []string{auth.HeaderAPIKey, "Authorization"} {
+		if v := md.Get(key); len(v) > 0 {
+			r.Header.Set(key, v[0])
+		}
+	}
+
+	id, err := a.Authenticate(r)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication is required")
+	}
+
+	if !id.Role.Allows(role) {
+		return nil, status.Errorf(codes.PermissionDenied, "role %q is required", role)
+	}
+
+	return auth.WithIdentity(ctx, id), nil
+}
+
+func authUnaryInterceptor(a auth.Authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authorize(ctx, a, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+func authStreamInterceptor(a auth.Authenticator) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authorize(ss.Context(), a, info.FullMethod)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &identityStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// identityStream passes the context with the caller to the stream handler
+// and checks the role of the request it receives.
+type identityStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *identityStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *identityStream) RecvMsg(m any) error {
+	err := s.ServerStream.RecvMsg(m)
+	if err != nil {
+		return err
+	}
+
+	role, ok := requestRole(m)
+	if !ok {
+		return nil
+	}
+
+	if id, _ := auth.FromContext(s.ctx); !id.Role.Allows(role) {
+		return status.Errorf(codes.PermissionDenied, "role %q is required", role)
+	}
+
+	return nil
+}
@@ -0,0 +1,30 @@
+package rpc
+
+import (
+	"net"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/go-ozzo/ozzo-validation/v4/is"
+)
+
+const defaultPort = "9090"
+
+type Config struct {
+	Host string `koanf:"host"`
+	Port string `koanf:"port"`
+}
+
+func (c *Config) Addr() string {
+	return net.JoinHostPort(c.Host, c.Port)
+}
+
+func (c *Config) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Host, validation.Required, is.Host),
+		validation.Field(&c.Port, validation.Required, is.Port),
+	)
+}
+
+func defaultConfig() *Config {
+	return &Config{Port: defaultPort}
+}
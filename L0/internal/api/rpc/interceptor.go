@@ -0,0 +1,23 @@
+package rpc
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+func unaryInterceptor(mc metrics.Metrics) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		mc.IncRequests()
+		return handler(ctx, req)
+	}
+}
+
+func streamInterceptor(mc metrics.Metrics) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		mc.IncRequests()
+		return handler(srv, ss)
+	}
+}
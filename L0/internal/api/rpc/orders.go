@@ -0,0 +1,181 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/imotkin/L0/internal/convert"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/pii"
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/service"
+	orderv1 "github.com/imotkin/L0/pkg/pb/order/v1"
+)
+
+var errLimitReached = errors.New("limit reached")
+
+type orderServer struct {
+	orderv1.UnimplementedOrderServiceServer
+	s   service.Service
+	log logger.Logger
+}
+
+func newOrderServer(log logger.Logger, s service.Service) *orderServer {
+	return &orderServer{s: s, log: log}
+}
+
+func (o *orderServer) GetOrder(ctx context.Context, req *orderv1.GetOrderRequest) (*orderv1.GetOrderResponse, error) {
+	id, err := uuid.Parse(req.GetOrderUid())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order_uid: %v", err)
+	}
+
+	order, err := o.s.Get(ctx, id)
+	if err != nil {
+		if errors.Is(err, entity.ErrOrderNotFound) {
+			return nil, status.Errorf(codes.NotFound, "order %q is not found", id)
+		}
+
+		return nil, o.error(ctx, "failed to get order", err)
+	}
+
+	if pii.Masked(ctx) {
+		order = pii.MaskOrder(order)
+	}
+
+	return &orderv1.GetOrderResponse{Order: convert.OrderToProto(order)}, nil
+}
+
+func (o *orderServer) ListOrders(req *orderv1.ListOrdersRequest, stream orderv1.OrderService_ListOrdersServer) error {
+	query, err := listQuery(req)
+	if err != nil {
+		return status.Errorf(codes.InvalidArgument, "invalid list query: %v", err)
+	}
+
+	var (
+		sent   int64
+		masked = pii.Masked(stream.Context())
+	)
+
+	err = o.s.Export(stream.Context(), query, func(order entity.Order) error {
+		if masked {
+			order = pii.MaskOrder(order)
+		}
+
+		err := stream.Send(&orderv1.ListOrdersResponse{Order: convert.OrderToProto(order)})
+		if err != nil {
+			return fmt.Errorf("send order: %w", err)
+		}
+
+		sent++
+
+		if sent == req.GetLimit() {
+			return errLimitReached
+		}
+
+		return nil
+	})
+	if err != nil && !errors.Is(err, errLimitReached) {
+		return o.error(stream.Context(), "failed to list orders", err)
+	}
+
+	return nil
+}
+
+func listQuery(req *orderv1.ListOrdersRequest) (repo.ListQuery, error) {
+	if req.GetLimit() < 0 {
+		return repo.ListQuery{}, errors.New("limit: must be no less than 0")
+	}
+
+	query := repo.ListQuery{
+		CustomerID:      req.GetCustomerId(),
+		TrackNumber:     req.GetTrackNumber(),
+		DeliveryService: req.GetDeliveryService(),
+		Locale:          req.GetLocale(),
+	}
+
+	if req.GetFrom() != nil {
+		query.From = req.GetFrom().AsTime()
+	}
+
+	if req.GetTo() != nil {
+		query.To = req.GetTo().AsTime()
+	}
+
+	if req.GetCursor() != "" {
+		after, err := repo.ParseCursor(req.GetCursor())
+		if err != nil {
+			return repo.ListQuery{}, fmt.Errorf("parse cursor: %w", err)
+		}
+
+		query.After = after
+	}
+
+	return query, query.Validate()
+}
+
+func (o *orderServer) SubmitOrder(ctx context.Context, req *orderv1.SubmitOrderRequest) (*orderv1.SubmitOrderResponse, error) {
+	if req.GetOrder() == nil {
+		return nil, status.Error(codes.InvalidArgument, "order is required")
+	}
+
+	order, err := convert.OrderFromProto(req.GetOrder())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "invalid order: %v", err)
+	}
+
+	res, err := o.s.Submit(ctx, order)
+	if err != nil {
+		return nil, o.error(ctx, "failed to submit order", err)
+	}
+
+	if res.Status == service.SubmitInvalid {
+		return nil, invalidOrder(res.Errors)
+	}
+
+	return &orderv1.SubmitOrderResponse{
+		OrderUid: res.UID.String(),
+		Status:   string(res.Status),
+	}, nil
+}
+
+func invalidOrder(errs validation.Errors) error {
+	st := status.New(codes.InvalidArgument, "invalid order")
+
+	violations := make([]*errdetails.BadRequest_FieldViolation, 0, len(errs))
+	for _, field := range slices.Sorted(maps.Keys(errs)) {
+		violations = append(violations, &errdetails.BadRequest_FieldViolation{
+			Field:       field,
+			Description: errs[field].Error(),
+		})
+	}
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: violations})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
+
+// error keeps the status of a cancelled or timed out call, other errors are
+// logged and reported as internal without the cause.
+func (o *orderServer) error(ctx context.Context, msg string, err error) error {
+	if ctx.Err() != nil {
+		return status.FromContextError(ctx.Err()).Err()
+	}
+
+	o.log.Error(err, msg)
+
+	return status.Error(codes.Internal, msg)
+}
@@ -0,0 +1,136 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/service"
+	orderv1 "github.com/imotkin/L0/pkg/pb/order/v1"
+)
+
+const shutdownTimeout = 5 * time.Second
+
+type Server struct {
+	srv    *grpc.Server
+	health *health.Server
+	addr   string
+	log    logger.Logger
+}
+
+type options struct {
+	auth auth.Authenticator
+}
+
+type Option func(*options)
+
+// WithAuth requires the callers of the order methods to be granted the roles
+// of the matching HTTP routes.
+func WithAuth(a auth.Authenticator) Option {
+	return func(o *options) {
+		o.auth = a
+	}
+}
+
+// New creates a server, it listens on all interfaces on port 9090 when cfg
+// is nil.
+func New(log logger.Logger, cfg *Config, s service.Service, mc metrics.Metrics, opts ...Option) *Server {
+	if cfg == nil {
+		cfg = defaultConfig()
+	}
+
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	log = log.With("source", "grpc-server")
+
+	var (
+		unary  = []grpc.UnaryServerInterceptor{unaryInterceptor(mc)}
+		stream = []grpc.StreamServerInterceptor{streamInterceptor(mc)}
+	)
+
+	if o.auth != nil {
+		unary = append(unary, authUnaryInterceptor(o.auth))
+		stream = append(stream, authStreamInterceptor(o.auth))
+	}
+
+	srv := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(unary...),
+		grpc.ChainStreamInterceptor(stream...),
+	)
+
+	orderv1.RegisterOrderServiceServer(srv, newOrderServer(log, s))
+
+	hs := health.NewServer()
+	hs.SetServingStatus(orderv1.OrderService_ServiceDesc.ServiceName, healthv1.HealthCheckResponse_SERVING)
+	healthv1.RegisterHealthServer(srv, hs)
+
+	reflection.Register(srv)
+
+	return &Server{
+		srv:    srv,
+		health: hs,
+		addr:   cfg.Addr(),
+		log:    log,
+	}
+}
+
+func (s *Server) Start(ctx context.Context) error {
+	lis, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listen: %w", err)
+	}
+
+	return s.Serve(ctx, lis)
+}
+
+// Serve accepts connections on lis until ctx is done, then stops the server
+// gracefully. Streams that don't finish in time are cancelled.
+func (s *Server) Serve(ctx context.Context, lis net.Listener) error {
+	errs := make(chan error, 1)
+
+	go func() {
+		s.log.Info("started grpc server", "addr", lis.Addr().String())
+		errs <- s.srv.Serve(lis)
+	}()
+
+	select {
+	case err := <-errs:
+		return fmt.Errorf("serve grpc: %w", err)
+	case <-ctx.Done():
+	}
+
+	s.health.Shutdown()
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.srv.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(shutdownTimeout):
+		s.srv.Stop()
+	}
+
+	err := <-errs
+	if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return fmt.Errorf("serve grpc: %w", err)
+	}
+
+	return nil
+}
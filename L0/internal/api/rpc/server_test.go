@@ -0,0 +1,365 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthv1 "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	reflectionv1 "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/convert"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/entity/entitytest"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/pii"
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/service"
+	orderv1 "github.com/imotkin/L0/pkg/pb/order/v1"
+)
+
+func TestServer(t *testing.T) {
+	var (
+		ctrl        = gomock.NewController(t)
+		s           = service.NewMockService(ctrl)
+		mc          = metrics.NewMockMetrics(ctrl)
+		lis         = bufconn.Listen(1 << 20)
+		srv         = New(logger.NewNoOp(), &Config{Host: "localhost", Port: "0"}, s, mc)
+		ctx, cancel = context.WithCancel(context.Background())
+		done        = make(chan error, 1)
+	)
+
+	mc.EXPECT().IncRequests().AnyTimes()
+
+	go func() {
+		done <- srv.Serve(ctx, lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		conn.Close()
+		cancel()
+		require.NoError(t, <-done)
+	}()
+
+	client := orderv1.NewOrderServiceClient(conn)
+
+	t.Run("GetOrder", func(t *testing.T) {
+		order := entitytest.Order()
+
+		s.EXPECT().Get(gomock.Any(), order.UID).Return(order, nil)
+
+		resp, err := client.GetOrder(context.Background(), &orderv1.GetOrderRequest{OrderUid: order.UID.String()})
+		require.NoError(t, err)
+
+		got, err := convert.OrderFromProto(resp.GetOrder())
+		require.NoError(t, err)
+		require.Equal(t, order, got)
+
+		s.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Order{}, entity.ErrOrderNotFound)
+
+		_, err = client.GetOrder(context.Background(), &orderv1.GetOrderRequest{OrderUid: uuid.NewString()})
+		require.Equal(t, codes.NotFound, status.Code(err))
+
+		s.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Order{}, errors.New("connection refused"))
+
+		_, err = client.GetOrder(context.Background(), &orderv1.GetOrderRequest{OrderUid: uuid.NewString()})
+		require.Equal(t, codes.Internal, status.Code(err))
+		require.NotContains(t, err.Error(), "connection refused")
+
+		_, err = client.GetOrder(context.Background(), &orderv1.GetOrderRequest{OrderUid: "first"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("ListOrders", func(t *testing.T) {
+		orders := []entity.Order{entitytest.Order(), entitytest.Order(), entitytest.Order()}
+
+		export := func(_ context.Context, _ repo.ListQuery, fn func(entity.Order) error) error {
+			for _, order := range orders {
+				if err := fn(order); err != nil {
+					return err
+				}
+			}
+
+			return nil
+		}
+
+		receive := func(req *orderv1.ListOrdersRequest) ([]uuid.UUID, error) {
+			stream, err := client.ListOrders(context.Background(), req)
+			require.NoError(t, err)
+
+			var ids []uuid.UUID
+
+			for {
+				resp, err := stream.Recv()
+				if errors.Is(err, io.EOF) {
+					return ids, nil
+				}
+
+				if err != nil {
+					return ids, err
+				}
+
+				ids = append(ids, uuid.MustParse(resp.GetOrder().GetOrderUid()))
+			}
+		}
+
+		s.EXPECT().Export(gomock.Any(), repo.ListQuery{Locale: "en"}, gomock.Any()).DoAndReturn(export)
+
+		ids, err := receive(&orderv1.ListOrdersRequest{Locale: "en"})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{orders[0].UID, orders[1].UID, orders[2].UID}, ids)
+
+		s.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(export)
+
+		ids, err = receive(&orderv1.ListOrdersRequest{Limit: 2})
+		require.NoError(t, err)
+		require.Equal(t, []uuid.UUID{orders[0].UID, orders[1].UID}, ids)
+
+		_, err = receive(&orderv1.ListOrdersRequest{Cursor: "first"})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		_, err = receive(&orderv1.ListOrdersRequest{Limit: -1})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("SubmitOrder", func(t *testing.T) {
+		order := entitytest.Order()
+
+		s.EXPECT().Submit(gomock.Any(), order).Return(service.Result{UID: order.UID, Status: service.SubmitCreated}, nil)
+
+		resp, err := client.SubmitOrder(context.Background(), &orderv1.SubmitOrderRequest{Order: convert.OrderToProto(order)})
+		require.NoError(t, err)
+		require.Equal(t, order.UID.String(), resp.GetOrderUid())
+		require.Equal(t, string(service.SubmitCreated), resp.GetStatus())
+
+		s.EXPECT().Submit(gomock.Any(), gomock.Any()).Return(service.Result{
+			UID:    order.UID,
+			Status: service.SubmitInvalid,
+			Errors: validation.Errors{"locale": validation.ErrRequired, "entry": validation.ErrRequired},
+		}, nil)
+
+		_, err = client.SubmitOrder(context.Background(), &orderv1.SubmitOrderRequest{Order: convert.OrderToProto(order)})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+
+		details := status.Convert(err).Details()
+		require.Len(t, details, 1)
+
+		violations := details[0].(*errdetails.BadRequest).GetFieldViolations()
+		require.Len(t, violations, 2)
+		require.Equal(t, "entry", violations[0].GetField())
+		require.Equal(t, "locale", violations[1].GetField())
+
+		_, err = client.SubmitOrder(context.Background(), &orderv1.SubmitOrderRequest{})
+		require.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("HealthAndReflection", func(t *testing.T) {
+		resp, err := healthv1.NewHealthClient(conn).Check(context.Background(), &healthv1.HealthCheckRequest{
+			Service: orderv1.OrderService_ServiceDesc.ServiceName,
+		})
+		require.NoError(t, err)
+		require.Equal(t, healthv1.HealthCheckResponse_SERVING, resp.GetStatus())
+
+		stream, err := reflectionv1.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+		require.NoError(t, err)
+
+		err = stream.Send(&reflectionv1.ServerReflectionRequest{
+			MessageRequest: &reflectionv1.ServerReflectionRequest_ListServices{},
+		})
+		require.NoError(t, err)
+
+		info, err := stream.Recv()
+		require.NoError(t, err)
+
+		var services []string
+		for _, svc := range info.GetListServicesResponse().GetService() {
+			services = append(services, svc.GetName())
+		}
+
+		require.Contains(t, services, orderv1.OrderService_ServiceDesc.ServiceName)
+		require.NoError(t, stream.CloseSend())
+	})
+}
+
+func withKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "x-api-key", key)
+}
+
+func TestAuth(t *testing.T) {
+	authn, err := auth.New(&auth.Config{
+		Enabled: true,
+		APIKeys: []auth.APIKey{
+			{Name: "dashboard", Key: "viewer-key-0123456789", Role: auth.RoleViewer},
+			{Name: "support", Key: "support-key-0123456789", Role: auth.RoleSupport},
+			{Name: "admin", Key: "admin-key-0123456789", Role: auth.RoleAdmin},
+		},
+	})
+	require.NoError(t, err)
+
+	var (
+		ctrl        = gomock.NewController(t)
+		s           = service.NewMockService(ctrl)
+		mc          = metrics.NewMockMetrics(ctrl)
+		lis         = bufconn.Listen(1 << 20)
+		srv         = New(logger.NewNoOp(), &Config{Host: "localhost", Port: "0"}, s, mc, WithAuth(authn))
+		ctx, cancel = context.WithCancel(context.Background())
+		done        = make(chan error, 1)
+	)
+
+	mc.EXPECT().IncRequests().AnyTimes()
+
+	go func() {
+		done <- srv.Serve(ctx, lis)
+	}()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		conn.Close()
+		cancel()
+		require.NoError(t, <-done)
+	}()
+
+	client := orderv1.NewOrderServiceClient(conn)
+
+	order := entitytest.Order()
+
+	t.Run("Roles", func(t *testing.T) {
+		var err error
+
+		_, err = client.GetOrder(context.Background(), &orderv1.GetOrderRequest{OrderUid: order.UID.String()})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		_, err = client.GetOrder(withKey("unknown-key-0123456789"), &orderv1.GetOrderRequest{OrderUid: order.UID.String()})
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		s.EXPECT().Get(gomock.Any(), order.UID).DoAndReturn(func(ctx context.Context, _ uuid.UUID) (entity.Order, error) {
+			id, ok := auth.FromContext(ctx)
+			require.True(t, ok)
+			require.Equal(t, "dashboard", id.Subject)
+
+			return order, nil
+		})
+
+		_, err = client.GetOrder(withKey("viewer-key-0123456789"), &orderv1.GetOrderRequest{OrderUid: order.UID.String()})
+		require.NoError(t, err)
+
+		stream, err := client.ListOrders(context.Background(), &orderv1.ListOrdersRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+		s.EXPECT().Export(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, _ repo.ListQuery, _ func(entity.Order) error) error {
+				_, ok := auth.FromContext(ctx)
+				require.True(t, ok)
+
+				return nil
+			}).Times(2)
+
+		// listing with a limit is granted to support as GET /orders, and
+		// streaming all orders only to admins as GET /orders/export
+		lists := []struct {
+			key  string
+			req  *orderv1.ListOrdersRequest
+			code codes.Code
+		}{
+			{key: "viewer-key-0123456789", req: &orderv1.ListOrdersRequest{Limit: 10}, code: codes.PermissionDenied},
+			{key: "support-key-0123456789", req: &orderv1.ListOrdersRequest{Limit: 10}, code: codes.OK},
+			{key: "support-key-0123456789", req: &orderv1.ListOrdersRequest{}, code: codes.PermissionDenied},
+			{key: "admin-key-0123456789", req: &orderv1.ListOrdersRequest{}, code: codes.OK},
+		}
+
+		for _, tt := range lists {
+			stream, err = client.ListOrders(withKey(tt.key), tt.req)
+			require.NoError(t, err)
+
+			_, err = stream.Recv()
+			if tt.code == codes.OK {
+				require.ErrorIs(t, err, io.EOF)
+			} else {
+				require.Equal(t, tt.code, status.Code(err), tt.key)
+			}
+		}
+
+		req := &orderv1.SubmitOrderRequest{Order: convert.OrderToProto(order)}
+
+		_, err = client.SubmitOrder(withKey("support-key-0123456789"), req)
+		require.Equal(t, codes.PermissionDenied, status.Code(err))
+
+		s.EXPECT().Submit(gomock.Any(), order).Return(service.Result{UID: order.UID, Status: service.SubmitCreated}, nil)
+
+		_, err = client.SubmitOrder(withKey("admin-key-0123456789"), req)
+		require.NoError(t, err)
+
+		_, err = healthv1.NewHealthClient(conn).Check(context.Background(), &healthv1.HealthCheckRequest{})
+		require.NoError(t, err)
+	})
+
+	t.Run("MaskOrders", func(t *testing.T) {
+		s.EXPECT().Get(gomock.Any(), order.UID).Return(order, nil).AnyTimes()
+
+		tests := []struct {
+			name     string
+			key      string
+			delivery entity.Delivery
+		}{
+			{
+				name:     "viewer",
+				key:      "viewer-key-0123456789",
+				delivery: pii.MaskOrder(order).Delivery,
+			},
+			{
+				name:     "support",
+				key:      "support-key-0123456789",
+				delivery: order.Delivery,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				resp, err := client.GetOrder(withKey(tt.key), &orderv1.GetOrderRequest{OrderUid: order.UID.String()})
+				require.NoError(t, err)
+
+				got, err := convert.OrderFromProto(resp.GetOrder())
+				require.NoError(t, err)
+				require.Equal(t, tt.delivery, got.Delivery)
+			})
+		}
+	})
+}
+
+func TestDefaultConfig(t *testing.T) {
+	srv := New(logger.NewNoOp(), nil, nil, nil)
+	require.Equal(t, ":9090", srv.addr)
+}
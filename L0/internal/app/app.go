@@ -4,92 +4,45 @@ import (
 	"context"
 	"flag"
 	"fmt"
+	"io"
+	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"golang.org/x/sync/errgroup"
 
 	"github.com/imotkin/L0/internal/api/handler"
 	"github.com/imotkin/L0/internal/api/router"
+	"github.com/imotkin/L0/internal/api/rpc"
 	"github.com/imotkin/L0/internal/api/server"
+	"github.com/imotkin/L0/internal/auth"
 	"github.com/imotkin/L0/internal/broker"
 	"github.com/imotkin/L0/internal/cache"
 	"github.com/imotkin/L0/internal/config"
 	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/entity/entitytest"
 	"github.com/imotkin/L0/internal/healthcheck"
 	"github.com/imotkin/L0/internal/logger"
 	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/outbox"
+	"github.com/imotkin/L0/internal/pii"
 	"github.com/imotkin/L0/internal/repo/postgres"
 	"github.com/imotkin/L0/internal/service"
+	"github.com/imotkin/L0/internal/stats"
 )
 
+// TestOrder returns an order of a new customer for the test publisher.
 func TestOrder() (key string, v any) {
-	id := uuid.New()
-
-	return id.String(), entity.Order{
-		UID:         id,
-		TrackNumber: uuid.NewString(),
-		Entry:       "WBIL",
-		Delivery: entity.Delivery{
-			Name:    "Иван Иванов",
-			Phone:   "+79999999999",
-			Zip:     "101000",
-			City:    "Москва",
-			Address: "Площадь Мира, стр. 15",
-			Region:  "Центральный",
-			Email:   "ivanov@example.com",
-		},
-		Payment: entity.Payment{
-			Transaction:  uuid.New(),
-			RequestID:    uuid.NewString(),
-			Currency:     "USD",
-			Provider:     "wbpay",
-			Amount:       1817,
-			PaymentDt:    1637907727,
-			Bank:         "alpha",
-			DeliveryCost: 1500,
-			GoodsTotal:   317,
-			CustomFee:    0,
-		},
-		Items: []entity.Item{
-			{
-				ChrtID:      9934930,
-				TrackNumber: "WBILMTESTTRACK",
-				Price:       453,
-				RID:         uuid.New(),
-				Name:        "Product 1",
-				Sale:        30,
-				Size:        "0",
-				TotalPrice:  317,
-				NmID:        2389212,
-				Brand:       "ABC",
-				Status:      202,
-			},
-			{
-				ChrtID:      9934931,
-				TrackNumber: "WBILMTESTTRACK",
-				Price:       453,
-				RID:         uuid.New(),
-				Name:        "Product 2",
-				Sale:        30,
-				Size:        "0",
-				TotalPrice:  317,
-				NmID:        2389212,
-				Brand:       "DEF",
-				Status:      202,
-			},
-		},
-		Locale:            "en",
-		InternalSignature: "sign-123",
-		CustomerID:        uuid.NewString(),
-		DeliveryService:   "DHL",
-		ShardKey:          "9",
-		SmID:              99,
-		DateCreated:       time.Now(),
-		Shard:             "1",
-	}
+	order := entitytest.Order()
+	order.TrackNumber = uuid.NewString()
+	order.CustomerID = uuid.NewString()
+	order.DateCreated = time.Now()
+
+	return order.UID.String(), order
 }
 
 var configPath = flag.String("config", "config.example.yaml", "path to config file")
@@ -119,7 +72,18 @@ func Run() error {
 	ctx, cancel := signal.NotifyContext(context.Background(), signals...)
 	defer cancel()
 
-	pg, err := postgres.New(ctx, cfg.Postgres.ConnectionURL())
+	keys, err := newKeyRing(cfg.PII)
+	if err != nil {
+		return err
+	}
+
+	var pgOpts []postgres.Option
+
+	if keys != nil {
+		pgOpts = append(pgOpts, postgres.WithKeyRing(keys))
+	}
+
+	pg, err := postgres.New(ctx, cfg.Postgres.ConnectionURL(), pgOpts...)
 	if err != nil {
 		return fmt.Errorf("create postgres: %w", err)
 	}
@@ -134,28 +98,196 @@ func Run() error {
 		return fmt.Errorf("create metrics client: %w", err)
 	}
 
-	pub, err := broker.NewPublisher(log, cfg.Broker)
+	schema := service.OrderSchema()
+
+	pub, err := broker.NewPublisher(log, cfg.Broker, cfg.Broker.Topic,
+		broker.WithTopicSchema(cfg.Broker.Topic, schema),
+	)
 	if err != nil {
 		return fmt.Errorf("create producer: %w", err)
 	}
 
+	eventPub, err := broker.NewPublisher(log, cfg.Broker, cfg.Broker.TopicAccepted,
+		broker.WithTopicSchema(cfg.Broker.TopicAccepted, schema),
+	)
+	if err != nil {
+		return fmt.Errorf("create events producer: %w", err)
+	}
+	defer eventPub.Close()
+
+	topics := map[entity.EventType]string{
+		entity.EventOrderAccepted: cfg.Broker.TopicAccepted,
+		entity.EventStatusChanged: cfg.Broker.TopicStatus,
+	}
+
+	relay := outbox.NewRelay(log, cfg.Outbox, pg, eventPub, topics, m)
+
+	go stats.NewRefresher(log, cfg.Stats, pg).Run(ctx)
+
 	pub.IntervalPublish(ctx, TestOrder, cfg.Broker.Interval)
 
-	sub, err := broker.NewSubscriber[entity.Order](log, cfg.Broker, m)
+	sub, err := broker.NewSubscriber[entity.Order](log, cfg.Broker, m, broker.WithSchema(schema))
 	if err != nil {
 		return fmt.Errorf("create producer: %w", err)
 	}
 
+	dlq, err := broker.NewDLQ(log, cfg.Broker)
+	if err != nil {
+		return fmt.Errorf("create dlq client: %w", err)
+	}
+	defer dlq.Close()
+
 	go healthcheck.Run(ctx, time.Second*10, sub, pg, m)
 
+	orders, err := newOrderCache(ctx, log, cfg.Cache, keys)
+	if err != nil {
+		return fmt.Errorf("create cache: %w", err)
+	}
+
+	if closer, ok := orders.(io.Closer); ok {
+		defer closer.Close()
+	}
+
+	if cfg.Cache.TracePath != "" {
+		f, err := os.OpenFile(cfg.Cache.TracePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			return fmt.Errorf("open cache trace: %w", err)
+		}
+		defer f.Close()
+
+		recorder := cache.NewRecorder(orders, f)
+		defer recorder.Flush()
+
+		orders = recorder
+	}
+
+	var opts []service.Option
+
+	if cfg.Cache.Warmup != nil {
+		opts = append(opts, service.WithWarmup(cfg.Cache.Warmup))
+	}
+
+	var adminOpts []handler.AdminOption
+
+	if cfg.Cache.NegativeTTL > 0 {
+		notFound, err := cache.New(cfg.Cache.NegativeSize, cache.WithTTL[uuid.UUID, struct{}](cfg.Cache.NegativeTTL))
+		if err != nil {
+			return fmt.Errorf("create negative cache: %w", err)
+		}
+
+		opts = append(opts, service.WithNegativeCache(notFound))
+		adminOpts = append(adminOpts, handler.WithNegativeCache(notFound))
+	}
+
+	authn, err := auth.New(cfg.Auth)
+	if err != nil {
+		return fmt.Errorf("create authenticator: %w", err)
+	}
+
 	var (
-		c = cache.New[uuid.UUID, entity.Order](cfg.Cache.Size)
-		s = service.New(log, pg, c, m)
-		h = handler.New(log, s, m)
-		r = router.New(h, cfg.Web.TemplatePath)
+		s  = service.New(log, pg, orders, m, opts...)
+		h  = handler.New(log, s, m)
+		a  = handler.NewAdmin(log, dlq, orders, m, adminOpts...)
+		st = handler.NewStats(log, pg, m)
+		au = handler.NewAuth(log, cfg.Auth, authn)
+		r  = router.New(h, a, st, au, cfg.Web.TemplatePath)
 	)
 
 	s.Run(ctx, sub)
 
-	return server.New(log, cfg.Server, r).Start(ctx)
+	var api http.Handler = r
+
+	if cfg.Limits != nil {
+		limiter := handler.NewLimiter(log, cfg.Limits, m)
+		go limiter.Run(ctx)
+
+		api = limiter.Wrap(r)
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		return server.New(log, cfg.Server, api).Start(gctx)
+	})
+
+	var rpcOpts []rpc.Option
+
+	if cfg.Auth.Enabled {
+		rpcOpts = append(rpcOpts, rpc.WithAuth(authn))
+	}
+
+	g.Go(func() error {
+		return rpc.New(log, cfg.GRPC, s, m, rpcOpts...).Start(gctx)
+	})
+
+	g.Go(func() error {
+		relay.Run(gctx)
+		return nil
+	})
+
+	err = g.Wait()
+
+	cancel()
+	sub.Wait()
+
+	if err := s.SaveSnapshot(); err != nil {
+		log.Error(err, "failed to save cache snapshot")
+	}
+
+	return err
+}
+
+func newOrderCache(
+	ctx context.Context,
+	log logger.Logger,
+	cfg *cache.Config,
+	keys *pii.KeyRing,
+) (cache.Cache[uuid.UUID, entity.Order], error) {
+	if cfg.Backend == cache.BackendRedis {
+		return newRedisCache(log, cfg, keys)
+	}
+
+	local, err := cache.NewSharded(cfg.Shards, cfg.Size, cache.Options[uuid.UUID, entity.Order](cfg)...)
+	if err != nil {
+		return nil, fmt.Errorf("create local cache: %w", err)
+	}
+
+	local.OnEvict(func(id uuid.UUID, _ entity.Order, reason cache.EvictReason) {
+		log.Debug("order was evicted from cache", "uid", id, "reason", reason)
+	})
+
+	if cfg.TTL > 0 {
+		go local.Run(ctx, cfg.CleanupInterval)
+	}
+
+	if cfg.Backend != cache.BackendTiered {
+		return local, nil
+	}
+
+	remote, err := newRedisCache(log, cfg, keys)
+	if err != nil {
+		return nil, err
+	}
+
+	tiered := cache.NewTiered(log, local, remote, cfg.Redis.Channel)
+	go tiered.Run(ctx)
+
+	return tiered, nil
+}
+
+// newRedisCache seals the personal data of the cached orders when the
+// encryption is enabled.
+func newRedisCache(log logger.Logger, cfg *cache.Config, keys *pii.KeyRing) (*cache.RedisCache[uuid.UUID, entity.Order], error) {
+	var opts []cache.RedisOption[uuid.UUID, entity.Order]
+
+	if keys != nil {
+		serializer, err := cache.NewSerializer(cfg.Redis.Serializer)
+		if err != nil {
+			return nil, err
+		}
+
+		opts = append(opts, cache.WithSerializer[uuid.UUID, entity.Order](pii.NewSerializer(keys, serializer)))
+	}
+
+	return cache.NewRedis(log, redis.NewClient(cfg.Redis.Options()), cfg, opts...)
 }
@@ -0,0 +1,65 @@
+package app
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/imotkin/L0/internal/cache"
+)
+
+const cacheUsage = `usage: app cache <command> [flags]
+
+commands:
+  replay  replay a recorded access log and report the hit ratio per policy`
+
+func RunCache(args []string) error {
+	if len(args) == 0 || args[0] != "replay" {
+		return errors.New(cacheUsage)
+	}
+
+	var (
+		fs = flag.NewFlagSet("cache replay", flag.ContinueOnError)
+
+		tracePath = fs.String("trace", "", "path to recorded access log")
+		size      = fs.Int("size", 100, "cache capacity")
+		policies  = fs.String("policies", "lru,lfu,arc,tinylfu", "comma-separated eviction policies")
+	)
+
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	if *tracePath == "" {
+		return errors.New("trace path is required")
+	}
+
+	f, err := os.Open(*tracePath)
+	if err != nil {
+		return fmt.Errorf("open trace: %w", err)
+	}
+	defer f.Close()
+
+	trace, err := cache.ReadTrace(f)
+	if err != nil {
+		return fmt.Errorf("read trace: %w", err)
+	}
+
+	results, err := cache.Replay(trace, *size, strings.Split(*policies, ",")...)
+	if err != nil {
+		return fmt.Errorf("replay trace: %w", err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "POLICY\tHITS\tMISSES\tHIT RATIO")
+
+	for _, r := range results {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%.2f%%\n", r.Policy, r.Hits, r.Misses, r.HitRatio()*100)
+	}
+
+	return w.Flush()
+}
@@ -0,0 +1,106 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/imotkin/L0/internal/broker"
+	"github.com/imotkin/L0/internal/config"
+	"github.com/imotkin/L0/internal/logger"
+)
+
+const dlqUsage = `usage: app dlq <command> [flags]
+
+commands:
+  list    show the latest dlq messages
+  show    show a single dlq message with the failure reason
+  replay  send a dlq message back to the main topic`
+
+func RunDLQ(args []string) error {
+	if len(args) == 0 {
+		return errors.New(dlqUsage)
+	}
+
+	var (
+		cmd = args[0]
+		fs  = flag.NewFlagSet("dlq "+cmd, flag.ContinueOnError)
+
+		configPath = fs.String("config", "config.example.yaml", "path to config file")
+		limit      = fs.Int("limit", 50, "max number of messages to list")
+		partition  = fs.Int("partition", 0, "dlq message partition")
+		offset     = fs.Int64("offset", -1, "dlq message offset")
+		file       = fs.String("file", "", "path to edited message value for replay")
+	)
+
+	err := fs.Parse(args[1:])
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Parse(*configPath)
+	if err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+
+	err = cfg.Broker.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid broker config: %w", err)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	log := logger.New(logger.FormatText, logger.LevelError, os.Stderr)
+
+	dlq, err := broker.NewDLQ(log, cfg.Broker)
+	if err != nil {
+		return fmt.Errorf("create dlq client: %w", err)
+	}
+	defer dlq.Close()
+
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+
+	switch cmd {
+	case "list":
+		letters, err := dlq.List(ctx, *limit)
+		if err != nil {
+			return fmt.Errorf("list dlq messages: %w", err)
+		}
+
+		return enc.Encode(letters)
+	case "show":
+		letter, err := dlq.Get(ctx, int32(*partition), *offset)
+		if err != nil {
+			return fmt.Errorf("get dlq message: %w", err)
+		}
+
+		return enc.Encode(letter)
+	case "replay":
+		var value []byte
+
+		if *file != "" {
+			value, err = os.ReadFile(*file)
+			if err != nil {
+				return fmt.Errorf("read edited message: %w", err)
+			}
+		}
+
+		err = dlq.Replay(ctx, int32(*partition), *offset, value)
+		if err != nil {
+			return fmt.Errorf("replay dlq message: %w", err)
+		}
+
+		fmt.Printf("message %d/%d was replayed\n", *partition, *offset)
+
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q\n%s", cmd, dlqUsage)
+	}
+}
@@ -0,0 +1,123 @@
+package app
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/imotkin/L0/internal/config"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/export"
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/repo/postgres"
+)
+
+func RunExport(args []string) error {
+	var (
+		fs = flag.NewFlagSet("export", flag.ContinueOnError)
+
+		configPath      = fs.String("config", "config.example.yaml", "path to config file")
+		format          = fs.String("format", export.FormatCSV, "output format: csv, ndjson or xlsx")
+		output          = fs.String("output", "", "path to output file, stdout by default")
+		customerID      = fs.String("customer-id", "", "export orders of the customer")
+		trackNumber     = fs.String("track-number", "", "export orders with the track number")
+		deliveryService = fs.String("delivery-service", "", "export orders of the delivery service")
+		locale          = fs.String("locale", "", "export orders with the locale")
+		from            = fs.String("from", "", "export orders created since the time (RFC 3339)")
+		to              = fs.String("to", "", "export orders created before the time (RFC 3339)")
+	)
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	query := repo.ListQuery{
+		CustomerID:      *customerID,
+		TrackNumber:     *trackNumber,
+		DeliveryService: *deliveryService,
+		Locale:          *locale,
+	}
+
+	if *from != "" {
+		query.From, err = time.Parse(time.RFC3339, *from)
+		if err != nil {
+			return fmt.Errorf("parse from: %w", err)
+		}
+	}
+
+	if *to != "" {
+		query.To, err = time.Parse(time.RFC3339, *to)
+		if err != nil {
+			return fmt.Errorf("parse to: %w", err)
+		}
+	}
+
+	err = query.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid query: %w", err)
+	}
+
+	cfg, err := config.Parse(*configPath)
+	if err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+
+	err = cfg.Postgres.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid postgres config: %w", err)
+	}
+
+	pgOpts, err := postgresOptions(cfg.PII)
+	if err != nil {
+		return err
+	}
+
+	var out io.Writer = os.Stdout
+
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			return fmt.Errorf("create output: %w", err)
+		}
+		defer f.Close()
+
+		out = f
+	}
+
+	w, err := export.NewWriter(*format, out)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	pg, err := postgres.New(ctx, cfg.Postgres.ConnectionURL(), pgOpts...)
+	if err != nil {
+		return fmt.Errorf("create postgres: %w", err)
+	}
+
+	var n int
+
+	err = pg.Export(ctx, query, func(order entity.Order) error {
+		n++
+		return w.Write(order)
+	})
+	if cerr := w.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return fmt.Errorf("export orders: %w", err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d orders were exported\n", n)
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/imotkin/L0/internal/config"
+	"github.com/imotkin/L0/internal/pii"
+	"github.com/imotkin/L0/internal/repo/postgres"
+)
+
+// RunRotateKeys reseals the stored personal data with the primary key of the
+// ring. It also encrypts the data stored before the encryption was enabled.
+func RunRotateKeys(args []string) error {
+	var (
+		fs = flag.NewFlagSet("rotate-keys", flag.ContinueOnError)
+
+		configPath = fs.String("config", "config.example.yaml", "path to config file")
+	)
+
+	err := fs.Parse(args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := config.Parse(*configPath)
+	if err != nil {
+		return fmt.Errorf("parse config: %w", err)
+	}
+
+	err = cfg.Postgres.Validate()
+	if err != nil {
+		return fmt.Errorf("invalid postgres config: %w", err)
+	}
+
+	if cfg.PII == nil || !cfg.PII.Enabled {
+		return errors.New("encryption is not enabled")
+	}
+
+	opts, err := postgresOptions(cfg.PII)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer cancel()
+
+	pg, err := postgres.New(ctx, cfg.Postgres.ConnectionURL(), opts...)
+	if err != nil {
+		return fmt.Errorf("create postgres: %w", err)
+	}
+
+	n, err := pg.RotateKeys(ctx)
+	if err != nil {
+		return fmt.Errorf("rotate keys (%d orders were updated): %w", n, err)
+	}
+
+	fmt.Fprintf(os.Stderr, "%d orders were updated\n", n)
+
+	return nil
+}
+
+// postgresOptions encrypts the personal data when the encryption is enabled.
+func postgresOptions(cfg *pii.Config) ([]postgres.Option, error) {
+	keys, err := newKeyRing(cfg)
+	if err != nil || keys == nil {
+		return nil, err
+	}
+
+	return []postgres.Option{postgres.WithKeyRing(keys)}, nil
+}
+
+// newKeyRing returns nil when the encryption is disabled.
+func newKeyRing(cfg *pii.Config) (*pii.KeyRing, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+
+	err := cfg.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid pii config: %w", err)
+	}
+
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	keys, err := pii.NewKeyRing(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create key ring: %w", err)
+	}
+
+	return keys, nil
+}
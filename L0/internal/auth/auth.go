@@ -0,0 +1,211 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	HeaderAPIKey     = "X-API-Key"
+	defaultRoleClaim = "role"
+)
+
+var (
+	ErrNoCredentials      = errors.New("no credentials")
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+type authenticator struct {
+	keys      []apiKey
+	secret    []byte
+	rsaKeys   map[string]*rsa.PublicKey
+	parser    *jwt.Parser
+	roleClaim string
+}
+
+// apiKey keeps the hash of the key, so keys of any length are compared in
+// constant time.
+type apiKey struct {
+	hash [sha256.Size]byte
+	name string
+	role Role
+}
+
+func New(cfg *Config) (Authenticator, error) {
+	a := &authenticator{roleClaim: defaultRoleClaim}
+
+	for _, k := range cfg.APIKeys {
+		a.keys = append(a.keys, apiKey{hash: sha256.Sum256([]byte(k.Key)), name: k.Name, role: k.Role})
+	}
+
+	if cfg.JWT == nil {
+		return a, nil
+	}
+
+	var (
+		methods []string
+		opts    []jwt.ParserOption
+	)
+
+	if cfg.JWT.Secret != "" {
+		a.secret = []byte(cfg.JWT.Secret)
+		methods = append(methods, jwt.SigningMethodHS256.Alg())
+	}
+
+	if cfg.JWT.JWKSPath != "" {
+		keys, err := readJWKS(cfg.JWT.JWKSPath)
+		if err != nil {
+			return nil, err
+		}
+
+		a.rsaKeys = keys
+		methods = append(methods, jwt.SigningMethodRS256.Alg())
+	}
+
+	opts = append(opts, jwt.WithValidMethods(methods), jwt.WithExpirationRequired())
+
+	if cfg.JWT.Issuer != "" {
+		opts = append(opts, jwt.WithIssuer(cfg.JWT.Issuer))
+	}
+
+	if cfg.JWT.Audience != "" {
+		opts = append(opts, jwt.WithAudience(cfg.JWT.Audience))
+	}
+
+	if cfg.JWT.RoleClaim != "" {
+		a.roleClaim = cfg.JWT.RoleClaim
+	}
+
+	a.parser = jwt.NewParser(opts...)
+
+	return a, nil
+}
+
+// Authenticate checks the API key of the X-API-Key header or the bearer
+// token of the Authorization header.
+func (a *authenticator) Authenticate(r *http.Request) (Identity, error) {
+	if key := r.Header.Get(HeaderAPIKey); key != "" {
+		return a.apiKey(key)
+	}
+
+	token, ok := bearer(r)
+	if !ok {
+		return Identity{}, ErrNoCredentials
+	}
+
+	return a.token(token)
+}
+
+// Subject returns the subject of the request credentials without verifying
+// them: the hash of the API key or the subject of the bearer token. It is
+// cheap enough to tell clients apart before they are authenticated, forged
+// credentials are rejected by Authenticate.
+func Subject(r *http.Request) (string, bool) {
+	if key := r.Header.Get(HeaderAPIKey); key != "" {
+		hash := sha256.Sum256([]byte(key))
+		return "key:" + hex.EncodeToString(hash[:]), true
+	}
+
+	token, ok := bearer(r)
+	if !ok {
+		return "", false
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, _, err := jwt.NewParser().ParseUnverified(token, claims)
+	if err != nil {
+		return "", false
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return "", false
+	}
+
+	return "token:" + subject, true
+}
+
+func bearer(r *http.Request) (string, bool) {
+	scheme, token, ok := strings.Cut(r.Header.Get("Authorization"), " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+
+	return token, true
+}
+
+func (a *authenticator) apiKey(key string) (Identity, error) {
+	var (
+		hash  = sha256.Sum256([]byte(key))
+		found *apiKey
+	)
+
+	for i := range a.keys {
+		if subtle.ConstantTimeCompare(hash[:], a.keys[i].hash[:]) == 1 {
+			found = &a.keys[i]
+		}
+	}
+
+	if found == nil {
+		return Identity{}, ErrInvalidCredentials
+	}
+
+	return Identity{Subject: found.name, Role: found.role}, nil
+}
+
+func (a *authenticator) token(raw string) (Identity, error) {
+	if a.parser == nil {
+		return Identity{}, ErrInvalidCredentials
+	}
+
+	claims := jwt.MapClaims{}
+
+	_, err := a.parser.ParseWithClaims(raw, claims, a.key)
+	if err != nil {
+		return Identity{}, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	subject, err := claims.GetSubject()
+	if err != nil || subject == "" {
+		return Identity{}, fmt.Errorf("%w: no subject", ErrInvalidCredentials)
+	}
+
+	role, _ := claims[a.roleClaim].(string)
+	if !Role(role).Allows(RoleViewer) {
+		return Identity{}, fmt.Errorf("%w: unknown role %q", ErrInvalidCredentials, role)
+	}
+
+	return Identity{Subject: subject, Role: Role(role)}, nil
+}
+
+func (a *authenticator) key(token *jwt.Token) (any, error) {
+	switch token.Method.Alg() {
+	case jwt.SigningMethodHS256.Alg():
+		return a.secret, nil
+	case jwt.SigningMethodRS256.Alg():
+		kid, _ := token.Header["kid"].(string)
+
+		if key, ok := a.rsaKeys[kid]; ok {
+			return key, nil
+		}
+
+		if kid == "" && len(a.rsaKeys) == 1 {
+			for _, key := range a.rsaKeys {
+				return key, nil
+			}
+		}
+
+		return nil, fmt.Errorf("unknown key %q", kid)
+	default:
+		return nil, fmt.Errorf("unexpected signing method %s", token.Method.Alg())
+	}
+}
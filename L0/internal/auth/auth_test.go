@@ -0,0 +1,317 @@
+package auth
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func writeJWKS(t *testing.T, kid string, key *rsa.PublicKey) string {
+	set := jwks{Keys: []jwk{{
+		Kty: "RSA",
+		Kid: kid,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}}}
+
+	data, err := json.Marshal(set)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "jwks.json")
+	require.NoError(t, os.WriteFile(path, data, 0o600))
+
+	return path
+}
+
+func TestAuthenticate(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	a, err := New(&Config{
+		APIKeys: []APIKey{
+			{Name: "dashboard", Key: "viewer-key-0123456789", Role: RoleViewer},
+			{Name: "ops", Key: "admin-key-0123456789", Role: RoleAdmin},
+		},
+		JWT: &JWTConfig{
+			Secret:   testSecret,
+			JWKSPath: writeJWKS(t, "main", &rsaKey.PublicKey),
+			Issuer:   "auth.example.com",
+			Audience: "orders",
+		},
+	})
+	require.NoError(t, err)
+
+	claims := func(role string, exp time.Duration) jwt.MapClaims {
+		return jwt.MapClaims{
+			"sub":  "ivanov",
+			"role": role,
+			"iss":  "auth.example.com",
+			"aud":  "orders",
+			"exp":  time.Now().Add(exp).Unix(),
+		}
+	}
+
+	hs256 := func(c jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(testSecret))
+		require.NoError(t, err)
+		return token
+	}
+
+	rs256 := func(kid string, key *rsa.PrivateKey, c jwt.MapClaims) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, c)
+		token.Header["kid"] = kid
+
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	noIssuer := claims("admin", time.Hour)
+	delete(noIssuer, "iss")
+
+	noExpiry := claims("admin", time.Hour)
+	delete(noExpiry, "exp")
+
+	tests := []struct {
+		name     string
+		apiKey   string
+		header   string
+		identity Identity
+		err      error
+	}{
+		{
+			name:     "api key",
+			apiKey:   "admin-key-0123456789",
+			identity: Identity{Subject: "ops", Role: RoleAdmin},
+		},
+		{
+			name:   "unknown api key",
+			apiKey: "admin-key-9876543210",
+			err:    ErrInvalidCredentials,
+		},
+		{
+			name: "no credentials",
+			err:  ErrNoCredentials,
+		},
+		{
+			name:   "basic scheme",
+			header: "Basic b3BzOnNlY3JldA==",
+			err:    ErrNoCredentials,
+		},
+		{
+			name:     "hs256",
+			header:   "Bearer " + hs256(claims("support", time.Hour)),
+			identity: Identity{Subject: "ivanov", Role: RoleSupport},
+		},
+		{
+			name:     "rs256",
+			header:   "Bearer " + rs256("main", rsaKey, claims("viewer", time.Hour)),
+			identity: Identity{Subject: "ivanov", Role: RoleViewer},
+		},
+		{
+			name:   "rs256 unknown key",
+			header: "Bearer " + rs256("other", otherKey, claims("viewer", time.Hour)),
+			err:    ErrInvalidCredentials,
+		},
+		{
+			name:   "rs256 wrong signature",
+			header: "Bearer " + rs256("main", otherKey, claims("viewer", time.Hour)),
+			err:    ErrInvalidCredentials,
+		},
+		{
+			name:   "expired",
+			header: "Bearer " + hs256(claims("admin", -time.Minute)),
+			err:    ErrInvalidCredentials,
+		},
+		{
+			name:   "no expiration",
+			header: "Bearer " + hs256(noExpiry),
+			err:    ErrInvalidCredentials,
+		},
+		{
+			name:   "wrong issuer",
+			header: "Bearer " + hs256(noIssuer),
+			err:    ErrInvalidCredentials,
+		},
+		{
+			name:   "unknown role",
+			header: "Bearer " + hs256(claims("root", time.Hour)),
+			err:    ErrInvalidCredentials,
+		},
+		{
+			name: "none algorithm",
+			header: "Bearer " + func() string {
+				token, err := jwt.NewWithClaims(jwt.SigningMethodNone, claims("admin", time.Hour)).
+					SignedString(jwt.UnsafeAllowNoneSignatureType)
+				require.NoError(t, err)
+				return token
+			}(),
+			err: ErrInvalidCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+
+			if tt.apiKey != "" {
+				r.Header.Set(HeaderAPIKey, tt.apiKey)
+			}
+
+			if tt.header != "" {
+				r.Header.Set("Authorization", tt.header)
+			}
+
+			id, err := a.Authenticate(r)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.identity, id)
+		})
+	}
+}
+
+func TestAuthenticateWithoutJWT(t *testing.T) {
+	a, err := New(&Config{})
+	require.NoError(t, err)
+
+	r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	r.Header.Set("Authorization", "Bearer token")
+
+	_, err = a.Authenticate(r)
+	require.ErrorIs(t, err, ErrInvalidCredentials)
+}
+
+func TestSubject(t *testing.T) {
+	token := func(secret string, c jwt.MapClaims) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, c).SignedString([]byte(secret))
+		require.NoError(t, err)
+
+		return token
+	}
+
+	hash := sha256.Sum256([]byte("first-key-0123456789"))
+
+	tests := []struct {
+		name    string
+		header  string
+		value   string
+		subject string
+		ok      bool
+	}{
+		{"api key", HeaderAPIKey, "first-key-0123456789", "key:" + hex.EncodeToString(hash[:]), true},
+		{"token", "Authorization", "Bearer " + token(testSecret, jwt.MapClaims{"sub": "user-1"}), "token:user-1", true},
+		{"unverified token", "Authorization", "Bearer " + token("wrong-secret", jwt.MapClaims{"sub": "user-1"}), "token:user-1", true},
+		{"token without subject", "Authorization", "Bearer " + token(testSecret, jwt.MapClaims{"role": "admin"}), "", false},
+		{"malformed token", "Authorization", "Bearer token", "", false},
+		{"basic auth", "Authorization", "Basic dXNlcjpwYXNz", "", false},
+		{"no credentials", "", "", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest(http.MethodGet, "/orders", nil)
+			if tt.header != "" {
+				r.Header.Set(tt.header, tt.value)
+			}
+
+			subject, ok := Subject(r)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.subject, subject)
+		})
+	}
+}
+
+func TestRoleAllows(t *testing.T) {
+	tests := []struct {
+		role     Role
+		required Role
+		allows   bool
+	}{
+		{RoleViewer, RoleViewer, true},
+		{RoleViewer, RoleSupport, false},
+		{RoleSupport, RoleViewer, true},
+		{RoleSupport, RoleAdmin, false},
+		{RoleAdmin, RoleSupport, true},
+		{Role("root"), RoleViewer, false},
+		{Role(""), RoleViewer, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.role)+" "+string(tt.required), func(t *testing.T) {
+			require.Equal(t, tt.allows, tt.role.Allows(tt.required))
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		valid bool
+	}{
+		{
+			name:  "disabled",
+			cfg:   Config{},
+			valid: true,
+		},
+		{
+			name:  "enabled without credentials",
+			cfg:   Config{Enabled: true},
+			valid: false,
+		},
+		{
+			name:  "short key",
+			cfg:   Config{Enabled: true, APIKeys: []APIKey{{Name: "ops", Key: "short", Role: RoleAdmin}}},
+			valid: false,
+		},
+		{
+			name:  "unknown role",
+			cfg:   Config{Enabled: true, APIKeys: []APIKey{{Name: "ops", Key: "admin-key-0123456789", Role: "root"}}},
+			valid: false,
+		},
+		{
+			name:  "short secret",
+			cfg:   Config{Enabled: true, JWT: &JWTConfig{Secret: "secret"}},
+			valid: false,
+		},
+		{
+			name:  "jwt",
+			cfg:   Config{Enabled: true, JWT: &JWTConfig{Secret: testSecret}},
+			valid: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
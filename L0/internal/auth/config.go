@@ -0,0 +1,63 @@
+package auth
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type Config struct {
+	Enabled bool         `koanf:"enabled"`
+	APIKeys []APIKey     `koanf:"api_keys"`
+	JWT     *JWTConfig   `koanf:"jwt"`
+	Metrics *MetricsAuth `koanf:"metrics"`
+}
+
+func (c *Config) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.APIKeys, validation.Required.When(c.Enabled && c.JWT == nil)),
+		validation.Field(&c.JWT),
+		validation.Field(&c.Metrics),
+	)
+}
+
+type APIKey struct {
+	Name string `koanf:"name"`
+	Key  string `koanf:"key"`
+	Role Role   `koanf:"role"`
+}
+
+func (k APIKey) Validate() error {
+	return validation.ValidateStruct(&k,
+		validation.Field(&k.Name, validation.Required),
+		validation.Field(&k.Key, validation.Required, validation.Length(16, 0)),
+		validation.Field(&k.Role, validation.Required, validation.In(Roles...)),
+	)
+}
+
+// JWTConfig accepts HS256 tokens signed with Secret and RS256 tokens signed
+// with a key from the JWKS file. The role is read from RoleClaim.
+type JWTConfig struct {
+	Secret    string `koanf:"secret"`
+	JWKSPath  string `koanf:"jwks_path"`
+	Issuer    string `koanf:"issuer"`
+	Audience  string `koanf:"audience"`
+	RoleClaim string `koanf:"role_claim"`
+}
+
+func (c *JWTConfig) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Secret, validation.Required.When(c.JWKSPath == ""), validation.Length(32, 0)),
+		validation.Field(&c.JWKSPath, validation.Required.When(c.Secret == "")),
+	)
+}
+
+// MetricsAuth protects /metrics with its own bearer token, so Prometheus
+// doesn't need an API key. The endpoint is public without a token.
+type MetricsAuth struct {
+	Token string `koanf:"token"`
+}
+
+func (c *MetricsAuth) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Token, validation.Length(16, 0)),
+	)
+}
@@ -0,0 +1,7 @@
+package auth
+
+import "net/http"
+
+type Authenticator interface {
+	Authenticate(r *http.Request) (Identity, error)
+}
@@ -0,0 +1,44 @@
+package auth
+
+import (
+	"context"
+	"slices"
+)
+
+type Role string
+
+// Roles are ordered by privileges, a role is granted everything the previous
+// roles are.
+const (
+	RoleViewer  Role = "viewer"
+	RoleSupport Role = "support"
+	RoleAdmin   Role = "admin"
+)
+
+var Roles = []any{RoleViewer, RoleSupport, RoleAdmin}
+
+var order = []Role{RoleViewer, RoleSupport, RoleAdmin}
+
+// Allows reports whether the role is granted the required one. Unknown roles
+// are granted nothing.
+func (r Role) Allows(required Role) bool {
+	have := slices.Index(order, r)
+	return have >= 0 && have >= slices.Index(order, required)
+}
+
+// Identity is the authenticated caller.
+type Identity struct {
+	Subject string
+	Role    Role
+}
+
+type identityKey struct{}
+
+func WithIdentity(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityKey{}, id)
+}
+
+func FromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityKey{}).(Identity)
+	return id, ok
+}
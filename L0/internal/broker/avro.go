@@ -0,0 +1,211 @@
+package broker
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+// avroCodec encodes the protobuf representation of a value with an Avro
+// schema loaded from a local file. Avro field names match the proto ones.
+type avroCodec struct {
+	schema avro.Schema
+}
+
+func newAvroCodec(path string) (*avroCodec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read schema: %w", err)
+	}
+
+	schema, err := avro.Parse(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("parse schema: %w", err)
+	}
+
+	return &avroCodec{schema: schema}, nil
+}
+
+func (c *avroCodec) Name() string        { return CodecAvro }
+func (c *avroCodec) ContentType() string { return "application/avro" }
+
+func (c *avroCodec) Marshal(v any) ([]byte, error) {
+	msg, err := toMessage(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return avro.Marshal(c.schema, messageToAvro(msg.ProtoReflect()))
+}
+
+func (c *avroCodec) Unmarshal(data []byte, v any) error {
+	return fromMessage(v, func(msg proto.Message) error {
+		var record map[string]any
+
+		err := avro.Unmarshal(c.schema, data, &record)
+		if err != nil {
+			return err
+		}
+
+		return avroToMessage(msg.ProtoReflect(), record)
+	})
+}
+
+func messageToAvro(m protoreflect.Message) map[string]any {
+	var (
+		fields = m.Descriptor().Fields()
+		record = make(map[string]any, fields.Len())
+	)
+
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+
+		switch {
+		case fd.IsList():
+			list := m.Get(fd).List()
+			values := make([]any, list.Len())
+
+			for j := range list.Len() {
+				values[j] = valueToAvro(fd, list.Get(j))
+			}
+
+			record[string(fd.Name())] = values
+		case isTimestamp(fd) && !m.Has(fd):
+			record[string(fd.Name())] = nil
+		default:
+			record[string(fd.Name())] = valueToAvro(fd, m.Get(fd))
+		}
+	}
+
+	return record
+}
+
+func valueToAvro(fd protoreflect.FieldDescriptor, v protoreflect.Value) any {
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		if isTimestamp(fd) {
+			return v.Message().Interface().(*timestamppb.Timestamp).AsTime()
+		}
+
+		return messageToAvro(v.Message())
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return int(v.Int())
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return v.Int()
+	case protoreflect.BoolKind:
+		return v.Bool()
+	case protoreflect.DoubleKind:
+		return v.Float()
+	case protoreflect.BytesKind:
+		return v.Bytes()
+	default:
+		return v.String()
+	}
+}
+
+func avroToMessage(m protoreflect.Message, record map[string]any) error {
+	fields := m.Descriptor().Fields()
+
+	for i := range fields.Len() {
+		fd := fields.Get(i)
+
+		value, ok := record[string(fd.Name())]
+		if !ok || value == nil {
+			continue
+		}
+
+		if fd.IsList() {
+			values, ok := value.([]any)
+			if !ok {
+				return fmt.Errorf("field %s: expected array, got %T", fd.Name(), value)
+			}
+
+			list := m.Mutable(fd).List()
+
+			for _, item := range values {
+				v, err := valueFromAvro(fd, list.NewElement(), item)
+				if err != nil {
+					return err
+				}
+
+				list.Append(v)
+			}
+
+			continue
+		}
+
+		v, err := valueFromAvro(fd, m.NewField(fd), value)
+		if err != nil {
+			return err
+		}
+
+		m.Set(fd, v)
+	}
+
+	return nil
+}
+
+func valueFromAvro(fd protoreflect.FieldDescriptor, empty protoreflect.Value, value any) (protoreflect.Value, error) {
+	var ok bool
+
+	switch fd.Kind() {
+	case protoreflect.MessageKind:
+		if isTimestamp(fd) {
+			var t time.Time
+			if t, ok = value.(time.Time); ok {
+				return protoreflect.ValueOfMessage(timestamppb.New(t).ProtoReflect()), nil
+			}
+
+			break
+		}
+
+		var record map[string]any
+		if record, ok = value.(map[string]any); ok {
+			err := avroToMessage(empty.Message(), record)
+			return empty, err
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		var n int
+		if n, ok = value.(int); ok {
+			return protoreflect.ValueOfInt32(int32(n)), nil
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		var n int64
+		if n, ok = value.(int64); ok {
+			return protoreflect.ValueOfInt64(n), nil
+		}
+	case protoreflect.BoolKind:
+		var b bool
+		if b, ok = value.(bool); ok {
+			return protoreflect.ValueOfBool(b), nil
+		}
+	case protoreflect.DoubleKind:
+		var f float64
+		if f, ok = value.(float64); ok {
+			return protoreflect.ValueOfFloat64(f), nil
+		}
+	case protoreflect.BytesKind:
+		var b []byte
+		if b, ok = value.([]byte); ok {
+			return protoreflect.ValueOfBytes(b), nil
+		}
+	case protoreflect.StringKind:
+		var s string
+		if s, ok = value.(string); ok {
+			return protoreflect.ValueOfString(s), nil
+		}
+	}
+
+	return protoreflect.Value{}, fmt.Errorf("field %s: unexpected avro value %T", fd.Name(), value)
+}
+
+func isTimestamp(fd protoreflect.FieldDescriptor) bool {
+	return fd.Message() != nil && fd.Message().FullName() == timestampName
+}
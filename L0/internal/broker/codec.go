@@ -0,0 +1,137 @@
+package broker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const HeaderContentType = "content-type"
+
+const (
+	CodecJSON     = "json"
+	CodecProtobuf = "protobuf"
+	CodecAvro     = "avro"
+)
+
+var Codecs = []any{CodecJSON, CodecProtobuf, CodecAvro}
+
+var (
+	ErrUnknownCodec       = errors.New("unknown codec")
+	ErrUnknownContentType = errors.New("unknown content type")
+	ErrUnsupportedValue   = errors.New("unsupported value type")
+)
+
+// Codec converts values to and from the message payload. The same codec is
+// selected for a topic on both sides and is advertised in the content-type
+// header of each record.
+type Codec interface {
+	Name() string
+	ContentType() string
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+type jsonCodec struct{}
+
+func (jsonCodec) Name() string        { return CodecJSON }
+func (jsonCodec) ContentType() string { return "application/json" }
+
+func (jsonCodec) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonCodec) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type codecs struct {
+	byName   map[string]Codec
+	byTopic  map[string]Codec
+	byType   map[string]Codec
+	fallback Codec
+}
+
+func newCodecs(cfg *Config) (*codecs, error) {
+	c := &codecs{
+		byName: map[string]Codec{
+			CodecJSON:     jsonCodec{},
+			CodecProtobuf: protobufCodec{},
+		},
+		byTopic: make(map[string]Codec, len(cfg.TopicCodecs)),
+		byType:  make(map[string]Codec),
+	}
+
+	if cfg.AvroSchema != "" {
+		avro, err := newAvroCodec(cfg.AvroSchema)
+		if err != nil {
+			return nil, fmt.Errorf("create avro codec: %w", err)
+		}
+
+		c.byName[CodecAvro] = avro
+	}
+
+	for _, codec := range c.byName {
+		c.byType[codec.ContentType()] = codec
+	}
+
+	fallback, err := c.get(cfg.Codec)
+	if err != nil {
+		return nil, err
+	}
+
+	c.fallback = fallback
+
+	for topic, name := range cfg.TopicCodecs {
+		codec, err := c.get(name)
+		if err != nil {
+			return nil, fmt.Errorf("topic %q: %w", topic, err)
+		}
+
+		c.byTopic[topic] = codec
+	}
+
+	return c, nil
+}
+
+func (c *codecs) get(name string) (Codec, error) {
+	if name == "" {
+		return jsonCodec{}, nil
+	}
+
+	codec, ok := c.byName[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownCodec, name)
+	}
+
+	return codec, nil
+}
+
+func (c *codecs) forTopic(topic string) Codec {
+	if codec, ok := c.byTopic[topic]; ok {
+		return codec
+	}
+
+	return c.fallback
+}
+
+// forRecord prefers the content-type header set by the producer and falls
+// back to the codec configured for the record topic.
+func (c *codecs) forRecord(record *kgo.Record) (Codec, error) {
+	for _, h := range record.Headers {
+		if h.Key != HeaderContentType {
+			continue
+		}
+
+		codec, ok := c.byType[string(h.Value)]
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrUnknownContentType, h.Value)
+		}
+
+		return codec, nil
+	}
+
+	return c.forTopic(record.Topic), nil
+}
@@ -0,0 +1,179 @@
+package broker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/imotkin/L0/internal/convert"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/entity/entitytest"
+)
+
+const testAvroSchema = "../../proto/order/v1/order.avsc"
+
+func TestCodecRoundTrip(t *testing.T) {
+	avro, err := newAvroCodec(testAvroSchema)
+	require.NoError(t, err)
+
+	for _, codec := range []Codec{jsonCodec{}, protobufCodec{}, avro} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			order := entitytest.Order()
+
+			data, err := codec.Marshal(order)
+			require.NoError(t, err)
+
+			var got entity.Order
+
+			err = codec.Unmarshal(data, &got)
+			require.NoError(t, err)
+			require.Equal(t, order, got)
+		})
+
+		t.Run(codec.Name()+"/empty", func(t *testing.T) {
+			data, err := codec.Marshal(entity.Order{})
+			require.NoError(t, err)
+
+			var got entity.Order
+
+			err = codec.Unmarshal(data, &got)
+			require.NoError(t, err)
+			require.Zero(t, got.DateCreated)
+			require.Error(t, got.Validate())
+		})
+	}
+}
+
+func TestCodecProtoMessage(t *testing.T) {
+	avro, err := newAvroCodec(testAvroSchema)
+	require.NoError(t, err)
+
+	want := convert.OrderToProto(entitytest.Order())
+
+	for _, codec := range []Codec{protobufCodec{}, avro} {
+		t.Run(codec.Name(), func(t *testing.T) {
+			data, err := codec.Marshal(want)
+			require.NoError(t, err)
+
+			got := proto.Clone(want)
+			proto.Reset(got)
+
+			err = codec.Unmarshal(data, got)
+			require.NoError(t, err)
+			require.True(t, proto.Equal(want, got))
+		})
+	}
+}
+
+func TestCodecUnsupportedValue(t *testing.T) {
+	_, err := protobufCodec{}.Marshal(map[string]any{})
+	require.ErrorIs(t, err, ErrUnsupportedValue)
+
+	var v string
+
+	err = protobufCodec{}.Unmarshal(nil, &v)
+	require.ErrorIs(t, err, ErrUnsupportedValue)
+}
+
+func TestCodecsSelection(t *testing.T) {
+	codecs, err := newCodecs(&Config{
+		Codec:       CodecJSON,
+		TopicCodecs: map[string]string{"orders-pb": CodecProtobuf, "orders-avro": CodecAvro},
+		AvroSchema:  testAvroSchema,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		record *kgo.Record
+		codec  string
+		err    error
+	}{
+		{
+			name:   "default",
+			record: &kgo.Record{Topic: "orders"},
+			codec:  CodecJSON,
+		},
+		{
+			name:   "topic",
+			record: &kgo.Record{Topic: "orders-avro"},
+			codec:  CodecAvro,
+		},
+		{
+			name: "header",
+			record: &kgo.Record{
+				Topic:   "orders",
+				Headers: []kgo.RecordHeader{{Key: HeaderContentType, Value: []byte("application/x-protobuf")}},
+			},
+			codec: CodecProtobuf,
+		},
+		{
+			name: "header overrides topic",
+			record: &kgo.Record{
+				Topic:   "orders-pb",
+				Headers: []kgo.RecordHeader{{Key: HeaderContentType, Value: []byte("application/json")}},
+			},
+			codec: CodecJSON,
+		},
+		{
+			name: "unknown header",
+			record: &kgo.Record{
+				Topic:   "orders",
+				Headers: []kgo.RecordHeader{{Key: HeaderContentType, Value: []byte("text/xml")}},
+			},
+			err: ErrUnknownContentType,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			codec, err := codecs.forRecord(tt.record)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.codec, codec.Name())
+		})
+	}
+}
+
+func TestCodecsUnknown(t *testing.T) {
+	_, err := newCodecs(&Config{Codec: CodecAvro})
+	require.ErrorIs(t, err, ErrUnknownCodec)
+}
+
+func TestPublisherRecord(t *testing.T) {
+	codecs, err := newCodecs(&Config{
+		Codec:       CodecJSON,
+		TopicCodecs: map[string]string{"orders-pb": CodecProtobuf},
+	})
+	require.NoError(t, err)
+
+	var (
+		p     = &Publisher{topic: "orders", codecs: codecs}
+		order = entitytest.Order()
+	)
+
+	for _, topic := range []string{"orders", "orders-pb"} {
+		t.Run(topic, func(t *testing.T) {
+			record, err := p.record(topic, order.UID.String(), order)
+			require.NoError(t, err)
+			require.Equal(t, topic, record.Topic)
+
+			// the content type lets subscribers of any topic decode the record
+			codec, err := codecs.forRecord(&kgo.Record{Topic: "orders", Headers: record.Headers})
+			require.NoError(t, err)
+			require.Equal(t, codecs.forTopic(topic).Name(), codec.Name())
+
+			var got entity.Order
+
+			err = codec.Unmarshal(record.Value, &got)
+			require.NoError(t, err)
+			require.Equal(t, order, got)
+		})
+	}
+}
@@ -10,13 +10,29 @@ import (
 )
 
 type Config struct {
-	Host        string        `koanf:"host"`
-	Port        string        `koanf:"port"`
-	Topic       string        `koanf:"topic"`
-	TopicDLQ    string        `koanf:"topic_dlq"`
-	GroupID     string        `koanf:"group_id"`
-	FirstOffset bool          `koanf:"first_offset"`
-	Interval    time.Duration `koanf:"interval"`
+	Host          string        `koanf:"host"`
+	Port          string        `koanf:"port"`
+	Topic         string        `koanf:"topic"`
+	TopicDLQ      string        `koanf:"topic_dlq"`
+	TopicStatus   string        `koanf:"topic_status"`
+	TopicAccepted string        `koanf:"topic_accepted"`
+	GroupID       string        `koanf:"group_id"`
+	FirstOffset   bool          `koanf:"first_offset"`
+	Interval      time.Duration `koanf:"interval"`
+	BatchSize     int           `koanf:"batch_size"`
+
+	Workers      int           `koanf:"workers"`
+	WorkerQueue  int           `koanf:"worker_queue"`
+	Route        Route         `koanf:"route"`
+	DrainTimeout time.Duration `koanf:"drain_timeout"`
+
+	Codec       string            `koanf:"codec"`
+	TopicCodecs map[string]string `koanf:"topic_codecs"`
+	AvroSchema  string            `koanf:"avro_schema"`
+
+	RetryAttempts   int           `koanf:"retry_attempts"`
+	RetryMinBackoff time.Duration `koanf:"retry_min_backoff"`
+	RetryMaxBackoff time.Duration `koanf:"retry_max_backoff"`
 }
 
 func (c *Config) Validate() error {
@@ -25,12 +41,39 @@ func (c *Config) Validate() error {
 		validation.Field(&c.Port, validation.Required, is.Port),
 		validation.Field(&c.Topic, validation.Required),
 		validation.Field(&c.TopicDLQ, validation.Required),
+		validation.Field(&c.TopicStatus, validation.Required),
+		validation.Field(&c.TopicAccepted, validation.Required),
 		validation.Field(&c.GroupID, validation.Required),
 		validation.Field(&c.FirstOffset, validation.In(true, false)),
 		validation.Field(&c.Interval, validation.Required),
+		validation.Field(&c.BatchSize, validation.Required, validation.Min(1)),
+		validation.Field(&c.Workers, validation.Required, validation.Min(1)),
+		validation.Field(&c.WorkerQueue, validation.Required, validation.Min(1)),
+		validation.Field(&c.Route, validation.Required, validation.In(RoutePartition, RouteKey)),
+		validation.Field(&c.DrainTimeout, validation.Required),
+		validation.Field(&c.Codec, validation.In(Codecs...)),
+		validation.Field(&c.TopicCodecs, validation.Each(validation.In(Codecs...))),
+		validation.Field(&c.AvroSchema, validation.When(c.usesAvro(), validation.Required)),
+		validation.Field(&c.RetryAttempts, validation.Min(0)),
+		validation.Field(&c.RetryMinBackoff, validation.Required),
+		validation.Field(&c.RetryMaxBackoff, validation.Required, validation.Min(c.RetryMinBackoff)),
 	)
 }
 
+func (c *Config) usesAvro() bool {
+	if c.Codec == CodecAvro {
+		return true
+	}
+
+	for _, codec := range c.TopicCodecs {
+		if codec == CodecAvro {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (c *Config) Endpoint() string {
 	return net.JoinHostPort(c.Host, c.Port)
 }
@@ -0,0 +1,9 @@
+package broker
+
+import "context"
+
+type DeadLetterQueue interface {
+	List(ctx context.Context, limit int) ([]DeadLetter, error)
+	Get(ctx context.Context, partition int32, offset int64) (DeadLetter, error)
+	Replay(ctx context.Context, partition int32, offset int64, value []byte) error
+}
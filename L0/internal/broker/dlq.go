@@ -0,0 +1,350 @@
+package broker
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kadm"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/imotkin/L0/internal/logger"
+)
+
+const (
+	HeaderErrorKind         = "x-error-kind"
+	HeaderError             = "x-error"
+	HeaderOriginalTopic     = "x-original-topic"
+	HeaderOriginalPartition = "x-original-partition"
+	HeaderOriginalOffset    = "x-original-offset"
+	HeaderFailedAt          = "x-failed-at"
+	HeaderAttempts          = "x-attempts"
+	HeaderReplayedFrom      = "x-replayed-from"
+)
+
+type ErrorKind string
+
+const (
+	ErrorDecode     ErrorKind = "decode"
+	ErrorValidation ErrorKind = "validation"
+	ErrorTransient  ErrorKind = "transient"
+	ErrorRejected   ErrorKind = "rejected"
+
+	ErrorUnsupportedVersion ErrorKind = "unsupported_version"
+)
+
+var ErrDeadLetterNotFound = errors.New("dead letter not found")
+
+const readTimeout = 10 * time.Second
+
+type DeadLetter struct {
+	Partition         int32     `json:"partition"`
+	Offset            int64     `json:"offset"`
+	Key               string    `json:"key"`
+	Value             string    `json:"value"`
+	ContentType       string    `json:"content_type,omitempty"`
+	SchemaVersion     string    `json:"schema_version,omitempty"`
+	ErrorKind         ErrorKind `json:"error_kind,omitempty"`
+	Error             string    `json:"error,omitempty"`
+	OriginalTopic     string    `json:"original_topic,omitempty"`
+	OriginalPartition int32     `json:"original_partition"`
+	OriginalOffset    int64     `json:"original_offset"`
+	FailedAt          time.Time `json:"failed_at,omitzero"`
+	Attempts          int       `json:"attempts"`
+}
+
+func header(record *kgo.Record, key string) string {
+	for _, h := range record.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+func headerInt(record *kgo.Record, key string) int64 {
+	v, _ := strconv.ParseInt(header(record, key), 10, 64)
+	return v
+}
+
+func deadLetterRecord(record *kgo.Record, kind ErrorKind, err error, now time.Time) *kgo.Record {
+	attempts := headerInt(record, HeaderAttempts) + 1
+
+	headers := []kgo.RecordHeader{
+		{Key: HeaderErrorKind, Value: []byte(kind)},
+		{Key: HeaderError, Value: []byte(err.Error())},
+		{Key: HeaderOriginalTopic, Value: []byte(record.Topic)},
+		{Key: HeaderOriginalPartition, Value: []byte(strconv.Itoa(int(record.Partition)))},
+		{Key: HeaderOriginalOffset, Value: []byte(strconv.FormatInt(record.Offset, 10))},
+		{Key: HeaderFailedAt, Value: []byte(now.UTC().Format(time.RFC3339Nano))},
+		{Key: HeaderAttempts, Value: []byte(strconv.FormatInt(attempts, 10))},
+	}
+
+	for _, key := range []string{HeaderContentType, HeaderSchemaVersion} {
+		if v := header(record, key); v != "" {
+			headers = append(headers, kgo.RecordHeader{Key: key, Value: []byte(v)})
+		}
+	}
+
+	return &kgo.Record{
+		Key:     record.Key,
+		Value:   record.Value,
+		Headers: headers,
+	}
+}
+
+func parseDeadLetter(record *kgo.Record) DeadLetter {
+	failedAt, err := time.Parse(time.RFC3339Nano, header(record, HeaderFailedAt))
+	if err != nil {
+		failedAt = record.Timestamp
+	}
+
+	return DeadLetter{
+		Partition:         record.Partition,
+		Offset:            record.Offset,
+		Key:               string(record.Key),
+		Value:             string(record.Value),
+		ContentType:       header(record, HeaderContentType),
+		SchemaVersion:     header(record, HeaderSchemaVersion),
+		ErrorKind:         ErrorKind(header(record, HeaderErrorKind)),
+		Error:             header(record, HeaderError),
+		OriginalTopic:     header(record, HeaderOriginalTopic),
+		OriginalPartition: int32(headerInt(record, HeaderOriginalPartition)),
+		OriginalOffset:    headerInt(record, HeaderOriginalOffset),
+		FailedAt:          failedAt,
+		Attempts:          int(headerInt(record, HeaderAttempts)),
+	}
+}
+
+type DLQ struct {
+	c   *kgo.Client
+	adm *kadm.Client
+	cfg *Config
+	log logger.Logger
+}
+
+func NewDLQ(log logger.Logger, cfg *Config) (*DLQ, error) {
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(cfg.Endpoint()),
+		kgo.AllowAutoTopicCreation(),
+		kgo.DefaultProduceTopic(cfg.Topic),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create kafka client: %w", err)
+	}
+
+	return &DLQ{
+		c:   client,
+		adm: kadm.NewClient(client),
+		cfg: cfg,
+		log: log.With("source", "kafka-dlq"),
+	}, nil
+}
+
+func (d *DLQ) List(ctx context.Context, limit int) ([]DeadLetter, error) {
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	starts, ends, err := d.offsets(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		from = make(map[int32]kgo.Offset)
+		to   = make(map[int32]int64)
+	)
+
+	ends.Each(func(end kadm.ListedOffset) {
+		start, _ := starts.Lookup(end.Topic, end.Partition)
+
+		offset := max(start.Offset, end.Offset-int64(limit))
+		if offset >= end.Offset {
+			return
+		}
+
+		from[end.Partition] = kgo.NewOffset().At(offset)
+		to[end.Partition] = end.Offset
+	})
+
+	letters, err := d.read(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(letters, func(a, b DeadLetter) int {
+		return cmp.Or(
+			b.FailedAt.Compare(a.FailedAt),
+			cmp.Compare(a.Partition, b.Partition),
+			cmp.Compare(b.Offset, a.Offset),
+		)
+	})
+
+	return letters[:min(limit, len(letters))], nil
+}
+
+func (d *DLQ) Get(ctx context.Context, partition int32, offset int64) (DeadLetter, error) {
+	ctx, cancel := context.WithTimeout(ctx, readTimeout)
+	defer cancel()
+
+	starts, ends, err := d.offsets(ctx)
+	if err != nil {
+		return DeadLetter{}, err
+	}
+
+	start, ok := starts.Lookup(d.cfg.TopicDLQ, partition)
+	if !ok || offset < start.Offset {
+		return DeadLetter{}, ErrDeadLetterNotFound
+	}
+
+	end, ok := ends.Lookup(d.cfg.TopicDLQ, partition)
+	if !ok || offset >= end.Offset {
+		return DeadLetter{}, ErrDeadLetterNotFound
+	}
+
+	letters, err := d.read(
+		ctx,
+		map[int32]kgo.Offset{partition: kgo.NewOffset().At(offset)},
+		map[int32]int64{partition: offset + 1},
+	)
+	if err != nil {
+		return DeadLetter{}, err
+	}
+
+	for _, letter := range letters {
+		if letter.Offset == offset {
+			return letter, nil
+		}
+	}
+
+	return DeadLetter{}, ErrDeadLetterNotFound
+}
+
+func (d *DLQ) Replay(ctx context.Context, partition int32, offset int64, value []byte) error {
+	letter, err := d.Get(ctx, partition, offset)
+	if err != nil {
+		return fmt.Errorf("get dead letter: %w", err)
+	}
+
+	var (
+		edited      = value != nil
+		contentType = letter.ContentType
+		version     = letter.SchemaVersion
+	)
+
+	if edited {
+		// edited messages are sent by operators as JSON with the version field
+		contentType, version = jsonCodec{}.ContentType(), ""
+	} else {
+		value = []byte(letter.Value)
+	}
+
+	topic := cmp.Or(letter.OriginalTopic, d.cfg.Topic)
+	source := fmt.Sprintf("%s/%d/%d", d.cfg.TopicDLQ, partition, offset)
+
+	headers := []kgo.RecordHeader{
+		{Key: HeaderAttempts, Value: []byte(strconv.Itoa(letter.Attempts))},
+		{Key: HeaderReplayedFrom, Value: []byte(source)},
+	}
+
+	if contentType != "" {
+		headers = append(headers, kgo.RecordHeader{Key: HeaderContentType, Value: []byte(contentType)})
+	}
+
+	if version != "" {
+		headers = append(headers, kgo.RecordHeader{Key: HeaderSchemaVersion, Value: []byte(version)})
+	}
+
+	res := d.c.ProduceSync(ctx, &kgo.Record{
+		Topic:   topic,
+		Key:     []byte(letter.Key),
+		Value:   value,
+		Headers: headers,
+	})
+
+	if err := res.FirstErr(); err != nil {
+		return fmt.Errorf("publish replayed message: %w", err)
+	}
+
+	d.log.Info(
+		"dead letter was replayed",
+		"source", source,
+		"topic", topic,
+		"key", letter.Key,
+		"edited", edited,
+	)
+
+	return nil
+}
+
+func (d *DLQ) offsets(ctx context.Context) (starts, ends kadm.ListedOffsets, err error) {
+	starts, err = d.adm.ListStartOffsets(ctx, d.cfg.TopicDLQ)
+	if err == nil {
+		err = starts.Error()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("list dlq start offsets: %w", err)
+	}
+
+	ends, err = d.adm.ListEndOffsets(ctx, d.cfg.TopicDLQ)
+	if err == nil {
+		err = ends.Error()
+	}
+	if err != nil {
+		return nil, nil, fmt.Errorf("list dlq end offsets: %w", err)
+	}
+
+	return starts, ends, nil
+}
+
+func (d *DLQ) read(ctx context.Context, from map[int32]kgo.Offset, to map[int32]int64) ([]DeadLetter, error) {
+	letters := make([]DeadLetter, 0)
+
+	if len(from) == 0 {
+		return letters, nil
+	}
+
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(d.cfg.Endpoint()),
+		kgo.ConsumePartitions(map[string]map[int32]kgo.Offset{d.cfg.TopicDLQ: from}),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("create kafka reader: %w", err)
+	}
+	defer client.Close()
+
+	for len(to) > 0 {
+		fetches := client.PollFetches(ctx)
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("read dlq messages: %w", err)
+		}
+
+		if errs := fetches.Errors(); len(errs) > 0 {
+			return nil, fmt.Errorf("read dlq messages: %w", errs[0].Err)
+		}
+
+		fetches.EachRecord(func(record *kgo.Record) {
+			end, ok := to[record.Partition]
+			if !ok || record.Offset >= end {
+				return
+			}
+
+			letters = append(letters, parseDeadLetter(record))
+
+			if record.Offset >= end-1 {
+				delete(to, record.Partition)
+			}
+		})
+	}
+
+	return letters, nil
+}
+
+func (d *DLQ) Close() {
+	d.c.Close()
+}
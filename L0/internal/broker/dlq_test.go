@@ -0,0 +1,71 @@
+package broker
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+func TestDeadLetterRecord(t *testing.T) {
+	var (
+		now    = time.Date(2025, 4, 10, 10, 0, 0, 0, time.UTC)
+		record = &kgo.Record{
+			Key:       []byte("key"),
+			Value:     []byte(`{"order_uid":1}`),
+			Topic:     "orders",
+			Partition: 2,
+			Offset:    42,
+		}
+	)
+
+	dlq := deadLetterRecord(record, ErrorDecode, errors.New("invalid json"), now)
+	dlq.Partition, dlq.Offset = 0, 7
+
+	got := parseDeadLetter(dlq)
+
+	require.Equal(t, DeadLetter{
+		Partition:         0,
+		Offset:            7,
+		Key:               "key",
+		Value:             `{"order_uid":1}`,
+		ErrorKind:         ErrorDecode,
+		Error:             "invalid json",
+		OriginalTopic:     "orders",
+		OriginalPartition: 2,
+		OriginalOffset:    42,
+		FailedAt:          now,
+		Attempts:          1,
+	}, got)
+}
+
+func TestDeadLetterRecordAttempts(t *testing.T) {
+	record := &kgo.Record{
+		Value: []byte(`{}`),
+		Headers: []kgo.RecordHeader{
+			{Key: HeaderAttempts, Value: []byte("2")},
+		},
+	}
+
+	dlq := deadLetterRecord(record, ErrorValidation, errors.New("required"), time.Now())
+
+	require.Equal(t, 3, parseDeadLetter(dlq).Attempts)
+}
+
+func TestDeadLetterRecordHeaders(t *testing.T) {
+	record := &kgo.Record{
+		Value: []byte{0x0a, 0x01},
+		Headers: []kgo.RecordHeader{
+			{Key: HeaderContentType, Value: []byte("application/x-protobuf")},
+			{Key: HeaderSchemaVersion, Value: []byte("3")},
+		},
+	}
+
+	dlq := deadLetterRecord(record, ErrorUnsupportedVersion, errors.New("unsupported schema version"), time.Now())
+	got := parseDeadLetter(dlq)
+
+	require.Equal(t, "application/x-protobuf", got.ContentType)
+	require.Equal(t, "3", got.SchemaVersion)
+}
@@ -0,0 +1,85 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/broker/contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/broker/contract.go -destination=internal/broker/mock.go -package=broker
+//
+
+// Package broker is a generated GoMock package.
+package broker
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockDeadLetterQueue is a mock of DeadLetterQueue interface.
+type MockDeadLetterQueue struct {
+	ctrl     *gomock.Controller
+	recorder *MockDeadLetterQueueMockRecorder
+	isgomock struct{}
+}
+
+// MockDeadLetterQueueMockRecorder is the mock recorder for MockDeadLetterQueue.
+type MockDeadLetterQueueMockRecorder struct {
+	mock *MockDeadLetterQueue
+}
+
+// NewMockDeadLetterQueue creates a new mock instance.
+func NewMockDeadLetterQueue(ctrl *gomock.Controller) *MockDeadLetterQueue {
+	mock := &MockDeadLetterQueue{ctrl: ctrl}
+	mock.recorder = &MockDeadLetterQueueMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDeadLetterQueue) EXPECT() *MockDeadLetterQueueMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockDeadLetterQueue) Get(ctx context.Context, partition int32, offset int64) (DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, partition, offset)
+	ret0, _ := ret[0].(DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockDeadLetterQueueMockRecorder) Get(ctx, partition, offset any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockDeadLetterQueue)(nil).Get), ctx, partition, offset)
+}
+
+// List mocks base method.
+func (m *MockDeadLetterQueue) List(ctx context.Context, limit int) ([]DeadLetter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", ctx, limit)
+	ret0, _ := ret[0].([]DeadLetter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// List indicates an expected call of List.
+func (mr *MockDeadLetterQueueMockRecorder) List(ctx, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockDeadLetterQueue)(nil).List), ctx, limit)
+}
+
+// Replay mocks base method.
+func (m *MockDeadLetterQueue) Replay(ctx context.Context, partition int32, offset int64, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replay", ctx, partition, offset, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replay indicates an expected call of Replay.
+func (mr *MockDeadLetterQueueMockRecorder) Replay(ctx, partition, offset, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replay", reflect.TypeOf((*MockDeadLetterQueue)(nil).Replay), ctx, partition, offset, value)
+}
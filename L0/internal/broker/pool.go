@@ -0,0 +1,430 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/maphash"
+	"log/slog"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+// Route defines how records are distributed between workers.
+type Route string
+
+const (
+	// RoutePartition sends every partition to a single worker, so batches
+	// of the same partition are processed and committed in order without
+	// waiting for other partitions.
+	RoutePartition Route = "partition"
+	// RouteKey spreads records by key hash. A record of one partition can be
+	// handled by several workers, so the next fetch is dispatched only after
+	// the whole previous fetch is processed and committed.
+	RouteKey Route = "key"
+)
+
+type (
+	processFunc func(ctx context.Context, records []*kgo.Record) error
+	commitFunc  func(ctx context.Context, records ...*kgo.Record)
+)
+
+type job struct {
+	records []*kgo.Record
+	done    func(err error)
+}
+
+type topicPartition struct {
+	topic     string
+	partition int32
+}
+
+type pool struct {
+	route   Route
+	seed    maphash.Seed
+	queues  []chan job
+	process processFunc
+	commit  commitFunc
+	log     logger.Logger
+	mc      metrics.Metrics
+
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	// mu guards the partitions the pool has jobs of, the partitions revoked
+	// by a rebalance and the lost ones, offsets of lost partitions can't be
+	// committed. changed is closed when a partition has no jobs anymore.
+	mu       sync.Mutex
+	inflight map[topicPartition]int
+	revoked  map[topicPartition]struct{}
+	lost     map[topicPartition]struct{}
+	changed  chan struct{}
+
+	// failed is the error of the first failed batch, dispatch returns it so
+	// the subscriber stops instead of feeding a worker that skips batches
+	failed atomic.Pointer[error]
+}
+
+func newPool(log logger.Logger, cfg *Config, mc metrics.Metrics, process processFunc, commit commitFunc) *pool {
+	// workers must finish queued batches after the subscriber is stopped,
+	// so they use their own context which is cancelled by drain
+	ctx, cancel := context.WithCancel(context.Background())
+
+	p := &pool{
+		route:   cfg.Route,
+		seed:    maphash.MakeSeed(),
+		queues:  make([]chan job, cfg.Workers),
+		process: process,
+		commit:  commit,
+		log:     log,
+		mc:      mc,
+		ctx:     ctx,
+		cancel:  cancel,
+
+		inflight: make(map[topicPartition]int),
+		revoked:  make(map[topicPartition]struct{}),
+		lost:     make(map[topicPartition]struct{}),
+		changed:  make(chan struct{}),
+	}
+
+	for id := range p.queues {
+		p.queues[id] = make(chan job, cfg.WorkerQueue)
+
+		p.wg.Add(1)
+		go p.work(id, p.queues[id])
+	}
+
+	return p
+}
+
+func (p *pool) work(id int, queue chan job) {
+	defer p.wg.Done()
+
+	var failed error
+
+	for j := range queue {
+		p.mc.SetWorkerQueue(id, len(queue))
+
+		// batches after a failed one are skipped to keep their offsets uncommitted
+		if failed != nil {
+			j.done(failed)
+			continue
+		}
+
+		start := time.Now()
+
+		failed = p.process(p.ctx, j.records)
+
+		p.mc.ObserveWorkerLatency(id, time.Since(start))
+
+		if failed != nil {
+			p.failed.CompareAndSwap(nil, &failed)
+		}
+
+		j.done(failed)
+	}
+}
+
+func (p *pool) worker(record *kgo.Record) int {
+	if p.route == RouteKey {
+		return int(maphash.Bytes(p.seed, record.Key) % uint64(len(p.queues)))
+	}
+
+	return int(record.Partition) % len(p.queues)
+}
+
+func (p *pool) split(records []*kgo.Record) [][]*kgo.Record {
+	batches := make([][]*kgo.Record, len(p.queues))
+
+	for _, record := range records {
+		id := p.worker(record)
+		batches[id] = append(batches[id], record)
+	}
+
+	return batches
+}
+
+// dispatch hands records over to workers. It blocks while the worker queues
+// are full and, for key routing, until the records are processed. Records of
+// revoked partitions are dropped, the new owner consumes them again. Once a
+// batch has failed, dispatch returns its error: the worker skips the later
+// batches to keep their offsets uncommitted, so they have to be consumed
+// again from the last committed offset.
+func (p *pool) dispatch(ctx context.Context, records []*kgo.Record) error {
+	if err := p.failed.Load(); err != nil {
+		return fmt.Errorf("batch processing failed: %w", *err)
+	}
+
+	records = p.owned(records)
+	if len(records) == 0 {
+		return nil
+	}
+
+	batches := p.split(records)
+
+	if p.route == RoutePartition {
+		for id, batch := range batches {
+			if len(batch) == 0 {
+				continue
+			}
+
+			partitions := p.track(batch)
+
+			err := p.send(ctx, id, job{
+				records: batch,
+				done: func(err error) {
+					if err == nil {
+						p.commitOwned(batch)
+					}
+
+					p.untrack(partitions)
+				},
+			})
+			if err != nil {
+				p.untrack(partitions)
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	var (
+		pending    atomic.Int32
+		failed     atomic.Bool
+		finished   = make(chan struct{})
+		partitions = p.track(records)
+	)
+
+	for _, batch := range batches {
+		if len(batch) > 0 {
+			pending.Add(1)
+		}
+	}
+
+	done := func(err error) {
+		if err != nil {
+			failed.Store(true)
+		}
+
+		if pending.Add(-1) > 0 {
+			return
+		}
+
+		if !failed.Load() {
+			p.commitOwned(records)
+		}
+
+		p.untrack(partitions)
+		close(finished)
+	}
+
+	for id, batch := range batches {
+		if len(batch) == 0 {
+			continue
+		}
+
+		err := p.send(ctx, id, job{records: batch, done: done})
+		if err != nil {
+			// the fetch was dispatched partially and must not be committed,
+			// the batches that weren't sent are finished here
+			for _, rest := range batches[id:] {
+				if len(rest) > 0 {
+					done(err)
+				}
+			}
+
+			return err
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-finished:
+		if failed.Load() {
+			return errors.New("batch processing failed")
+		}
+
+		return nil
+	}
+}
+
+func (p *pool) send(ctx context.Context, id int, j job) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case p.queues[id] <- j:
+		p.mc.SetWorkerQueue(id, len(p.queues[id]))
+		return nil
+	}
+}
+
+// drain stops accepting new batches and waits for the queued ones. Workers
+// that don't finish in time are cancelled and their batches stay uncommitted.
+func (p *pool) drain(timeout time.Duration) {
+	for _, queue := range p.queues {
+		close(queue)
+	}
+
+	done := make(chan struct{})
+
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		p.log.Info("workers were drained")
+	case <-time.After(timeout):
+		p.log.Warn("workers were not drained in time", slog.Duration("timeout", timeout))
+		p.cancel()
+		<-done
+	}
+
+	p.cancel()
+}
+
+// revoke waits until the jobs of the revoked partitions are processed and
+// committed, so the next owner of a partition starts after the last offset
+// the pool has processed. New records of the partitions are dropped until
+// they are assigned again.
+func (p *pool) revoke(ctx context.Context, revoked map[string][]int32) error {
+	partitions := p.mark(revoked, p.revoked)
+
+	for {
+		p.mu.Lock()
+
+		busy := slices.ContainsFunc(partitions, func(tp topicPartition) bool {
+			return p.inflight[tp] > 0
+		})
+		changed := p.changed
+
+		p.mu.Unlock()
+
+		if !busy {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// lose stops committing the offsets of the lost partitions, they may belong
+// to another member already.
+func (p *pool) lose(lost map[string][]int32) {
+	p.mark(lost, p.revoked)
+	p.mark(lost, p.lost)
+}
+
+// assign resumes the processing of the assigned partitions.
+func (p *pool) assign(assigned map[string][]int32) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for topic, ids := range assigned {
+		for _, id := range ids {
+			tp := topicPartition{topic, id}
+
+			delete(p.revoked, tp)
+			delete(p.lost, tp)
+		}
+	}
+}
+
+func (p *pool) mark(partitions map[string][]int32, set map[topicPartition]struct{}) []topicPartition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var marked []topicPartition
+
+	for topic, ids := range partitions {
+		for _, id := range ids {
+			tp := topicPartition{topic, id}
+
+			set[tp] = struct{}{}
+			marked = append(marked, tp)
+		}
+	}
+
+	return marked
+}
+
+func (p *pool) owned(records []*kgo.Record) []*kgo.Record {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if len(p.revoked) == 0 {
+		return records
+	}
+
+	return slices.DeleteFunc(slices.Clone(records), func(r *kgo.Record) bool {
+		_, ok := p.revoked[topicPartition{r.Topic, r.Partition}]
+		return ok
+	})
+}
+
+// commitOwned commits the records except the ones of lost partitions.
+func (p *pool) commitOwned(records []*kgo.Record) {
+	p.mu.Lock()
+
+	if len(p.lost) > 0 {
+		records = slices.DeleteFunc(slices.Clone(records), func(r *kgo.Record) bool {
+			_, ok := p.lost[topicPartition{r.Topic, r.Partition}]
+			return ok
+		})
+	}
+
+	p.mu.Unlock()
+
+	if len(records) > 0 {
+		p.commit(p.ctx, records...)
+	}
+}
+
+// track counts the job of the records for every partition of them.
+func (p *pool) track(records []*kgo.Record) []topicPartition {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var partitions []topicPartition
+
+	for _, r := range records {
+		tp := topicPartition{r.Topic, r.Partition}
+		if !slices.Contains(partitions, tp) {
+			partitions = append(partitions, tp)
+			p.inflight[tp]++
+		}
+	}
+
+	return partitions
+}
+
+func (p *pool) untrack(partitions []topicPartition) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, tp := range partitions {
+		p.inflight[tp]--
+
+		if p.inflight[tp] <= 0 {
+			delete(p.inflight, tp)
+		}
+	}
+
+	close(p.changed)
+	p.changed = make(chan struct{})
+}
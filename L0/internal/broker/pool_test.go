@@ -0,0 +1,306 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+type poolRecorder struct {
+	mu        sync.Mutex
+	processed map[int32][]int64
+	committed []*kgo.Record
+}
+
+func (r *poolRecorder) process(_ context.Context, records []*kgo.Record) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, record := range records {
+		r.processed[record.Partition] = append(r.processed[record.Partition], record.Offset)
+	}
+
+	return nil
+}
+
+func (r *poolRecorder) commit(_ context.Context, records ...*kgo.Record) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.committed = append(r.committed, records...)
+}
+
+func testRecords(partitions int32, offset int64, count int) []*kgo.Record {
+	records := make([]*kgo.Record, 0, int(partitions)*count)
+
+	for i := range int64(count) {
+		for partition := range partitions {
+			records = append(records, &kgo.Record{
+				Key:       fmt.Appendf(nil, "key-%d-%d", partition, offset+i),
+				Partition: partition,
+				Offset:    offset + i,
+			})
+		}
+	}
+
+	return records
+}
+
+func TestPoolDispatch(t *testing.T) {
+	for _, route := range []Route{RoutePartition, RouteKey} {
+		t.Run(string(route), func(t *testing.T) {
+			r := &poolRecorder{processed: make(map[int32][]int64)}
+			mc := metrics.NewMockMetrics(gomock.NewController(t))
+			mc.EXPECT().SetWorkerQueue(gomock.Any(), gomock.Any()).AnyTimes()
+			mc.EXPECT().ObserveWorkerLatency(gomock.Any(), gomock.Any()).AnyTimes()
+
+			cfg := &Config{Workers: 3, WorkerQueue: 2, Route: route}
+
+			p := newPool(logger.NewNoOp(), cfg, mc, r.process, r.commit)
+
+			for fetch := range 5 {
+				err := p.dispatch(context.Background(), testRecords(5, int64(fetch*10), 10))
+				require.NoError(t, err)
+			}
+
+			p.drain(time.Second)
+
+			require.Len(t, r.committed, 250)
+			require.Len(t, r.processed, 5)
+
+			if route == RoutePartition {
+				for _, offsets := range r.processed {
+					require.IsIncreasing(t, offsets) // per-partition order is kept
+				}
+			}
+		})
+	}
+}
+
+func TestPoolKeyRoutingWaitsForFetch(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		r       = &poolRecorder{processed: make(map[int32][]int64)}
+	)
+
+	mc := metrics.NewMockMetrics(gomock.NewController(t))
+	mc.EXPECT().SetWorkerQueue(gomock.Any(), gomock.Any()).AnyTimes()
+	mc.EXPECT().ObserveWorkerLatency(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := &Config{Workers: 3, WorkerQueue: 2, Route: RouteKey}
+
+	p := newPool(logger.NewNoOp(), cfg, mc, func(ctx context.Context, records []*kgo.Record) error {
+		<-release
+		return r.process(ctx, records)
+	}, r.commit)
+
+	dispatched := make(chan error)
+
+	go func() {
+		dispatched <- p.dispatch(context.Background(), testRecords(3, 0, 5))
+	}()
+
+	select {
+	case <-dispatched:
+		t.Fatal("fetch was dispatched before processing")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+
+	require.NoError(t, <-dispatched)
+	require.Len(t, r.committed, 15)
+
+	p.drain(time.Second)
+}
+
+func TestPoolFailed(t *testing.T) {
+	var (
+		r       = &poolRecorder{processed: make(map[int32][]int64)}
+		errFail = errors.New("database is down")
+	)
+
+	mc := metrics.NewMockMetrics(gomock.NewController(t))
+	mc.EXPECT().SetWorkerQueue(gomock.Any(), gomock.Any()).AnyTimes()
+	mc.EXPECT().ObserveWorkerLatency(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := &Config{Workers: 3, WorkerQueue: 2, Route: RoutePartition}
+
+	p := newPool(logger.NewNoOp(), cfg, mc, func(ctx context.Context, records []*kgo.Record) error {
+		if records[0].Offset == 0 {
+			return errFail
+		}
+
+		return r.process(ctx, records)
+	}, r.commit)
+
+	require.NoError(t, p.dispatch(context.Background(), testRecords(1, 0, 1)))
+
+	require.Eventually(t, func() bool {
+		return errors.Is(p.dispatch(context.Background(), testRecords(1, 1, 1)), errFail)
+	}, time.Second, time.Millisecond, "the failure reaches the subscriber")
+
+	p.drain(time.Second)
+
+	require.Empty(t, r.committed)
+}
+
+func TestPoolDrainTimeout(t *testing.T) {
+	r := &poolRecorder{processed: make(map[int32][]int64)}
+
+	mc := metrics.NewMockMetrics(gomock.NewController(t))
+	mc.EXPECT().SetWorkerQueue(gomock.Any(), gomock.Any()).AnyTimes()
+	mc.EXPECT().ObserveWorkerLatency(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := &Config{Workers: 3, WorkerQueue: 2, Route: RoutePartition}
+
+	p := newPool(logger.NewNoOp(), cfg, mc, func(ctx context.Context, _ []*kgo.Record) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, r.commit)
+
+	err := p.dispatch(context.Background(), testRecords(3, 0, 1))
+	require.NoError(t, err)
+
+	p.drain(20 * time.Millisecond)
+
+	require.Empty(t, r.committed) // cancelled batches stay uncommitted
+}
+
+func TestPoolRevoke(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		r       = &poolRecorder{processed: make(map[int32][]int64)}
+	)
+
+	mc := metrics.NewMockMetrics(gomock.NewController(t))
+	mc.EXPECT().SetWorkerQueue(gomock.Any(), gomock.Any()).AnyTimes()
+	mc.EXPECT().ObserveWorkerLatency(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := &Config{Workers: 3, WorkerQueue: 2, Route: RoutePartition}
+
+	p := newPool(logger.NewNoOp(), cfg, mc, func(ctx context.Context, records []*kgo.Record) error {
+		if records[0].Partition == 0 {
+			<-release
+		}
+
+		return r.process(ctx, records)
+	}, r.commit)
+
+	err := p.dispatch(context.Background(), testRecords(2, 0, 1))
+	require.NoError(t, err)
+
+	revoked := make(chan error)
+
+	go func() {
+		revoked <- p.revoke(context.Background(), map[string][]int32{"": {0}})
+	}()
+
+	select {
+	case <-revoked:
+		t.Fatal("partition was revoked before its jobs were processed")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	close(release)
+
+	require.NoError(t, <-revoked)
+
+	r.mu.Lock()
+	require.Equal(t, []int64{0}, r.processed[0])
+	require.True(t, slices.ContainsFunc(r.committed, func(record *kgo.Record) bool {
+		return record.Partition == 0
+	}), "records of the revoked partition are committed before it is revoked")
+	r.mu.Unlock()
+
+	// records fetched before the revocation are dropped
+	err = p.dispatch(context.Background(), testRecords(1, 1, 1))
+	require.NoError(t, err)
+
+	p.assign(map[string][]int32{"": {0}})
+
+	err = p.dispatch(context.Background(), testRecords(1, 2, 1))
+	require.NoError(t, err)
+
+	p.drain(time.Second)
+
+	require.Equal(t, []int64{0, 2}, r.processed[0])
+}
+
+func TestPoolRevokeTimeout(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		r       = &poolRecorder{processed: make(map[int32][]int64)}
+	)
+
+	mc := metrics.NewMockMetrics(gomock.NewController(t))
+	mc.EXPECT().SetWorkerQueue(gomock.Any(), gomock.Any()).AnyTimes()
+	mc.EXPECT().ObserveWorkerLatency(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := &Config{Workers: 3, WorkerQueue: 2, Route: RoutePartition}
+
+	p := newPool(logger.NewNoOp(), cfg, mc, func(ctx context.Context, records []*kgo.Record) error {
+		<-release
+		return r.process(ctx, records)
+	}, r.commit)
+
+	err := p.dispatch(context.Background(), testRecords(1, 0, 1))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err = p.revoke(ctx, map[string][]int32{"": {0}})
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	p.drain(time.Second)
+}
+
+func TestPoolLost(t *testing.T) {
+	var (
+		release = make(chan struct{})
+		r       = &poolRecorder{processed: make(map[int32][]int64)}
+	)
+
+	mc := metrics.NewMockMetrics(gomock.NewController(t))
+	mc.EXPECT().SetWorkerQueue(gomock.Any(), gomock.Any()).AnyTimes()
+	mc.EXPECT().ObserveWorkerLatency(gomock.Any(), gomock.Any()).AnyTimes()
+
+	cfg := &Config{Workers: 3, WorkerQueue: 2, Route: RouteKey}
+
+	p := newPool(logger.NewNoOp(), cfg, mc, func(ctx context.Context, records []*kgo.Record) error {
+		<-release
+		return r.process(ctx, records)
+	}, r.commit)
+
+	dispatched := make(chan error)
+
+	go func() {
+		dispatched <- p.dispatch(context.Background(), testRecords(2, 0, 3))
+	}()
+
+	p.lose(map[string][]int32{"": {1}})
+	close(release)
+
+	require.NoError(t, <-dispatched)
+
+	p.drain(time.Second)
+
+	require.Len(t, r.committed, 3)
+
+	for _, record := range r.committed {
+		require.Equal(t, int32(0), record.Partition, "offsets of lost partitions aren't committed")
+	}
+}
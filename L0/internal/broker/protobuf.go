@@ -0,0 +1,71 @@
+package broker
+
+import (
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/imotkin/L0/internal/convert"
+	"github.com/imotkin/L0/internal/entity"
+	orderv1 "github.com/imotkin/L0/pkg/pb/order/v1"
+)
+
+type protobufCodec struct{}
+
+func (protobufCodec) Name() string        { return CodecProtobuf }
+func (protobufCodec) ContentType() string { return "application/x-protobuf" }
+
+func (protobufCodec) Marshal(v any) ([]byte, error) {
+	msg, err := toMessage(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return proto.Marshal(msg)
+}
+
+func (protobufCodec) Unmarshal(data []byte, v any) error {
+	return fromMessage(v, func(msg proto.Message) error {
+		return proto.Unmarshal(data, msg)
+	})
+}
+
+// toMessage maps domain values to their protobuf schema, so every binary
+// codec shares the definitions from proto/.
+func toMessage(v any) (proto.Message, error) {
+	switch v := v.(type) {
+	case proto.Message:
+		return v, nil
+	case entity.Order:
+		return convert.OrderToProto(v), nil
+	case *entity.Order:
+		return convert.OrderToProto(*v), nil
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedValue, v)
+	}
+}
+
+func fromMessage(v any, decode func(proto.Message) error) error {
+	switch v := v.(type) {
+	case proto.Message:
+		return decode(v)
+	case *entity.Order:
+		msg := new(orderv1.Order)
+
+		err := decode(msg)
+		if err != nil {
+			return err
+		}
+
+		order, err := convert.OrderFromProto(msg)
+		if err != nil {
+			return err
+		}
+
+		*v = order
+
+		return nil
+	default:
+		return fmt.Errorf("%w: %T", ErrUnsupportedValue, v)
+	}
+}
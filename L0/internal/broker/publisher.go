@@ -2,9 +2,9 @@ package broker
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strconv"
 	"time"
 
 	"github.com/twmb/franz-go/pkg/kgo"
@@ -13,45 +13,114 @@ import (
 )
 
 type Publisher struct {
-	c   *kgo.Client
-	log logger.Logger
+	c       *kgo.Client
+	topic   string
+	codecs  *codecs
+	schemas map[string]*Schema
+	log     logger.Logger
 }
 
-func NewPublisher(log logger.Logger, cfg *Config) (*Publisher, error) {
+type PublisherOption func(*Publisher)
+
+// WithTopicSchema stamps the records of the topic with the current version of
+// the schema, so subscribers know which upcasters to apply.
+func WithTopicSchema(topic string, schema *Schema) PublisherOption {
+	return func(p *Publisher) {
+		p.schemas[topic] = schema
+	}
+}
+
+func NewPublisher(log logger.Logger, cfg *Config, topic string, opts ...PublisherOption) (*Publisher, error) {
 	client, err := kgo.NewClient(
 		kgo.SeedBrokers([]string{cfg.Endpoint()}...),
 		kgo.AllowAutoTopicCreation(),
-		kgo.DefaultProduceTopic(cfg.Topic),
+		kgo.DefaultProduceTopic(topic),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("create kafka writer: %w", err)
 	}
 
-	return &Publisher{
-		c:   client,
-		log: log.With("source", "kafka-publisher"),
-	}, nil
+	codecs, err := newCodecs(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create codecs: %w", err)
+	}
+
+	p := &Publisher{
+		c:       client,
+		topic:   topic,
+		codecs:  codecs,
+		schemas: make(map[string]*Schema),
+		log:     log.With("source", "kafka-publisher", "topic", topic),
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p, nil
 }
 
+// Publish sends the value to the topic of the publisher.
 func (p *Publisher) Publish(ctx context.Context, key string, value any) (int, error) {
-	bytes, err := json.Marshal(value)
+	return p.send(ctx, p.topic, key, value)
+}
+
+// Send sends the value to the topic, it is encoded with the codec of the
+// topic like the values of Publish.
+func (p *Publisher) Send(ctx context.Context, topic, key string, value any) error {
+	_, err := p.send(ctx, topic, key, value)
+	return err
+}
+
+func (p *Publisher) send(ctx context.Context, topic, key string, value any) (int, error) {
+	record, err := p.record(topic, key, value)
 	if err != nil {
-		return 0, fmt.Errorf("encode value: %w", err)
+		return 0, err
 	}
 
-	r := p.c.ProduceSync(
-		context.Background(),
-		&kgo.Record{
-			Key:   []byte(key),
-			Value: bytes,
-		},
-	)
+	err = p.produce(ctx, record)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(record.Value), nil
+}
+
+func (p *Publisher) record(topic, key string, value any) (*kgo.Record, error) {
+	codec := p.codecs.forTopic(topic)
+
+	bytes, err := codec.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("encode value: %w", err)
+	}
+
+	headers := []kgo.RecordHeader{
+		{Key: HeaderContentType, Value: []byte(codec.ContentType())},
+	}
+
+	if schema, ok := p.schemas[topic]; ok {
+		headers = append(headers, kgo.RecordHeader{
+			Key:   HeaderSchemaVersion,
+			Value: []byte(strconv.Itoa(schema.Current())),
+		})
+	}
+
+	return &kgo.Record{
+		Topic:   topic,
+		Key:     []byte(key),
+		Value:   bytes,
+		Headers: headers,
+	}, nil
+}
+
+func (p *Publisher) produce(ctx context.Context, record *kgo.Record) error {
+	r := p.c.ProduceSync(ctx, record)
 
 	if err := r.FirstErr(); err != nil {
-		return 0, fmt.Errorf("publish message: %w", err)
+		return fmt.Errorf("publish message: %w", err)
 	}
 
-	return len(bytes), nil
+	return nil
 }
 
 func (p *Publisher) IntervalPublish(ctx context.Context, fn func() (string, any), interval time.Duration) {
@@ -86,3 +155,7 @@ func (p *Publisher) IntervalPublish(ctx context.Context, fn func() (string, any)
 		}
 	}()
 }
+
+func (p *Publisher) Close() {
+	p.c.Close()
+}
@@ -2,9 +2,13 @@ package broker
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"maps"
+	"slices"
+	"sync/atomic"
+	"time"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/twmb/franz-go/pkg/kgo"
@@ -13,20 +17,80 @@ import (
 	"github.com/imotkin/L0/internal/metrics"
 )
 
+const commitTimeout = 5 * time.Second
+
+// ErrPermanent marks the errors of handlers that retrying can't fix, such
+// messages are sent to the dlq at once.
+var ErrPermanent = errors.New("permanent failure")
+
+// BatchError is returned by handlers when some values of the batch failed
+// permanently, the values are keyed by their positions in the batch. Only
+// they are sent to the dlq, the rest of the batch is considered processed.
+type BatchError struct {
+	Failed map[int]error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%d values of the batch failed", len(e.Failed))
+}
+
+func (e *BatchError) Unwrap() error {
+	return ErrPermanent
+}
+
+type BatchHandler[T any] func(ctx context.Context, values []T) error
+
 type Subscriber[T validation.Validatable] struct {
-	r      *kgo.Client
-	dlq    *kgo.Client
-	values chan T
-	log    logger.Logger
-	mc     metrics.Metrics
+	r   *kgo.Client
+	dlq *kgo.Client
+	cfg *Config
+	log logger.Logger
+	mc  metrics.Metrics
+
+	codecs *codecs
+	schema *Schema
+	done   chan struct{}
+
+	// pool is set once the subscriber is started, rebalances hand the
+	// partitions over to it
+	pool atomic.Pointer[pool]
+}
+
+type SubscriberOption func(*subscriberOptions)
+
+type subscriberOptions struct {
+	schema *Schema
+}
+
+// WithSchema enables version checks and upcasting of incoming messages.
+func WithSchema(schema *Schema) SubscriberOption {
+	return func(o *subscriberOptions) {
+		o.schema = schema
+	}
 }
 
-func NewSubscriber[T validation.Validatable](log logger.Logger, cfg *Config, mc metrics.Metrics) (*Subscriber[T], error) {
+func NewSubscriber[T validation.Validatable](log logger.Logger, cfg *Config, mc metrics.Metrics, opts ...SubscriberOption) (*Subscriber[T], error) {
+	var options subscriberOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	c := &Subscriber[T]{
+		cfg:    cfg,
+		log:    log.With("source", "kafka-subscriber"),
+		mc:     mc,
+		schema: options.schema,
+		done:   make(chan struct{}),
+	}
+
 	reader, err := kgo.NewClient(
 		kgo.SeedBrokers(cfg.Endpoint()),
 		kgo.ConsumeTopics(cfg.Topic),
 		kgo.ConsumerGroup(cfg.GroupID),
 		kgo.DisableAutoCommit(),
+		kgo.OnPartitionsAssigned(c.onAssigned),
+		kgo.OnPartitionsRevoked(c.onRevoked),
+		kgo.OnPartitionsLost(c.onLost),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("create kafka reader: %w", err)
@@ -41,26 +105,80 @@ func NewSubscriber[T validation.Validatable](log logger.Logger, cfg *Config, mc
 		return nil, fmt.Errorf("create kafka dlq writer: %w", err)
 	}
 
-	return &Subscriber[T]{
-		r:      reader,
-		dlq:    writer,
-		values: make(chan T, 10),
-		log:    log.With("source", "kafka-subscriber"),
-		mc:     mc,
-	}, nil
+	codecs, err := newCodecs(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("create codecs: %w", err)
+	}
+
+	c.r = reader
+	c.dlq = writer
+	c.codecs = codecs
+
+	return c, nil
 }
 
-func (c *Subscriber[T]) Subscribe(ctx context.Context) <-chan T {
-	c.log.Info("subscriber was started")
-	go c.processMessages(ctx)
-	return c.values
+func (c *Subscriber[T]) onAssigned(_ context.Context, _ *kgo.Client, assigned map[string][]int32) {
+	if p := c.pool.Load(); p != nil {
+		p.assign(assigned)
+	}
 }
 
-func (c *Subscriber[T]) processMessages(ctx context.Context) {
+// onRevoked commits the processed records of the revoked partitions before
+// the rebalance goes on, waiting no longer than the drain timeout.
+func (c *Subscriber[T]) onRevoked(ctx context.Context, _ *kgo.Client, revoked map[string][]int32) {
+	p := c.pool.Load()
+	if p == nil || len(revoked) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.cfg.DrainTimeout)
+	defer cancel()
+
+	err := p.revoke(ctx, revoked)
+	if err != nil {
+		c.log.Warn("revoked partitions were not drained in time", slog.String("error", err.Error()))
+		return
+	}
+
+	c.log.Info("revoked partitions were drained", slog.Any("partitions", revoked))
+}
+
+func (c *Subscriber[T]) onLost(_ context.Context, _ *kgo.Client, lost map[string][]int32) {
+	if p := c.pool.Load(); p != nil {
+		p.lose(lost)
+	}
+
+	c.log.Warn("partitions were lost", slog.Any("partitions", lost))
+}
+
+func (c *Subscriber[T]) Subscribe(ctx context.Context, handle BatchHandler[T]) {
+	c.log.Info(
+		"subscriber was started",
+		slog.Int("batch_size", c.cfg.BatchSize),
+		slog.Int("workers", c.cfg.Workers),
+		slog.String("route", string(c.cfg.Route)),
+	)
+	go c.processMessages(ctx, handle)
+}
+
+// Wait blocks until the subscriber is stopped and its workers are drained.
+func (c *Subscriber[T]) Wait() {
+	<-c.done
+}
+
+func (c *Subscriber[T]) processMessages(ctx context.Context, handle BatchHandler[T]) {
+	defer close(c.done)
 	defer c.Close()
 
+	p := newPool(c.log, c.cfg, c.mc, func(ctx context.Context, records []*kgo.Record) error {
+		return c.processBatch(ctx, records, handle)
+	}, c.commit)
+	defer p.drain(c.cfg.DrainTimeout)
+
+	c.pool.Store(p)
+
 	for {
-		fetches := c.r.PollFetches(ctx)
+		fetches := c.r.PollRecords(ctx, c.cfg.BatchSize)
 		errs := fetches.Errors()
 		if len(errs) > 0 {
 			if ctx.Err() != nil {
@@ -71,64 +189,213 @@ func (c *Subscriber[T]) processMessages(ctx context.Context) {
 			continue
 		}
 
-		iter := fetches.RecordIter()
+		records := fetches.Records()
+		if len(records) == 0 {
+			continue
+		}
+
+		err := p.dispatch(ctx, records)
+		if err != nil {
+			// the batch is not committed and will be consumed again after restart
+			c.log.Warn("processing was interrupted", slog.String("error", err.Error()))
+			return
+		}
+	}
+}
 
-		for !iter.Done() {
-			record := iter.Next()
+func (c *Subscriber[T]) processBatch(ctx context.Context, records []*kgo.Record, handle BatchHandler[T]) error {
+	c.log.Info("subscriber got messages", slog.Int("count", len(records)))
 
-			c.log.Info(
-				"subscriber got message",
-				slog.String("uid", string(record.Key)),
-				slog.Int("bytes", len(record.Value)),
-			)
+	var (
+		values = make([]T, 0, len(records))
+		valid  = make([]*kgo.Record, 0, len(records))
+	)
 
-			var value T
-			err := json.Unmarshal(record.Value, &value)
+	for _, record := range records {
+		value, kind, err := c.decode(record)
+		if err != nil {
+			c.log.Error(err, "failed to decode message", slog.String("uid", string(record.Key)))
+
+			err = c.sendDLQ(ctx, record, kind, err)
 			if err != nil {
-				c.log.Error(err, "failed to to decode json")
-				c.sendDLQ(ctx, record)
-				continue
+				return err
 			}
 
-			err = value.Validate()
-			if err != nil {
-				c.log.Error(err, "failed to validate value")
-				c.sendDLQ(ctx, record)
-				continue
+			continue
+		}
+
+		values = append(values, value)
+		valid = append(valid, record)
+	}
+
+	if len(values) > 0 {
+		err := c.retry(ctx, c.cfg.RetryAttempts, func(ctx context.Context) error {
+			return handle(ctx, values)
+		})
+		if err != nil {
+			if ctx.Err() != nil {
+				return err
 			}
 
-			c.values <- value
-			err = c.r.CommitRecords(ctx, record)
-			if err != nil {
-				c.log.Error(err, "failed to commit record")
+			var batchErr *BatchError
+			if errors.As(err, &batchErr) {
+				return c.sendFailedDLQ(ctx, valid, batchErr)
+			}
+
+			c.log.Error(err, "failed to process messages", slog.Int("count", len(values)))
+
+			kind := ErrorTransient
+			if errors.Is(err, ErrPermanent) {
+				kind = ErrorRejected
+			}
+
+			for _, record := range valid {
+				err := c.sendDLQ(ctx, record, kind, err)
+				if err != nil {
+					return err
+				}
 			}
 		}
 	}
+
+	return nil
 }
 
-func (c *Subscriber[T]) sendDLQ(ctx context.Context, record *kgo.Record) {
-	res := c.dlq.ProduceSync(ctx, &kgo.Record{
-		Key:   record.Key,
-		Value: record.Value,
-	})
+// sendFailedDLQ sends the failed records of the batch to the dlq.
+func (c *Subscriber[T]) sendFailedDLQ(ctx context.Context, records []*kgo.Record, batchErr *BatchError) error {
+	for _, i := range slices.Sorted(maps.Keys(batchErr.Failed)) {
+		if i < 0 || i >= len(records) {
+			continue
+		}
+
+		err := c.sendDLQ(ctx, records[i], ErrorRejected, batchErr.Failed[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
 
-	if err := res.FirstErr(); err != nil {
-		c.log.Error(err, "failed to publish dlq message")
+func (c *Subscriber[T]) decode(record *kgo.Record) (value T, kind ErrorKind, err error) {
+	codec, err := c.codecs.forRecord(record)
+	if err != nil {
+		return value, ErrorDecode, err
+	}
+
+	data := record.Value
+
+	if c.schema != nil {
+		data, kind, err = c.upcast(record, codec)
+		if err != nil {
+			return value, kind, err
+		}
+	}
+
+	err = codec.Unmarshal(data, &value)
+	if err != nil {
+		return value, ErrorDecode, fmt.Errorf("decode %s: %w", codec.Name(), err)
 	}
 
-	if err := c.r.CommitRecords(ctx, record); err != nil {
-		c.log.Error(err, "failed to commit invalid message")
+	err = value.Validate()
+	if err != nil {
+		return value, ErrorValidation, fmt.Errorf("validate value: %w", err)
+	}
+
+	return value, "", nil
+}
+
+func (c *Subscriber[T]) upcast(record *kgo.Record, codec Codec) ([]byte, ErrorKind, error) {
+	version, err := c.schema.version(record, codec)
+	if err == nil {
+		err = c.schema.check(version)
 	}
 
-	c.log.Info("message was sent to dlq")
+	if err != nil {
+		return nil, ErrorUnsupportedVersion, err
+	}
+
+	// binary codecs rely on backward compatible schemas instead of upcasting
+	if version == c.schema.Current() || codec.Name() != CodecJSON {
+		return record.Value, "", nil
+	}
+
+	data, err := c.schema.upcast(version, record.Value)
+	if err != nil {
+		return nil, ErrorDecode, fmt.Errorf("upcast json: %w", err)
+	}
+
+	return data, "", nil
+}
+
+// retry repeats fn with exponential backoff until it succeeds, fails with
+// ErrPermanent, the attempts are exhausted (zero means no limit) or the
+// context is cancelled.
+func (c *Subscriber[T]) retry(ctx context.Context, attempts int, fn func(context.Context) error) error {
+	backoff := c.cfg.RetryMinBackoff
+
+	for attempt := 1; ; attempt++ {
+		err := fn(ctx)
+		if err == nil {
+			return nil
+		}
+
+		if errors.Is(err, ErrPermanent) {
+			return err
+		}
+
+		if attempts > 0 && attempt >= attempts {
+			return fmt.Errorf("retries exhausted after %d attempts: %w", attempt, err)
+		}
+
+		c.log.Warn(
+			"failed to process messages, retrying",
+			slog.Int("attempt", attempt),
+			slog.Duration("backoff", backoff),
+			slog.String("error", err.Error()),
+		)
+		c.mc.IncRetries()
+
+		select {
+		case <-ctx.Done():
+			return errors.Join(err, ctx.Err())
+		case <-time.After(backoff):
+		}
+
+		backoff = min(backoff*2, c.cfg.RetryMaxBackoff)
+	}
+}
+
+func (c *Subscriber[T]) commit(ctx context.Context, records ...*kgo.Record) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), commitTimeout)
+	defer cancel()
+
+	err := c.r.CommitRecords(ctx, records...)
+	if err != nil {
+		c.log.Error(err, "failed to commit record")
+	}
+}
+
+func (c *Subscriber[T]) sendDLQ(ctx context.Context, record *kgo.Record, kind ErrorKind, reason error) error {
+	dead := deadLetterRecord(record, kind, reason, time.Now())
+
+	err := c.retry(ctx, 0, func(ctx context.Context) error {
+		return c.dlq.ProduceSync(ctx, dead).FirstErr()
+	})
+	if err != nil {
+		return fmt.Errorf("publish dlq message: %w", err)
+	}
+
+	c.log.Info("message was sent to dlq", slog.String("kind", string(kind)))
 
 	c.mc.IncFailed()
+
+	return nil
 }
 
 func (c *Subscriber[T]) Close() {
 	c.r.Close()
 	c.dlq.Close()
-	close(c.values)
 	c.log.Info("subscriber was stopped")
 }
 
@@ -0,0 +1,105 @@
+package broker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+var testRetryConfig = &Config{
+	RetryMinBackoff: time.Millisecond,
+	RetryMaxBackoff: 4 * time.Millisecond,
+}
+
+func TestRetrySucceeded(t *testing.T) {
+	var (
+		mc  = metrics.NewMockMetrics(gomock.NewController(t))
+		sub = &Subscriber[entity.Order]{cfg: testRetryConfig, log: logger.NewNoOp(), mc: mc}
+	)
+
+	mc.EXPECT().IncRetries().Times(2)
+
+	calls := 0
+
+	err := sub.retry(context.Background(), 0, func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestRetryExhausted(t *testing.T) {
+	var (
+		mc  = metrics.NewMockMetrics(gomock.NewController(t))
+		cfg = *testRetryConfig
+		sub = &Subscriber[entity.Order]{cfg: &cfg, log: logger.NewNoOp(), mc: mc}
+	)
+
+	cfg.RetryAttempts = 3
+
+	mc.EXPECT().IncRetries().Times(2)
+
+	calls := 0
+
+	err := sub.retry(context.Background(), 3, func(context.Context) error {
+		calls++
+		return errors.New("connection refused")
+	})
+
+	require.Error(t, err)
+	require.Equal(t, 3, calls)
+}
+
+func TestRetryPermanent(t *testing.T) {
+	sub := &Subscriber[entity.Order]{cfg: testRetryConfig, log: logger.NewNoOp(), mc: metrics.NewMockMetrics(gomock.NewController(t))}
+
+	calls := 0
+
+	err := sub.retry(context.Background(), 0, func(context.Context) error {
+		calls++
+		return fmt.Errorf("%w: value out of range", ErrPermanent)
+	})
+
+	require.ErrorIs(t, err, ErrPermanent)
+	require.Equal(t, 1, calls, "permanent errors aren't retried")
+
+	err = sub.retry(context.Background(), 0, func(context.Context) error {
+		calls++
+		return &BatchError{Failed: map[int]error{1: errors.New("value out of range")}}
+	})
+
+	require.ErrorIs(t, err, ErrPermanent)
+	require.Equal(t, 2, calls, "partly failed batches aren't retried")
+}
+
+func TestRetryCancelled(t *testing.T) {
+	var (
+		mc  = metrics.NewMockMetrics(gomock.NewController(t))
+		sub = &Subscriber[entity.Order]{cfg: testRetryConfig, log: logger.NewNoOp(), mc: mc}
+	)
+
+	mc.EXPECT().IncRetries().AnyTimes()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := sub.retry(ctx, 0, func(context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,97 @@
+package broker
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+)
+
+const HeaderSchemaVersion = "x-schema-version"
+
+// VersionField is read from JSON payloads sent without the version header.
+const VersionField = "version"
+
+var ErrUnsupportedVersion = errors.New("unsupported schema version")
+
+// Upcaster converts a JSON payload of one schema version to the next one.
+type Upcaster func(payload map[string]any) error
+
+// Schema describes the versions of a message accepted by a subscriber.
+// Messages without a version are treated as the first version, which was
+// sent before versioning was introduced.
+type Schema struct {
+	current   int
+	upcasters []Upcaster
+}
+
+// NewSchema creates a schema where upcasters[i] converts version i+1 to
+// version i+2, so the current version is len(upcasters)+1.
+func NewSchema(upcasters ...Upcaster) *Schema {
+	return &Schema{
+		current:   len(upcasters) + 1,
+		upcasters: upcasters,
+	}
+}
+
+func (s *Schema) Current() int {
+	return s.current
+}
+
+func (s *Schema) version(record *kgo.Record, codec Codec) (int, error) {
+	if v := header(record, HeaderSchemaVersion); v != "" {
+		version, err := strconv.Atoi(v)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %q", ErrUnsupportedVersion, v)
+		}
+
+		return version, nil
+	}
+
+	if codec.Name() != CodecJSON {
+		return 1, nil
+	}
+
+	var probe struct {
+		Version *int `json:"version"`
+	}
+
+	// invalid payloads are reported by the codec itself
+	if json.Unmarshal(record.Value, &probe) != nil || probe.Version == nil {
+		return 1, nil
+	}
+
+	return *probe.Version, nil
+}
+
+func (s *Schema) check(version int) error {
+	switch {
+	case version > s.current:
+		return fmt.Errorf("%w: version %d is newer than supported %d", ErrUnsupportedVersion, version, s.current)
+	case version < 1:
+		return fmt.Errorf("%w: version %d", ErrUnsupportedVersion, version)
+	}
+
+	return nil
+}
+
+// upcast converts a JSON payload of the given version to the current one.
+func (s *Schema) upcast(version int, data []byte) ([]byte, error) {
+	var payload map[string]any
+
+	err := json.Unmarshal(data, &payload)
+	if err != nil {
+		return nil, err
+	}
+
+	for v := version; v < s.current; v++ {
+		err := s.upcasters[v-1](payload)
+		if err != nil {
+			return nil, fmt.Errorf("upcast from version %d: %w", v, err)
+		}
+	}
+
+	return json.Marshal(payload)
+}
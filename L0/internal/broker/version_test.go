@@ -0,0 +1,177 @@
+package broker
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kgo"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/entity/entitytest"
+	"github.com/imotkin/L0/internal/logger"
+)
+
+func testSchema() *Schema {
+	return NewSchema(
+		func(payload map[string]any) error {
+			payload["status"] = "created"
+			return nil
+		},
+		func(payload map[string]any) error {
+			payload["locale"] = payload["lang"]
+			delete(payload, "lang")
+			return nil
+		},
+	)
+}
+
+func TestSchemaVersion(t *testing.T) {
+	schema := testSchema()
+
+	tests := []struct {
+		name    string
+		record  *kgo.Record
+		codec   Codec
+		version int
+		err     error
+	}{
+		{
+			name:    "missing",
+			record:  &kgo.Record{Value: []byte(`{"order_uid":"1"}`)},
+			codec:   jsonCodec{},
+			version: 1,
+		},
+		{
+			name:    "field",
+			record:  &kgo.Record{Value: []byte(`{"version":2}`)},
+			codec:   jsonCodec{},
+			version: 2,
+		},
+		{
+			name: "header",
+			record: &kgo.Record{
+				Value:   []byte(`{"version":2}`),
+				Headers: []kgo.RecordHeader{{Key: HeaderSchemaVersion, Value: []byte("3")}},
+			},
+			codec:   jsonCodec{},
+			version: 3,
+		},
+		{
+			name:    "binary",
+			record:  &kgo.Record{Value: []byte{0x0a}},
+			codec:   protobufCodec{},
+			version: 1,
+		},
+		{
+			name: "invalid header",
+			record: &kgo.Record{
+				Headers: []kgo.RecordHeader{{Key: HeaderSchemaVersion, Value: []byte("v2")}},
+			},
+			codec: jsonCodec{},
+			err:   ErrUnsupportedVersion,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := schema.version(tt.record, tt.codec)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Equal(t, tt.version, version)
+		})
+	}
+
+	require.Equal(t, 3, schema.Current())
+	require.ErrorIs(t, schema.check(4), ErrUnsupportedVersion)
+	require.ErrorIs(t, schema.check(0), ErrUnsupportedVersion)
+	require.NoError(t, schema.check(1))
+}
+
+func TestSchemaUpcast(t *testing.T) {
+	data, err := testSchema().upcast(1, []byte(`{"order_uid":"1","lang":"en"}`))
+	require.NoError(t, err)
+
+	var payload map[string]any
+
+	err = json.Unmarshal(data, &payload)
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"order_uid": "1", "status": "created", "locale": "en"}, payload)
+}
+
+func TestDecodeVersioned(t *testing.T) {
+	codecs, err := newCodecs(&Config{})
+	require.NoError(t, err)
+
+	sub := &Subscriber[entity.Order]{
+		log:    logger.NewNoOp(),
+		codecs: codecs,
+		schema: NewSchema(func(payload map[string]any) error {
+			payload["status"] = string(entity.StatusPaid)
+			return nil
+		}),
+	}
+
+	order := entitytest.Order()
+	order.Status = ""
+
+	data, err := json.Marshal(order)
+	require.NoError(t, err)
+
+	got, kind, err := sub.decode(&kgo.Record{Value: data})
+	require.NoError(t, err)
+	require.Empty(t, kind)
+	require.Equal(t, entity.StatusPaid, got.Status)
+
+	_, kind, err = sub.decode(&kgo.Record{
+		Value:   data,
+		Headers: []kgo.RecordHeader{{Key: HeaderSchemaVersion, Value: []byte("3")}},
+	})
+	require.ErrorIs(t, err, ErrUnsupportedVersion)
+	require.Equal(t, ErrorUnsupportedVersion, kind)
+}
+
+func TestPublishedVersionUpcast(t *testing.T) {
+	codecs, err := newCodecs(&Config{})
+	require.NoError(t, err)
+
+	var (
+		schema = NewSchema(func(payload map[string]any) error {
+			payload["status"] = string(entity.StatusPaid)
+			return nil
+		})
+		sub = &Subscriber[entity.Order]{log: logger.NewNoOp(), codecs: codecs, schema: schema}
+		// the producer still sends the first version of orders
+		old     = &Publisher{codecs: codecs, schemas: map[string]*Schema{"orders": NewSchema()}}
+		current = &Publisher{codecs: codecs, schemas: map[string]*Schema{"orders": schema}}
+	)
+
+	order := entitytest.Order()
+	order.Status = ""
+
+	record, err := old.record("orders", order.UID.String(), order)
+	require.NoError(t, err)
+	require.Equal(t, "1", header(record, HeaderSchemaVersion))
+
+	got, _, err := sub.decode(record)
+	require.NoError(t, err)
+	require.Equal(t, entity.StatusPaid, got.Status, "old orders are upcast")
+
+	order.Status = entity.StatusShipped
+
+	record, err = current.record("orders", order.UID.String(), order)
+	require.NoError(t, err)
+	require.Equal(t, "2", header(record, HeaderSchemaVersion))
+
+	got, _, err = sub.decode(record)
+	require.NoError(t, err)
+	require.Equal(t, order, got, "current orders are decoded as is")
+
+	record, err = current.record("orders-status", order.UID.String(), entity.StatusChange{To: entity.StatusPaid})
+	require.NoError(t, err)
+	require.Empty(t, header(record, HeaderSchemaVersion), "only topics with a schema are versioned")
+}
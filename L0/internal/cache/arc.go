@@ -0,0 +1,118 @@
+package cache
+
+// arc implements the Adaptive Replacement Cache: t1 holds keys seen once,
+// t2 keys seen at least twice, b1 and b2 remember keys recently evicted from
+// them. The target size of t1 adapts to hits in the ghost lists, so bulk
+// loads and scans don't flush frequently used keys.
+type arc[K comparable] struct {
+	capacity int
+	p        int
+
+	t1, t2 *lruList[K]
+	b1, b2 *lruList[K]
+}
+
+func newARC[K comparable](capacity int) *arc[K] {
+	return &arc[K]{
+		capacity: capacity,
+		t1:       newLRUList[K](),
+		t2:       newLRUList[K](),
+		b1:       newLRUList[K](),
+		b2:       newLRUList[K](),
+	}
+}
+
+func (p *arc[K]) admit(key K) []K {
+	var evicted []K
+
+	switch {
+	case p.b1.contains(key):
+		p.p = min(p.capacity, p.p+max(p.b2.len()/p.b1.len(), 1))
+		evicted = p.replace(evicted, false)
+
+		p.b1.remove(key)
+		p.t2.pushFront(key)
+
+		return evicted
+	case p.b2.contains(key):
+		p.p = max(0, p.p-max(p.b1.len()/p.b2.len(), 1))
+		evicted = p.replace(evicted, true)
+
+		p.b2.remove(key)
+		p.t2.pushFront(key)
+
+		return evicted
+	}
+
+	l1 := p.t1.len() + p.b1.len()
+	total := l1 + p.t2.len() + p.b2.len()
+
+	switch {
+	case l1 >= p.capacity:
+		if p.t1.len() < p.capacity {
+			p.b1.popBack()
+			evicted = p.replace(evicted, false)
+		} else if victim, ok := p.t1.popBack(); ok {
+			evicted = append(evicted, victim)
+		}
+	case total >= p.capacity:
+		if total >= 2*p.capacity {
+			p.b2.popBack()
+		}
+
+		evicted = p.replace(evicted, false)
+	}
+
+	p.t1.pushFront(key)
+
+	return evicted
+}
+
+// replace evicts a key from t1 or t2 into the matching ghost list when the
+// cache is full.
+func (p *arc[K]) replace(evicted []K, inB2 bool) []K {
+	if p.t1.len()+p.t2.len() < p.capacity {
+		return evicted
+	}
+
+	return append(evicted, p.demote(inB2))
+}
+
+func (p *arc[K]) demote(inB2 bool) K {
+	if p.t1.len() > 0 && (p.t1.len() > p.p || (inB2 && p.t1.len() == p.p) || p.t2.len() == 0) {
+		key, _ := p.t1.popBack()
+		p.b1.pushFront(key)
+
+		return key
+	}
+
+	key, _ := p.t2.popBack()
+	p.b2.pushFront(key)
+
+	return key
+}
+
+func (p *arc[K]) touch(key K) {
+	if p.t1.remove(key) {
+		p.t2.pushFront(key)
+		return
+	}
+
+	if p.t2.contains(key) {
+		p.t2.moveToFront(key)
+	}
+}
+
+func (p *arc[K]) remove(key K) {
+	if !p.t1.remove(key) {
+		p.t2.remove(key)
+	}
+}
+
+func (p *arc[K]) victim() (key K, ok bool) {
+	if p.t1.len()+p.t2.len() == 0 {
+		return key, false
+	}
+
+	return p.demote(false), true
+}
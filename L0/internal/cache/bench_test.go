@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"math/rand/v2"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	benchCapacity = 10_000
+	benchKeys     = 20_000
+)
+
+// benchmarkCache runs a read-heavy workload (90% reads) from all goroutines.
+// Compare scaling with: go test -bench Parallel -cpu 1,2,4,8 ./internal/cache
+func benchmarkCache(b *testing.B, cache Cache[int, int]) {
+	for i := range benchCapacity {
+		cache.Set(i, i)
+	}
+
+	b.ResetTimer()
+
+	b.RunParallel(func(pb *testing.PB) {
+		r := rand.New(rand.NewPCG(rand.Uint64(), rand.Uint64()))
+
+		for pb.Next() {
+			key := r.IntN(benchKeys)
+
+			if r.IntN(10) == 0 {
+				cache.Set(key, key)
+			} else {
+				cache.Get(key)
+			}
+		}
+	})
+}
+
+func BenchmarkParallelMemoryCache(b *testing.B) {
+	cache, err := New[int, int](benchCapacity)
+	require.NoError(b, err)
+
+	benchmarkCache(b, cache)
+}
+
+func BenchmarkParallelShardedCache(b *testing.B) {
+	for _, shards := range []int{4, 16, 64} {
+		b.Run(strconv.Itoa(shards), func(b *testing.B) {
+			benchmarkCache(b, newTestSharded[int, int](b, shards, benchCapacity))
+		})
+	}
+
+	b.Run("gomaxprocs", func(b *testing.B) {
+		benchmarkCache(b, newTestSharded[int, int](b, runtime.GOMAXPROCS(0)*4, benchCapacity))
+	})
+}
@@ -1,80 +1,363 @@
 package cache
 
 import (
-	"container/list"
+	"context"
+	"slices"
 	"sync"
+	"time"
+	"unsafe"
 )
 
 type Entry struct {
 	Key   any
 	Value any
+
+	expires time.Time
+	used    time.Time
+	size    int64
+}
+
+func (e *Entry) expired(now time.Time) bool {
+	return !e.expires.IsZero() && !now.Before(e.expires)
 }
 
 type MemoryCache[K comparable, V any] struct {
 	mu       sync.RWMutex
 	capacity int
-	values   map[K]*list.Element
-	queue    *list.List
+	values   map[K]*Entry
+	policy   policy[K]
+
+	policyName string
+	ttl        time.Duration
+	maxBytes   int64
+	bytes      int64
+	onEvict    []EvictFunc[K, V]
+	now        func() time.Time
+
+	hits      uint64
+	misses    uint64
+	evictions uint64
 }
 
-func New[K comparable, V any](capacity int) *MemoryCache[K, V] {
-	return &MemoryCache[K, V]{
+// New creates a cache with the LRU eviction policy unless another policy is
+// set with WithPolicy. It returns ErrUnknownPolicy if the policy is unknown.
+func New[K comparable, V any](capacity int, opts ...Option[K, V]) (*MemoryCache[K, V], error) {
+	c := &MemoryCache[K, V]{
 		capacity: capacity,
-		values:   make(map[K]*list.Element, capacity),
-		queue:    list.New(),
+		values:   make(map[K]*Entry, capacity),
+		now:      time.Now,
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
+
+	policy, err := newPolicy[K](c.policyName, c.capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	c.policy = policy
+
+	return c, nil
 }
 
 func (c *MemoryCache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL stores the value that expires after ttl. Values with
+// a non-positive ttl never expire.
+func (c *MemoryCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	var (
+		now     = c.now()
+		expires time.Time
+	)
+
+	if ttl > 0 {
+		expires = now.Add(ttl)
+	}
+
 	c.mu.Lock()
-	defer c.mu.Unlock()
 
-	if element, ok := c.values[key]; ok {
-		c.queue.MoveToFront(element)
-		element.Value.(*Entry).Value = value
-		return
+	var evicted []eviction[K, V]
+
+	if entry, ok := c.values[key]; ok {
+		c.policy.touch(key)
+
+		c.bytes -= entry.size
+
+		entry.Value, entry.expires, entry.used, entry.size = value, expires, now, sizeOf(value)
+		c.bytes += entry.size
+	} else {
+		entry := &Entry{Key: key, Value: value, expires: expires, used: now, size: sizeOf(value)}
+
+		c.values[key] = entry
+		c.bytes += entry.size
+
+		for _, victim := range c.policy.admit(key) {
+			evicted = append(evicted, c.remove(victim, EvictCapacity))
+		}
 	}
 
-	if c.queue.Len() == c.capacity {
-		c.purge()
+	for c.maxBytes > 0 && c.bytes > c.maxBytes {
+		victim, ok := c.policy.victim()
+		if !ok {
+			break
+		}
+
+		evicted = append(evicted, c.remove(victim, EvictBytes))
+	}
+
+	c.mu.Unlock()
+
+	c.notify(evicted)
+}
+
+// remove deletes the entry which is already forgotten by the policy, it
+// must be called with the lock held. Entries removed without a reason are
+// not counted as evictions.
+func (c *MemoryCache[K, V]) remove(key K, reason EvictReason) eviction[K, V] {
+	entry := c.values[key]
+
+	delete(c.values, key)
+	c.bytes -= entry.size
+
+	if reason != "" {
+		c.evictions++
+	}
+
+	return eviction[K, V]{key: key, value: entry.Value.(V), reason: reason}
+}
+
+func (c *MemoryCache[K, V]) Get(key K) (value V, ok bool) {
+	now := c.now()
+
+	c.mu.Lock()
+
+	entry, ok := c.values[key]
+	if !ok {
+		c.misses++
+		c.mu.Unlock()
+		return value, false
+	}
+
+	// expired entries are removed lazily on read
+	if entry.expired(now) {
+		c.misses++
+		c.policy.remove(key)
+		evicted := []eviction[K, V]{c.remove(key, EvictExpired)}
+		c.mu.Unlock()
+
+		c.notify(evicted)
+
+		return value, false
+	}
+
+	c.hits++
+	c.policy.touch(key)
+	entry.used = now
+	value = entry.Value.(V)
+
+	c.mu.Unlock()
+
+	return value, true
+}
+
+// Peek returns the value without updating its recency and the statistics.
+func (c *MemoryCache[K, V]) Peek(key K) (value V, ok bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	entry, ok := c.values[key]
+	if !ok || entry.expired(c.now()) {
+		return value, false
+	}
+
+	return entry.Value.(V), true
+}
+
+// Delete removes the key from the cache without calling eviction callbacks.
+func (c *MemoryCache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.values[key]; !ok {
+		return false
 	}
 
-	entry := &Entry{Key: key, Value: value}
+	c.policy.remove(key)
+	c.remove(key, "")
+
+	return true
+}
+
+// Clear removes all entries without calling eviction callbacks.
+func (c *MemoryCache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	element := c.queue.PushFront(entry)
-	c.values[key] = element
+	clear(c.values)
+	// the name was checked by New
+	c.policy, _ = newPolicy[K](c.policyName, c.capacity)
+	c.bytes = 0
 }
 
-func (c *MemoryCache[K, V]) purge() {
-	last := c.queue.Back()
-	if last == nil {
+// OnEvict registers a callback called after an entry is removed from the
+// cache. Callbacks run without the cache lock held.
+func (c *MemoryCache[K, V]) OnEvict(fn EvictFunc[K, V]) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.onEvict = append(c.onEvict, fn)
+}
+
+func (c *MemoryCache[K, V]) notify(evicted []eviction[K, V]) {
+	if len(evicted) == 0 {
 		return
 	}
 
-	c.queue.Remove(last)
+	c.mu.RLock()
+	callbacks := c.onEvict
+	c.mu.RUnlock()
 
-	delete(c.values, last.Value.(*Entry).Key.(K))
+	for _, e := range evicted {
+		for _, fn := range callbacks {
+			fn(e.key, e.value, e.reason)
+		}
+	}
 }
 
-func (c *MemoryCache[K, V]) Get(key K) (value V, ok bool) {
+// Cleanup removes all expired entries.
+func (c *MemoryCache[K, V]) Cleanup() int {
+	now := c.now()
+
+	c.mu.Lock()
+
+	var evicted []eviction[K, V]
+
+	for key, entry := range c.values {
+		if entry.expired(now) {
+			c.policy.remove(key)
+			evicted = append(evicted, c.remove(key, EvictExpired))
+		}
+	}
+
+	c.mu.Unlock()
+
+	c.notify(evicted)
+
+	return len(evicted)
+}
+
+// Run removes expired entries in the background until ctx is done.
+func (c *MemoryCache[K, V]) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Cleanup()
+		}
+	}
+}
+
+// Keys returns the keys of entries which are not expired, the most recently
+// used first.
+func (c *MemoryCache[K, V]) Keys() []K {
+	return recentKeys(c.usage())
+}
+
+// usage returns the keys of entries which are not expired with the time
+// they were last used.
+func (c *MemoryCache[K, V]) usage() []keyUsage[K] {
+	now := c.now()
+
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	v, ok := c.values[key]
-	if !ok {
-		return value, ok
+	keys := make([]keyUsage[K], 0, len(c.values))
+
+	for key, entry := range c.values {
+		if !entry.expired(now) {
+			keys = append(keys, keyUsage[K]{key: key, used: entry.used})
+		}
 	}
 
-	return v.Value.(*Entry).Value.(V), ok
+	return keys
+}
+
+type keyUsage[K comparable] struct {
+	key  K
+	used time.Time
+}
+
+// recentKeys sorts the keys from the most to the least recently used.
+func recentKeys[K comparable](usage []keyUsage[K]) []K {
+	slices.SortFunc(usage, func(a, b keyUsage[K]) int {
+		return b.used.Compare(a.used)
+	})
+
+	keys := make([]K, len(usage))
+	for i, u := range usage {
+		keys[i] = u.key
+	}
+
+	return keys
 }
 
 func (c *MemoryCache[K, V]) Len() int {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 
-	return c.queue.Len()
+	return len(c.values)
 }
 
 func (c *MemoryCache[K, V]) Cap() int {
 	return c.capacity
 }
+
+func (c *MemoryCache[K, V]) Stats() Stats {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return Stats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Size:      len(c.values),
+		Capacity:  c.capacity,
+		Bytes:     c.bytes,
+	}
+}
+
+// Bytes returns the approximate size of the stored values.
+func (c *MemoryCache[K, V]) Bytes() int64 {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.bytes
+}
+
+type eviction[K comparable, V any] struct {
+	key    K
+	value  V
+	reason EvictReason
+}
+
+// Sizer is implemented by values which know their approximate size in
+// memory. Other values are measured by their shallow size.
+type Sizer interface {
+	Size() int
+}
+
+func sizeOf[V any](value V) int64 {
+	if s, ok := any(value).(Sizer); ok {
+		return int64(s.Size())
+	}
+
+	return int64(unsafe.Sizeof(value))
+}
@@ -3,12 +3,14 @@ package cache
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 func TestMemoryCacheGetNonExistent(t *testing.T) {
-	cache := New[string, int](10)
+	cache, err := New[string, int](10)
+	require.NoError(t, err)
 
 	_, ok := cache.Get("hello")
 
@@ -16,7 +18,8 @@ func TestMemoryCacheGetNonExistent(t *testing.T) {
 }
 
 func TestMemoryCacheGetExistent(t *testing.T) {
-	cache := New[string, int](10)
+	cache, err := New[string, int](10)
+	require.NoError(t, err)
 
 	cache.Set("hello", 123)
 
@@ -26,7 +29,8 @@ func TestMemoryCacheGetExistent(t *testing.T) {
 }
 
 func TestMemoryCacheSet(t *testing.T) {
-	cache := New[string, int](10)
+	cache, err := New[string, int](10)
+	require.NoError(t, err)
 
 	for i := range 10 {
 		cache.Set(strconv.Itoa(i), i)
@@ -42,7 +46,8 @@ func TestMemoryCacheSet(t *testing.T) {
 }
 
 func TestMemoryCacheOverflow(t *testing.T) {
-	cache := New[string, int](1)
+	cache, err := New[string, int](1)
+	require.NoError(t, err)
 
 	values := map[string]int{
 		"hello": 123,
@@ -57,3 +62,190 @@ func TestMemoryCacheOverflow(t *testing.T) {
 	require.Equal(t, 1, cache.Len())
 	require.Equal(t, 1, cache.Cap())
 }
+
+type testClock struct {
+	now time.Time
+}
+
+func (c *testClock) Now() time.Time {
+	return c.now
+}
+
+func (c *testClock) Add(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func TestMemoryCacheKeys(t *testing.T) {
+	clock := &testClock{now: time.Now()}
+
+	cache, err := New(10, withClock[string, int](clock.Now))
+	require.NoError(t, err)
+
+	for _, key := range []string{"a", "b", "c"} {
+		cache.Set(key, 0)
+		clock.Add(time.Second)
+	}
+
+	cache.Get("a")
+	clock.Add(time.Second)
+	cache.Peek("b")
+
+	require.Equal(t, []string{"a", "c", "b"}, cache.Keys())
+}
+
+type evicted struct {
+	key    string
+	reason EvictReason
+}
+
+func TestMemoryCacheTTL(t *testing.T) {
+	var (
+		clock = &testClock{now: time.Now()}
+		got   []evicted
+	)
+
+	cache, err := New(10,
+		WithTTL[string, int](time.Minute),
+		withClock[string, int](clock.Now),
+		WithOnEvict(func(key string, _ int, reason EvictReason) {
+			got = append(got, evicted{key, reason})
+		}),
+	)
+	require.NoError(t, err)
+
+	cache.Set("default", 1)
+	cache.SetWithTTL("short", 2, time.Second)
+	cache.SetWithTTL("forever", 3, 0)
+
+	clock.Add(2 * time.Second)
+
+	_, ok := cache.Get("short")
+	require.False(t, ok)
+	require.Equal(t, 2, cache.Len()) // removed lazily on read
+
+	v, ok := cache.Get("default")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	clock.Add(time.Hour)
+
+	require.Equal(t, 1, cache.Cleanup())
+	require.Equal(t, 1, cache.Len())
+
+	_, ok = cache.Get("forever")
+	require.True(t, ok)
+
+	require.Equal(t, []evicted{
+		{"short", EvictExpired},
+		{"default", EvictExpired},
+	}, got)
+}
+
+type sized int
+
+func (s sized) Size() int {
+	return int(s)
+}
+
+func TestMemoryCacheMaxBytes(t *testing.T) {
+	var got []evicted
+
+	cache, err := New(10,
+		WithMaxBytes[string, sized](100),
+		WithOnEvict(func(key string, _ sized, reason EvictReason) {
+			got = append(got, evicted{key, reason})
+		}),
+	)
+	require.NoError(t, err)
+
+	cache.Set("a", 40)
+	cache.Set("b", 40)
+	require.Equal(t, int64(80), cache.Bytes())
+
+	cache.Set("c", 40)
+	require.Equal(t, int64(80), cache.Bytes())
+	require.Equal(t, 2, cache.Len())
+
+	_, ok := cache.Get("a")
+	require.False(t, ok)
+
+	cache.Set("b", 10) // updated values are measured again
+	require.Equal(t, int64(50), cache.Bytes())
+
+	cache.Set("huge", 500) // values larger than the budget are not kept
+	require.Zero(t, cache.Len())
+	require.Zero(t, cache.Bytes())
+
+	require.Equal(t, []evicted{
+		{"a", EvictBytes},
+		{"c", EvictBytes},
+		{"b", EvictBytes},
+		{"huge", EvictBytes},
+	}, got)
+}
+
+func TestMemoryCacheEvictCapacity(t *testing.T) {
+	var got []evicted
+
+	cache, err := New[string, int](2)
+	require.NoError(t, err)
+	cache.OnEvict(func(key string, _ int, reason EvictReason) {
+		got = append(got, evicted{key, reason})
+	})
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Set("c", 3)
+
+	require.Equal(t, []evicted{{"a", EvictCapacity}}, got)
+}
+
+func TestMemoryCacheGetRecency(t *testing.T) {
+	cache, err := New[string, int](2)
+	require.NoError(t, err)
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+
+	_, ok := cache.Get("a") // "a" becomes the most recently used
+	require.True(t, ok)
+
+	cache.Set("c", 3)
+
+	_, ok = cache.Get("a")
+	require.True(t, ok)
+
+	_, ok = cache.Get("b")
+	require.False(t, ok)
+}
+
+func TestMemoryCacheStats(t *testing.T) {
+	cache, err := New[string, int](2)
+	require.NoError(t, err)
+
+	cache.Set("a", 1)
+	cache.Set("b", 2)
+	cache.Get("a")
+	cache.Get("c")
+	cache.Set("c", 3) // evicts b
+
+	v, ok := cache.Peek("a")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	stats := cache.Stats()
+	require.Equal(t, Stats{Hits: 1, Misses: 1, Evictions: 1, Size: 2, Capacity: 2, Bytes: stats.Bytes}, stats)
+	require.Equal(t, 0.5, stats.HitRatio())
+
+	require.True(t, cache.Delete("a"))
+	require.Equal(t, uint64(1), cache.Stats().Evictions)
+
+	cache.Clear()
+	require.Zero(t, cache.Len())
+	require.Zero(t, cache.Bytes())
+	require.Equal(t, uint64(1), cache.Stats().Hits)
+
+	cache.Set("d", 4)
+	cache.Set("e", 5)
+	require.Equal(t, 2, cache.Len())
+}
@@ -1,5 +1,98 @@
 package cache
 
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	BackendMemory = "memory"
+	BackendRedis  = "redis"
+	BackendTiered = "tiered"
+)
+
+var Backends = []any{BackendMemory, BackendRedis, BackendTiered}
+
+const (
+	WarmupRecent   = "recent"
+	WarmupSnapshot = "snapshot"
+	WarmupNone     = "none"
+)
+
+var WarmupStrategies = []any{WarmupRecent, WarmupSnapshot, WarmupNone}
+
 type Config struct {
-	Size int `koanf:"size"`
+	Backend         string        `koanf:"backend"`
+	Size            int           `koanf:"size"`
+	Shards          int           `koanf:"shards"`
+	Policy          string        `koanf:"policy"`
+	TracePath       string        `koanf:"trace_path"`
+	TTL             time.Duration `koanf:"ttl"`
+	MaxBytes        int64         `koanf:"max_bytes"`
+	CleanupInterval time.Duration `koanf:"cleanup_interval"`
+	NegativeTTL     time.Duration `koanf:"negative_ttl"`
+	NegativeSize    int           `koanf:"negative_size"`
+	Redis           *RedisConfig  `koanf:"redis"`
+	Warmup          *WarmupConfig `koanf:"warmup"`
+}
+
+func (c *Config) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Backend, validation.In(Backends...)),
+		validation.Field(&c.Size, validation.Required, validation.Min(1)),
+		validation.Field(&c.Shards, validation.Min(0), validation.Max(c.Size)),
+		validation.Field(&c.Policy, validation.In(Policies...)),
+		validation.Field(&c.TTL, validation.Min(time.Duration(0))),
+		validation.Field(&c.MaxBytes, validation.Min(int64(0))),
+		validation.Field(&c.CleanupInterval, validation.When(c.TTL > 0, validation.Required)),
+		validation.Field(&c.NegativeTTL, validation.Min(time.Duration(0))),
+		validation.Field(&c.NegativeSize, validation.When(c.NegativeTTL > 0, validation.Required, validation.Min(1))),
+		validation.Field(&c.Redis, validation.When(c.Backend == BackendRedis || c.Backend == BackendTiered, validation.Required)),
+	)
+}
+
+type WarmupConfig struct {
+	Strategy     string `koanf:"strategy"`
+	Size         int    `koanf:"size"`
+	BatchSize    int    `koanf:"batch_size"`
+	SnapshotPath string `koanf:"snapshot_path"`
+}
+
+func (c *WarmupConfig) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Strategy, validation.In(WarmupStrategies...)),
+		validation.Field(&c.Size, validation.Min(0)),
+		validation.Field(&c.BatchSize, validation.Min(0)),
+		validation.Field(&c.SnapshotPath, validation.When(c.Strategy == WarmupSnapshot, validation.Required)),
+	)
+}
+
+type RedisConfig struct {
+	Addr       string        `koanf:"addr"`
+	Password   string        `koanf:"password"`
+	DB         int           `koanf:"db"`
+	Prefix     string        `koanf:"prefix"`
+	Serializer string        `koanf:"serializer"`
+	Channel    string        `koanf:"channel"`
+	Timeout    time.Duration `koanf:"timeout"`
+}
+
+func (c *RedisConfig) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Addr, validation.Required),
+		validation.Field(&c.DB, validation.Min(0)),
+		validation.Field(&c.Prefix, validation.Required),
+		validation.Field(&c.Serializer, validation.In(Serializers...)),
+		validation.Field(&c.Timeout, validation.Min(time.Duration(0))),
+	)
+}
+
+func (c *RedisConfig) Options() *redis.Options {
+	return &redis.Options{
+		Addr:     c.Addr,
+		Password: c.Password,
+		DB:       c.DB,
+	}
 }
@@ -1,8 +1,23 @@
 package cache
 
+import "time"
+
 type Cache[K comparable, V any] interface {
 	Set(key K, value V)
+	SetWithTTL(key K, value V, ttl time.Duration)
 	Get(key K) (value V, ok bool)
+	Peek(key K) (value V, ok bool)
+	Delete(key K) bool
+	Clear()
+	OnEvict(fn EvictFunc[K, V])
+	Keys() []K
 	Len() int
 	Cap() int
+	Stats() Stats
+}
+
+// Purger is implemented by caches whose Clear can fail. Purge clears the
+// cache and reports the error, which Clear only logs.
+type Purger interface {
+	Purge() error
 }
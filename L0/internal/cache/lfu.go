@@ -0,0 +1,111 @@
+package cache
+
+import "container/heap"
+
+// lfu evicts the least frequently used key, ties are broken by recency.
+type lfu[K comparable] struct {
+	capacity int
+	tick     uint64
+	heap     lfuHeap[K]
+	items    map[K]*lfuItem[K]
+}
+
+type lfuItem[K comparable] struct {
+	key   K
+	freq  uint64
+	tick  uint64
+	index int
+}
+
+func newLFU[K comparable](capacity int) *lfu[K] {
+	return &lfu[K]{
+		capacity: capacity,
+		items:    make(map[K]*lfuItem[K], capacity),
+	}
+}
+
+func (p *lfu[K]) admit(key K) []K {
+	var evicted []K
+
+	if len(p.items) >= p.capacity {
+		if victim, ok := p.victim(); ok {
+			evicted = append(evicted, victim)
+		}
+	}
+
+	p.tick++
+
+	item := &lfuItem[K]{key: key, freq: 1, tick: p.tick}
+	p.items[key] = item
+	heap.Push(&p.heap, item)
+
+	return evicted
+}
+
+func (p *lfu[K]) touch(key K) {
+	item, ok := p.items[key]
+	if !ok {
+		return
+	}
+
+	p.tick++
+
+	item.freq++
+	item.tick = p.tick
+	heap.Fix(&p.heap, item.index)
+}
+
+func (p *lfu[K]) remove(key K) {
+	item, ok := p.items[key]
+	if !ok {
+		return
+	}
+
+	heap.Remove(&p.heap, item.index)
+	delete(p.items, key)
+}
+
+func (p *lfu[K]) victim() (key K, ok bool) {
+	if len(p.heap) == 0 {
+		return key, false
+	}
+
+	item := heap.Pop(&p.heap).(*lfuItem[K])
+	delete(p.items, item.key)
+
+	return item.key, true
+}
+
+type lfuHeap[K comparable] []*lfuItem[K]
+
+func (h lfuHeap[K]) Len() int { return len(h) }
+
+func (h lfuHeap[K]) Less(i, j int) bool {
+	if h[i].freq != h[j].freq {
+		return h[i].freq < h[j].freq
+	}
+
+	return h[i].tick < h[j].tick
+}
+
+func (h lfuHeap[K]) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index, h[j].index = i, j
+}
+
+func (h *lfuHeap[K]) Push(x any) {
+	item := x.(*lfuItem[K])
+	item.index = len(*h)
+	*h = append(*h, item)
+}
+
+func (h *lfuHeap[K]) Pop() any {
+	old := *h
+	n := len(old)
+
+	item := old[n-1]
+	old[n-1] = nil
+	*h = old[:n-1]
+
+	return item
+}
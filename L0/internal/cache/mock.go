@@ -11,6 +11,7 @@ package cache
 
 import (
 	reflect "reflect"
+	time "time"
 
 	gomock "go.uber.org/mock/gomock"
 )
@@ -53,6 +54,32 @@ func (mr *MockCacheMockRecorder[K, V]) Cap() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Cap", reflect.TypeOf((*MockCache[K, V])(nil).Cap))
 }
 
+// Clear mocks base method.
+func (m *MockCache[K, V]) Clear() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Clear")
+}
+
+// Clear indicates an expected call of Clear.
+func (mr *MockCacheMockRecorder[K, V]) Clear() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Clear", reflect.TypeOf((*MockCache[K, V])(nil).Clear))
+}
+
+// Delete mocks base method.
+func (m *MockCache[K, V]) Delete(key K) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", key)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCacheMockRecorder[K, V]) Delete(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCache[K, V])(nil).Delete), key)
+}
+
 // Get mocks base method.
 func (m *MockCache[K, V]) Get(key K) (V, bool) {
 	m.ctrl.T.Helper()
@@ -68,6 +95,20 @@ func (mr *MockCacheMockRecorder[K, V]) Get(key any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCache[K, V])(nil).Get), key)
 }
 
+// Keys mocks base method.
+func (m *MockCache[K, V]) Keys() []K {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Keys")
+	ret0, _ := ret[0].([]K)
+	return ret0
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockCacheMockRecorder[K, V]) Keys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockCache[K, V])(nil).Keys))
+}
+
 // Len mocks base method.
 func (m *MockCache[K, V]) Len() int {
 	m.ctrl.T.Helper()
@@ -82,6 +123,33 @@ func (mr *MockCacheMockRecorder[K, V]) Len() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Len", reflect.TypeOf((*MockCache[K, V])(nil).Len))
 }
 
+// OnEvict mocks base method.
+func (m *MockCache[K, V]) OnEvict(fn EvictFunc[K, V]) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnEvict", fn)
+}
+
+// OnEvict indicates an expected call of OnEvict.
+func (mr *MockCacheMockRecorder[K, V]) OnEvict(fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnEvict", reflect.TypeOf((*MockCache[K, V])(nil).OnEvict), fn)
+}
+
+// Peek mocks base method.
+func (m *MockCache[K, V]) Peek(key K) (V, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Peek", key)
+	ret0, _ := ret[0].(V)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Peek indicates an expected call of Peek.
+func (mr *MockCacheMockRecorder[K, V]) Peek(key any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Peek", reflect.TypeOf((*MockCache[K, V])(nil).Peek), key)
+}
+
 // Set mocks base method.
 func (m *MockCache[K, V]) Set(key K, value V) {
 	m.ctrl.T.Helper()
@@ -93,3 +161,29 @@ func (mr *MockCacheMockRecorder[K, V]) Set(key, value any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCache[K, V])(nil).Set), key, value)
 }
+
+// SetWithTTL mocks base method.
+func (m *MockCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetWithTTL", key, value, ttl)
+}
+
+// SetWithTTL indicates an expected call of SetWithTTL.
+func (mr *MockCacheMockRecorder[K, V]) SetWithTTL(key, value, ttl any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWithTTL", reflect.TypeOf((*MockCache[K, V])(nil).SetWithTTL), key, value, ttl)
+}
+
+// Stats mocks base method.
+func (m *MockCache[K, V]) Stats() Stats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(Stats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockCacheMockRecorder[K, V]) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockCache[K, V])(nil).Stats))
+}
@@ -0,0 +1,68 @@
+package cache
+
+import "time"
+
+type EvictReason string
+
+const (
+	EvictCapacity EvictReason = "capacity"
+	EvictBytes    EvictReason = "bytes"
+	EvictExpired  EvictReason = "expired"
+)
+
+type EvictFunc[K comparable, V any] func(key K, value V, reason EvictReason)
+
+type Option[K comparable, V any] func(c *MemoryCache[K, V])
+
+// WithTTL sets the default lifetime of values stored with Set.
+func WithTTL[K comparable, V any](ttl time.Duration) Option[K, V] {
+	return func(c *MemoryCache[K, V]) {
+		c.ttl = ttl
+	}
+}
+
+// WithMaxBytes limits the approximate size of stored values. Values can
+// implement Sizer to report their size.
+func WithMaxBytes[K comparable, V any](n int64) Option[K, V] {
+	return func(c *MemoryCache[K, V]) {
+		c.maxBytes = n
+	}
+}
+
+func WithOnEvict[K comparable, V any](fn EvictFunc[K, V]) Option[K, V] {
+	return func(c *MemoryCache[K, V]) {
+		c.onEvict = append(c.onEvict, fn)
+	}
+}
+
+// WithPolicy selects the eviction policy by name, see Policies.
+func WithPolicy[K comparable, V any](name string) Option[K, V] {
+	return func(c *MemoryCache[K, V]) {
+		c.policyName = name
+	}
+}
+
+type RedisOption[K comparable, V any] func(c *RedisCache[K, V])
+
+// WithSerializer replaces the serializer of the config, e.g. with the one
+// that wraps it.
+func WithSerializer[K comparable, V any](s Serializer) RedisOption[K, V] {
+	return func(c *RedisCache[K, V]) {
+		c.serializer = s
+	}
+}
+
+func withClock[K comparable, V any](now func() time.Time) Option[K, V] {
+	return func(c *MemoryCache[K, V]) {
+		c.now = now
+	}
+}
+
+// Options converts the config into cache options.
+func Options[K comparable, V any](cfg *Config) []Option[K, V] {
+	return []Option[K, V]{
+		WithTTL[K, V](cfg.TTL),
+		WithMaxBytes[K, V](cfg.MaxBytes),
+		WithPolicy[K, V](cfg.Policy),
+	}
+}
@@ -0,0 +1,142 @@
+package cache
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+)
+
+const (
+	PolicyLRU     = "lru"
+	PolicyLFU     = "lfu"
+	PolicyARC     = "arc"
+	PolicyTinyLFU = "tinylfu"
+)
+
+var Policies = []any{PolicyLRU, PolicyLFU, PolicyARC, PolicyTinyLFU}
+
+var ErrUnknownPolicy = errors.New("unknown eviction policy")
+
+// policy decides which keys stay in a cache of fixed capacity. Values are
+// stored by the cache itself, the policy only tracks keys.
+type policy[K comparable] interface {
+	// admit records a new key and returns the keys that must be removed to
+	// keep the capacity. The key itself is returned when it is not admitted.
+	admit(key K) []K
+	// touch records a hit of a stored key.
+	touch(key K)
+	// remove forgets a key removed from the cache for another reason.
+	remove(key K)
+	// victim removes and returns the next key to evict.
+	victim() (K, bool)
+}
+
+func newPolicy[K comparable](name string, capacity int) (policy[K], error) {
+	switch name {
+	case "", PolicyLRU:
+		return newLRU[K](capacity), nil
+	case PolicyLFU:
+		return newLFU[K](capacity), nil
+	case PolicyARC:
+		return newARC[K](capacity), nil
+	case PolicyTinyLFU:
+		return newTinyLFU[K](capacity), nil
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownPolicy, name)
+	}
+}
+
+// lruList is a list of keys ordered from the most to the least recently used.
+type lruList[K comparable] struct {
+	queue    *list.List
+	elements map[K]*list.Element
+}
+
+func newLRUList[K comparable]() *lruList[K] {
+	return &lruList[K]{
+		queue:    list.New(),
+		elements: make(map[K]*list.Element),
+	}
+}
+
+func (l *lruList[K]) len() int {
+	return l.queue.Len()
+}
+
+func (l *lruList[K]) contains(key K) bool {
+	_, ok := l.elements[key]
+	return ok
+}
+
+func (l *lruList[K]) pushFront(key K) {
+	l.elements[key] = l.queue.PushFront(key)
+}
+
+func (l *lruList[K]) moveToFront(key K) {
+	l.queue.MoveToFront(l.elements[key])
+}
+
+func (l *lruList[K]) remove(key K) bool {
+	element, ok := l.elements[key]
+	if !ok {
+		return false
+	}
+
+	l.queue.Remove(element)
+	delete(l.elements, key)
+
+	return true
+}
+
+func (l *lruList[K]) back() (key K, ok bool) {
+	last := l.queue.Back()
+	if last == nil {
+		return key, false
+	}
+
+	return last.Value.(K), true
+}
+
+func (l *lruList[K]) popBack() (key K, ok bool) {
+	key, ok = l.back()
+	if ok {
+		l.remove(key)
+	}
+
+	return key, ok
+}
+
+type lru[K comparable] struct {
+	capacity int
+	keys     *lruList[K]
+}
+
+func newLRU[K comparable](capacity int) *lru[K] {
+	return &lru[K]{capacity: capacity, keys: newLRUList[K]()}
+}
+
+func (p *lru[K]) admit(key K) []K {
+	var evicted []K
+
+	if p.keys.len() >= p.capacity {
+		if victim, ok := p.keys.popBack(); ok {
+			evicted = append(evicted, victim)
+		}
+	}
+
+	p.keys.pushFront(key)
+
+	return evicted
+}
+
+func (p *lru[K]) touch(key K) {
+	p.keys.moveToFront(key)
+}
+
+func (p *lru[K]) remove(key K) {
+	p.keys.remove(key)
+}
+
+func (p *lru[K]) victim() (K, bool) {
+	return p.keys.popBack()
+}
@@ -0,0 +1,188 @@
+package cache
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPolicies(t *testing.T) {
+	for _, name := range []string{PolicyLRU, PolicyLFU, PolicyARC, PolicyTinyLFU} {
+		t.Run(name, func(t *testing.T) {
+			cache, err := New(100, WithPolicy[int, int](name))
+			require.NoError(t, err)
+
+			for i := range 1000 {
+				cache.Set(i, i)
+
+				if v, ok := cache.Get(i); ok {
+					require.Equal(t, i, v)
+				}
+
+				require.LessOrEqual(t, cache.Len(), cache.Cap())
+			}
+
+			require.Equal(t, 100, cache.Len())
+
+			cache.Set(999, 0) // updates don't evict
+			require.Equal(t, 100, cache.Len())
+		})
+	}
+}
+
+func TestPolicyUnknown(t *testing.T) {
+	_, err := newPolicy[int]("fifo", 10)
+	require.ErrorIs(t, err, ErrUnknownPolicy)
+
+	_, err = New(10, WithPolicy[int, int]("fifo"))
+	require.ErrorIs(t, err, ErrUnknownPolicy)
+
+	_, err = NewSharded(4, 10, WithPolicy[int, int]("fifo"))
+	require.ErrorIs(t, err, ErrUnknownPolicy)
+}
+
+func TestPolicyVictim(t *testing.T) {
+	for _, name := range []string{PolicyLRU, PolicyLFU, PolicyARC, PolicyTinyLFU} {
+		t.Run(name, func(t *testing.T) {
+			cache, err := New(100, WithPolicy[string, sized](name), WithMaxBytes[string, sized](50))
+			require.NoError(t, err)
+
+			for i := range 20 {
+				cache.Set(strconv.Itoa(i), 10)
+				require.LessOrEqual(t, cache.Bytes(), int64(50))
+			}
+		})
+	}
+}
+
+func TestLFUKeepsFrequent(t *testing.T) {
+	cache, err := New(3, WithPolicy[string, int](PolicyLFU))
+	require.NoError(t, err)
+
+	cache.Set("hot", 1)
+	for range 5 {
+		cache.Get("hot")
+	}
+
+	for i := range 10 {
+		cache.Set(strconv.Itoa(i), i)
+	}
+
+	_, ok := cache.Get("hot")
+	require.True(t, ok)
+}
+
+// TestScanResistance loads hot keys, then scans many unique keys once, like
+// the initial bulk load does, and checks that hot keys survive.
+func TestScanResistance(t *testing.T) {
+	tests := []struct {
+		policy    string
+		resistant bool
+	}{
+		{PolicyLRU, false},
+		{PolicyLFU, true},
+		{PolicyARC, true},
+		{PolicyTinyLFU, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.policy, func(t *testing.T) {
+			cache, err := New(100, WithPolicy[string, int](tt.policy))
+			require.NoError(t, err)
+
+			for range 3 {
+				for i := range 50 {
+					key := "hot-" + strconv.Itoa(i)
+					if _, ok := cache.Get(key); !ok {
+						cache.Set(key, i)
+					}
+				}
+			}
+
+			for i := range 1000 {
+				cache.Set("scan-"+strconv.Itoa(i), i)
+			}
+
+			var hits int
+
+			for i := range 50 {
+				if _, ok := cache.Get("hot-" + strconv.Itoa(i)); ok {
+					hits++
+				}
+			}
+
+			if tt.resistant {
+				require.Greater(t, hits, 40)
+			} else {
+				require.Zero(t, hits)
+			}
+		})
+	}
+}
+
+func TestReplay(t *testing.T) {
+	var (
+		trace bytes.Buffer
+		r     = rand.New(rand.NewPCG(1, 2))
+		zipf  = rand.NewZipf(r, 1.1, 1, 10_000)
+	)
+
+	for i := range 50_000 {
+		if i%10_000 < 2_000 {
+			fmt.Fprintf(&trace, "scan-%d\n", i) // periodic scans
+			continue
+		}
+
+		fmt.Fprintf(&trace, "order-%d\n", zipf.Uint64())
+	}
+
+	recorded := trace.String()
+
+	keys, err := ReadTrace(strings.NewReader(recorded))
+	require.NoError(t, err)
+	require.Len(t, keys, 50_000)
+
+	results, err := Replay(keys, 500, PolicyLRU, PolicyLFU, PolicyARC, PolicyTinyLFU)
+	require.NoError(t, err)
+	require.Len(t, results, 4)
+
+	ratios := make(map[string]float64, len(results))
+
+	for _, result := range results {
+		require.Equal(t, len(keys), result.Hits+result.Misses)
+		ratios[result.Policy] = result.HitRatio()
+
+		t.Logf("%s: %.2f%%", result.Policy, result.HitRatio()*100)
+	}
+
+	require.Greater(t, ratios[PolicyTinyLFU], ratios[PolicyLRU])
+	require.Greater(t, ratios[PolicyARC], ratios[PolicyLRU])
+
+	_, err = Replay(keys, 500, "fifo")
+	require.ErrorIs(t, err, ErrUnknownPolicy)
+}
+
+func TestRecorder(t *testing.T) {
+	cache, err := New[string, int](10)
+	require.NoError(t, err)
+
+	var (
+		log      bytes.Buffer
+		recorder = NewRecorder(cache, &log)
+	)
+
+	recorder.Set("a", 1)
+	recorder.Get("a")
+	recorder.Get("b")
+
+	require.NoError(t, recorder.Flush())
+
+	keys, err := ReadTrace(&log)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, keys)
+}
@@ -0,0 +1,283 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/imotkin/L0/internal/logger"
+)
+
+const (
+	defaultRedisTimeout = time.Second
+	redisScanCount      = 100
+)
+
+// ErrEmptyPrefix is returned by NewRedis when the keys have no prefix, so
+// Clear would remove every key of the database.
+var ErrEmptyPrefix = errors.New("cache prefix is empty")
+
+// RedisCache stores values in a Redis-compatible server shared between
+// instances. Errors are logged and reported as misses, so the caller falls
+// back to the repository when the server is unavailable.
+type RedisCache[K comparable, V any] struct {
+	client     redis.UniversalClient
+	log        logger.Logger
+	serializer Serializer
+	prefix     string
+	ttl        time.Duration
+	timeout    time.Duration
+	capacity   int
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+// NewRedis creates a cache that owns the client and closes it in Close. The
+// keys of the cache are stored with the prefix, which must not be empty.
+func NewRedis[K comparable, V any](
+	log logger.Logger,
+	client redis.UniversalClient,
+	cfg *Config,
+	opts ...RedisOption[K, V],
+) (*RedisCache[K, V], error) {
+	if cfg.Redis.Prefix == "" {
+		return nil, ErrEmptyPrefix
+	}
+
+	serializer, err := NewSerializer(cfg.Redis.Serializer)
+	if err != nil {
+		return nil, err
+	}
+
+	timeout := cfg.Redis.Timeout
+	if timeout == 0 {
+		timeout = defaultRedisTimeout
+	}
+
+	c := &RedisCache[K, V]{
+		client:     client,
+		log:        log.With("source", "redis-cache"),
+		serializer: serializer,
+		prefix:     cfg.Redis.Prefix,
+		ttl:        cfg.TTL,
+		timeout:    timeout,
+		capacity:   cfg.Size,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c, nil
+}
+
+func (c *RedisCache[K, V]) key(key K) string {
+	return c.prefix + formatKey(key)
+}
+
+func (c *RedisCache[K, V]) context() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.timeout)
+}
+
+func (c *RedisCache[K, V]) Set(key K, value V) {
+	c.SetWithTTL(key, value, c.ttl)
+}
+
+// SetWithTTL stores the value that expires after ttl. Values with
+// a non-positive ttl never expire.
+func (c *RedisCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	data, err := c.serializer.Marshal(value)
+	if err != nil {
+		c.log.Error(err, "failed to marshal cache value", "key", key)
+		return
+	}
+
+	ctx, cancel := c.context()
+	defer cancel()
+
+	err = c.client.Set(ctx, c.key(key), data, max(ttl, 0)).Err()
+	if err != nil {
+		c.log.Error(err, "failed to set cache value", "key", key)
+	}
+}
+
+func (c *RedisCache[K, V]) Get(key K) (value V, ok bool) {
+	value, ok = c.Peek(key)
+	if ok {
+		c.hits.Add(1)
+	} else {
+		c.misses.Add(1)
+	}
+
+	return value, ok
+}
+
+// Peek returns the value without updating the statistics.
+func (c *RedisCache[K, V]) Peek(key K) (value V, ok bool) {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	data, err := c.client.Get(ctx, c.key(key)).Bytes()
+	if err != nil {
+		if !errors.Is(err, redis.Nil) {
+			c.log.Error(err, "failed to get cache value", "key", key)
+		}
+
+		return value, false
+	}
+
+	err = c.serializer.Unmarshal(data, &value)
+	if err != nil {
+		c.log.Error(err, "failed to unmarshal cache value", "key", key)
+		return value, false
+	}
+
+	return value, true
+}
+
+func (c *RedisCache[K, V]) Delete(key K) bool {
+	ctx, cancel := c.context()
+	defer cancel()
+
+	n, err := c.client.Del(ctx, c.key(key)).Result()
+	if err != nil {
+		c.log.Error(err, "failed to delete cache value", "key", key)
+		return false
+	}
+
+	return n > 0
+}
+
+// Clear removes the keys with the cache prefix. Errors are logged, Purge
+// reports them.
+func (c *RedisCache[K, V]) Clear() {
+	if err := c.Purge(); err != nil {
+		c.log.Error(err, "failed to clear cache")
+	}
+}
+
+// Purge removes the keys with the cache prefix and stops at the first error,
+// so the keys can be left partially removed.
+func (c *RedisCache[K, V]) Purge() error {
+	return c.scan(func(ctx context.Context, keys []string) error {
+		err := c.client.Del(ctx, keys...).Err()
+		if err != nil {
+			return fmt.Errorf("delete cache keys: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// scan calls fn for every page of keys with the cache prefix. Every page gets
+// its own timeout, so large caches are scanned completely.
+func (c *RedisCache[K, V]) scan(fn func(ctx context.Context, keys []string) error) error {
+	var cursor uint64
+
+	for {
+		ctx, cancel := c.context()
+
+		keys, next, err := c.client.Scan(ctx, cursor, c.prefix+"*", redisScanCount).Result()
+		if err != nil {
+			err = fmt.Errorf("scan cache keys: %w", err)
+		} else if len(keys) > 0 {
+			err = fn(ctx, keys)
+		}
+
+		cancel()
+
+		if err != nil {
+			return err
+		}
+
+		if next == 0 {
+			return nil
+		}
+
+		cursor = next
+	}
+}
+
+// OnEvict does nothing: values are evicted by the server, which doesn't
+// report evictions to the instances.
+func (c *RedisCache[K, V]) OnEvict(EvictFunc[K, V]) {}
+
+// Keys returns the keys with the cache prefix in no particular order, the
+// server doesn't expose the recency of keys. Keys which can't be parsed are
+// skipped, the keys scanned before an error are returned.
+func (c *RedisCache[K, V]) Keys() []K {
+	var keys []K
+
+	err := c.scan(func(_ context.Context, page []string) error {
+		for _, s := range page {
+			key, err := parseKey[K](strings.TrimPrefix(s, c.prefix))
+			if err != nil {
+				c.log.Warn("invalid cache key", "key", s)
+				continue
+			}
+
+			keys = append(keys, key)
+		}
+
+		return nil
+	})
+	if err != nil {
+		c.log.Error(err, "failed to list cache keys")
+	}
+
+	return keys
+}
+
+// Len counts the keys with the cache prefix, so other keys of the database
+// aren't counted.
+func (c *RedisCache[K, V]) Len() int {
+	var n int
+
+	err := c.scan(func(_ context.Context, keys []string) error {
+		n += len(keys)
+		return nil
+	})
+	if err != nil {
+		c.log.Error(err, "failed to get cache size")
+		return 0
+	}
+
+	return n
+}
+
+// Cap returns the configured cache size. The actual limit depends on the
+// server memory settings.
+func (c *RedisCache[K, V]) Cap() int {
+	return c.capacity
+}
+
+// Stats returns the hits and misses of this instance. Evictions are done by
+// the server and are not counted.
+func (c *RedisCache[K, V]) Stats() Stats {
+	return Stats{
+		Hits:     c.hits.Load(),
+		Misses:   c.misses.Load(),
+		Size:     c.Len(),
+		Capacity: c.capacity,
+	}
+}
+
+// Close closes the client.
+func (c *RedisCache[K, V]) Close() error {
+	return c.client.Close()
+}
+
+func (c *RedisCache[K, V]) publish(ctx context.Context, channel string, message []byte) error {
+	err := c.client.Publish(ctx, channel, message).Err()
+	if err != nil {
+		return fmt.Errorf("publish to %q: %w", channel, err)
+	}
+
+	return nil
+}
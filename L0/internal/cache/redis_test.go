@@ -0,0 +1,208 @@
+package cache
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"github.com/imotkin/L0/internal/logger"
+)
+
+type testValue struct {
+	ID      uuid.UUID
+	Name    string
+	Created time.Time
+}
+
+func newTestRedis[K comparable, V any](
+	t *testing.T,
+	server *miniredis.Miniredis,
+	cfg *Config,
+	opts ...RedisOption[K, V],
+) *RedisCache[K, V] {
+	t.Helper()
+
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	cache, err := NewRedis(logger.NewNoOp(), client, cfg, opts...)
+	require.NoError(t, err)
+
+	return cache
+}
+
+func TestRedisCacheSerializers(t *testing.T) {
+	for _, name := range []string{SerializerJSON, SerializerGob} {
+		t.Run(name, func(t *testing.T) {
+			var (
+				server = miniredis.RunT(t)
+				cache  = newTestRedis[uuid.UUID, testValue](t, server, &Config{
+					Size:  10,
+					Redis: &RedisConfig{Prefix: "orders:", Serializer: name},
+				})
+				value = testValue{ID: uuid.New(), Name: "test", Created: time.Date(2021, 11, 26, 6, 22, 19, 0, time.UTC)}
+			)
+
+			cache.Set(value.ID, value)
+
+			require.True(t, server.Exists("orders:"+value.ID.String()))
+
+			got, ok := cache.Get(value.ID)
+			require.True(t, ok)
+			require.Equal(t, value, got)
+
+			_, ok = cache.Get(uuid.New())
+			require.False(t, ok)
+
+			require.Equal(t, 1, cache.Len())
+			require.Equal(t, 10, cache.Cap())
+
+			require.True(t, cache.Delete(value.ID))
+			require.False(t, cache.Delete(value.ID))
+			require.Zero(t, cache.Len())
+		})
+	}
+}
+
+// prefixSerializer marks the data to show that it was used.
+type prefixSerializer struct{}
+
+func (prefixSerializer) Marshal(v any) ([]byte, error) {
+	data, err := jsonSerializer{}.Marshal(v)
+	return append([]byte("sealed:"), data...), err
+}
+
+func (prefixSerializer) Unmarshal(data []byte, v any) error {
+	return jsonSerializer{}.Unmarshal(bytes.TrimPrefix(data, []byte("sealed:")), v)
+}
+
+func TestRedisCacheWithSerializer(t *testing.T) {
+	var (
+		server = miniredis.RunT(t)
+		cache  = newTestRedis(t, server, &Config{Redis: &RedisConfig{Prefix: "test:"}},
+			WithSerializer[string, int](prefixSerializer{}),
+		)
+	)
+
+	cache.Set("key", 1)
+
+	data, err := server.Get("test:key")
+	require.NoError(t, err)
+	require.Equal(t, "sealed:1", data)
+
+	v, ok := cache.Get("key")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+}
+
+func TestRedisCacheTTL(t *testing.T) {
+	var (
+		server = miniredis.RunT(t)
+		cache  = newTestRedis[string, int](t, server, &Config{
+			TTL:   time.Minute,
+			Redis: &RedisConfig{Prefix: "test:"},
+		})
+	)
+
+	cache.Set("default", 1)
+	cache.SetWithTTL("short", 2, time.Second)
+	cache.SetWithTTL("forever", 3, 0)
+
+	require.Equal(t, time.Minute, server.TTL("test:default"))
+	require.Zero(t, server.TTL("test:forever"))
+
+	server.FastForward(2 * time.Second)
+
+	_, ok := cache.Get("short")
+	require.False(t, ok)
+
+	v, ok := cache.Get("default")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+}
+
+func TestRedisCacheClose(t *testing.T) {
+	var (
+		server = miniredis.RunT(t)
+		cache  = newTestRedis[string, int](t, server, &Config{Redis: &RedisConfig{Prefix: "test:"}})
+	)
+
+	cache.Set("key", 1)
+	require.NoError(t, cache.Close())
+
+	_, ok := cache.Get("key")
+	require.False(t, ok)
+	require.Zero(t, cache.Len())
+}
+
+func TestRedisCacheUnavailable(t *testing.T) {
+	var (
+		server = miniredis.RunT(t)
+		cache  = newTestRedis[string, int](t, server, &Config{
+			Redis: &RedisConfig{Prefix: "test:", Timeout: 100 * time.Millisecond},
+		})
+	)
+
+	cache.Set("key", 1)
+	server.Close()
+
+	_, ok := cache.Get("key")
+	require.False(t, ok)
+}
+
+func TestRedisCacheClear(t *testing.T) {
+	var (
+		server = miniredis.RunT(t)
+		cache  = newTestRedis[int, int](t, server, &Config{Redis: &RedisConfig{Prefix: "test:"}})
+	)
+
+	// keys span several scan pages
+	for i := range 3 * redisScanCount {
+		cache.Set(i, i)
+	}
+
+	require.NoError(t, server.Set("other", "value"))
+
+	require.Equal(t, 3*redisScanCount, cache.Len(), "keys without the prefix aren't counted")
+	require.Len(t, cache.Keys(), 3*redisScanCount)
+
+	require.NoError(t, cache.Purge())
+	require.Zero(t, cache.Len())
+	require.True(t, server.Exists("other"), "keys without the prefix are kept")
+
+	cache.Set(1, 1)
+	server.Close()
+
+	require.Error(t, cache.Purge())
+}
+
+func TestUnknownSerializer(t *testing.T) {
+	_, err := NewRedis[string, int](logger.NewNoOp(), nil, &Config{Redis: &RedisConfig{Prefix: "test:", Serializer: "xml"}})
+	require.ErrorIs(t, err, ErrUnknownSerializer)
+}
+
+func TestEmptyPrefix(t *testing.T) {
+	_, err := NewRedis[string, int](logger.NewNoOp(), nil, &Config{Redis: &RedisConfig{}})
+	require.ErrorIs(t, err, ErrEmptyPrefix)
+}
+
+func TestParseKey(t *testing.T) {
+	id := uuid.New()
+
+	got, err := parseKey[uuid.UUID](formatKey(id))
+	require.NoError(t, err)
+	require.Equal(t, id, got)
+
+	s, err := parseKey[string]("key")
+	require.NoError(t, err)
+	require.Equal(t, "key", s)
+
+	n, err := parseKey[int](formatKey(42))
+	require.NoError(t, err)
+	require.Equal(t, 42, n)
+}
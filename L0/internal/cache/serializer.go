@@ -0,0 +1,90 @@
+package cache
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+const (
+	SerializerJSON = "json"
+	SerializerGob  = "gob"
+)
+
+var Serializers = []any{SerializerJSON, SerializerGob}
+
+var ErrUnknownSerializer = errors.New("unknown serializer")
+
+// Serializer converts values stored outside of the process memory.
+type Serializer interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// NewSerializer returns the serializer by name, JSON is used by default.
+func NewSerializer(name string) (Serializer, error) {
+	switch name {
+	case "", SerializerJSON:
+		return jsonSerializer{}, nil
+	case SerializerGob:
+		return gobSerializer{}, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrUnknownSerializer, name)
+}
+
+type jsonSerializer struct{}
+
+func (jsonSerializer) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonSerializer) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+type gobSerializer struct{}
+
+func (gobSerializer) Marshal(v any) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := gob.NewEncoder(&buf).Encode(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (gobSerializer) Unmarshal(data []byte, v any) error {
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(v)
+}
+
+// formatKey converts the key to a string, preferring its text form.
+func formatKey[K comparable](key K) string {
+	if m, ok := any(key).(encoding.TextMarshaler); ok {
+		if text, err := m.MarshalText(); err == nil {
+			return string(text)
+		}
+	}
+
+	return fmt.Sprint(key)
+}
+
+// parseKey is the reverse of formatKey for string keys and keys which
+// implement encoding.TextUnmarshaler.
+func parseKey[K comparable](s string) (key K, err error) {
+	switch k := any(&key).(type) {
+	case *string:
+		*k = s
+	case encoding.TextUnmarshaler:
+		err = k.UnmarshalText([]byte(s))
+	default:
+		_, err = fmt.Sscan(s, &key)
+	}
+
+	return key, err
+}
@@ -0,0 +1,157 @@
+package cache
+
+import (
+	"context"
+	"hash/maphash"
+	"time"
+)
+
+// ShardedCache splits keys between independently locked LRU segments, so
+// concurrent operations on different keys don't contend for one lock.
+type ShardedCache[K comparable, V any] struct {
+	seed   maphash.Seed
+	shards []*MemoryCache[K, V]
+}
+
+// NewSharded creates a cache of n shards. The capacity and the byte budget
+// are divided between them, the first shards take the remainder, so the
+// total never exceeds the configured limits.
+func NewSharded[K comparable, V any](n, capacity int, opts ...Option[K, V]) (*ShardedCache[K, V], error) {
+	n = max(n, 1)
+
+	c := &ShardedCache[K, V]{
+		seed:   maphash.MakeSeed(),
+		shards: make([]*MemoryCache[K, V], n),
+	}
+
+	for i := range c.shards {
+		shard, err := New(share(capacity, n, i), opts...)
+		if err != nil {
+			return nil, err
+		}
+
+		// a zero budget means unlimited, so each shard keeps at least a byte
+		if shard.maxBytes > 0 {
+			shard.maxBytes = max(int64(share(int(shard.maxBytes), n, i)), 1)
+		}
+
+		c.shards[i] = shard
+	}
+
+	return c, nil
+}
+
+// share returns the part of total that the i-th of n shards takes.
+func share(total, n, i int) int {
+	size := total / n
+	if i < total%n {
+		size++
+	}
+
+	return size
+}
+
+func (c *ShardedCache[K, V]) shard(key K) *MemoryCache[K, V] {
+	return c.shards[maphash.Comparable(c.seed, key)%uint64(len(c.shards))]
+}
+
+func (c *ShardedCache[K, V]) Set(key K, value V) {
+	c.shard(key).Set(key, value)
+}
+
+func (c *ShardedCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.shard(key).SetWithTTL(key, value, ttl)
+}
+
+func (c *ShardedCache[K, V]) Get(key K) (value V, ok bool) {
+	return c.shard(key).Get(key)
+}
+
+func (c *ShardedCache[K, V]) Peek(key K) (value V, ok bool) {
+	return c.shard(key).Peek(key)
+}
+
+func (c *ShardedCache[K, V]) Delete(key K) bool {
+	return c.shard(key).Delete(key)
+}
+
+func (c *ShardedCache[K, V]) Clear() {
+	for _, shard := range c.shards {
+		shard.Clear()
+	}
+}
+
+func (c *ShardedCache[K, V]) OnEvict(fn EvictFunc[K, V]) {
+	for _, shard := range c.shards {
+		shard.OnEvict(fn)
+	}
+}
+
+func (c *ShardedCache[K, V]) Cleanup() int {
+	var n int
+	for _, shard := range c.shards {
+		n += shard.Cleanup()
+	}
+
+	return n
+}
+
+func (c *ShardedCache[K, V]) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.Cleanup()
+		}
+	}
+}
+
+// Keys returns the keys of all shards, the most recently used first.
+func (c *ShardedCache[K, V]) Keys() []K {
+	var usage []keyUsage[K]
+	for _, shard := range c.shards {
+		usage = append(usage, shard.usage()...)
+	}
+
+	return recentKeys(usage)
+}
+
+func (c *ShardedCache[K, V]) Len() int {
+	var n int
+	for _, shard := range c.shards {
+		n += shard.Len()
+	}
+
+	return n
+}
+
+func (c *ShardedCache[K, V]) Cap() int {
+	var n int
+	for _, shard := range c.shards {
+		n += shard.Cap()
+	}
+
+	return n
+}
+
+func (c *ShardedCache[K, V]) Stats() Stats {
+	var stats Stats
+	for _, shard := range c.shards {
+		stats = stats.add(shard.Stats())
+	}
+
+	return stats
+}
+
+func (c *ShardedCache[K, V]) Bytes() int64 {
+	var n int64
+	for _, shard := range c.shards {
+		n += shard.Bytes()
+	}
+
+	return n
+}
@@ -0,0 +1,134 @@
+package cache
+
+import (
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShardedCacheSet(t *testing.T) {
+	cache := newTestSharded[string, int](t, 4, 100)
+
+	for i := range 50 {
+		cache.Set(strconv.Itoa(i), i)
+	}
+
+	require.Equal(t, 50, cache.Len())
+	require.Equal(t, 100, cache.Cap())
+
+	for i := range 50 {
+		v, ok := cache.Get(strconv.Itoa(i))
+		require.True(t, ok)
+		require.Equal(t, i, v)
+	}
+}
+
+func TestShardedCacheOverflow(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		evicted int
+	)
+
+	cache := newTestSharded[string, int](t, 4, 8)
+	cache.OnEvict(func(string, int, EvictReason) {
+		mu.Lock()
+		evicted++
+		mu.Unlock()
+	})
+
+	for i := range 100 {
+		cache.Set(strconv.Itoa(i), i)
+	}
+
+	require.LessOrEqual(t, cache.Len(), cache.Cap())
+	require.Equal(t, 100-cache.Len(), evicted)
+}
+
+func TestShardedCacheKeys(t *testing.T) {
+	var (
+		clock    = &testClock{now: time.Now()}
+		cache    = newTestSharded(t, 4, 100, withClock[int, int](clock.Now))
+		expected []int
+	)
+
+	for i := range 20 {
+		cache.Set(i, i)
+		clock.Add(time.Second)
+
+		expected = append([]int{i}, expected...)
+	}
+
+	require.Equal(t, expected, cache.Keys())
+}
+
+func TestShardedCacheMaxBytes(t *testing.T) {
+	cache := newTestSharded(t, 4, 100, WithMaxBytes[string, sized](400))
+
+	for i := range 100 {
+		cache.Set(strconv.Itoa(i), 10)
+	}
+
+	require.LessOrEqual(t, cache.Bytes(), int64(400))
+}
+
+func TestShardedCacheLimits(t *testing.T) {
+	tests := []struct {
+		name     string
+		shards   int
+		capacity int
+		maxBytes int64
+	}{
+		{name: "remainder", shards: 16, capacity: 100, maxBytes: 1000},
+		{name: "even", shards: 4, capacity: 100, maxBytes: 400},
+		{name: "small budget", shards: 8, capacity: 4, maxBytes: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cache := newTestSharded(t, tt.shards, tt.capacity, WithMaxBytes[string, sized](tt.maxBytes))
+
+			require.Equal(t, tt.capacity, cache.Cap())
+
+			var total int64
+			for _, shard := range cache.shards {
+				require.Positive(t, shard.maxBytes, "a shard budget is never unlimited")
+				total += shard.maxBytes
+			}
+
+			require.Equal(t, max(tt.maxBytes, int64(tt.shards)), total)
+		})
+	}
+}
+
+func TestShardedCacheConcurrent(t *testing.T) {
+	var (
+		cache = newTestSharded[int, int](t, 8, 1000)
+		wg    sync.WaitGroup
+	)
+
+	for w := range 8 {
+		wg.Go(func() {
+			for i := range 1000 {
+				key := w*1000 + i
+				cache.Set(key, i)
+				cache.Get(key)
+			}
+		})
+	}
+
+	wg.Wait()
+
+	require.LessOrEqual(t, cache.Len(), cache.Cap())
+}
+
+func newTestSharded[K comparable, V any](t testing.TB, n, capacity int, opts ...Option[K, V]) *ShardedCache[K, V] {
+	t.Helper()
+
+	cache, err := NewSharded(n, capacity, opts...)
+	require.NoError(t, err)
+
+	return cache
+}
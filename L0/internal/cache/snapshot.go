@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteSnapshot writes the keys, one key per line, so the hot key set can be
+// loaded again after a restart.
+func WriteSnapshot[K comparable](w io.Writer, keys []K) error {
+	bw := bufio.NewWriter(w)
+
+	for _, key := range keys {
+		_, err := fmt.Fprintln(bw, formatKey(key))
+		if err != nil {
+			return err
+		}
+	}
+
+	return bw.Flush()
+}
+
+// ReadSnapshot reads the keys written by WriteSnapshot.
+func ReadSnapshot[K comparable](r io.Reader) ([]K, error) {
+	var (
+		keys    []K
+		scanner = bufio.NewScanner(r)
+	)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		key, err := parseKey[K](line)
+		if err != nil {
+			return nil, fmt.Errorf("parse key %q: %w", line, err)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, scanner.Err()
+}
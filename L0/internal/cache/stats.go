@@ -0,0 +1,31 @@
+package cache
+
+// Stats is a snapshot of the cache counters. Hits, misses and evictions are
+// counted since the cache was created and are not reset by Clear.
+type Stats struct {
+	Hits      uint64 `json:"hits"`
+	Misses    uint64 `json:"misses"`
+	Evictions uint64 `json:"evictions"`
+	Size      int    `json:"size"`
+	Capacity  int    `json:"capacity"`
+	Bytes     int64  `json:"bytes"`
+}
+
+func (s Stats) HitRatio() float64 {
+	if s.Hits+s.Misses == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(s.Hits+s.Misses)
+}
+
+func (s Stats) add(other Stats) Stats {
+	s.Hits += other.Hits
+	s.Misses += other.Misses
+	s.Evictions += other.Evictions
+	s.Size += other.Size
+	s.Capacity += other.Capacity
+	s.Bytes += other.Bytes
+
+	return s
+}
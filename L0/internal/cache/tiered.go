@@ -0,0 +1,223 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"sync/atomic"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/imotkin/L0/internal/logger"
+)
+
+const DefaultChannel = "cache:invalidate"
+
+type invalidation struct {
+	Source string `json:"source"`
+	Key    string `json:"key,omitempty"`
+	All    bool   `json:"all,omitempty"`
+}
+
+// TieredCache keeps recently used values in a local cache in front of the
+// shared one. Instances announce changed keys through pub/sub, so local
+// copies don't outlive updates made by other instances.
+type TieredCache[K comparable, V any] struct {
+	local   Cache[K, V]
+	remote  *RedisCache[K, V]
+	log     logger.Logger
+	id      string
+	channel string
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+}
+
+func NewTiered[K comparable, V any](
+	log logger.Logger,
+	local Cache[K, V],
+	remote *RedisCache[K, V],
+	channel string,
+) *TieredCache[K, V] {
+	if channel == "" {
+		channel = DefaultChannel
+	}
+
+	return &TieredCache[K, V]{
+		local:   local,
+		remote:  remote,
+		log:     log.With("source", "tiered-cache"),
+		id:      uuid.NewString(),
+		channel: channel,
+	}
+}
+
+// Set stores the value in both caches without telling other instances:
+// values are loaded from the repository, so the copies of other instances
+// are the same. A changed value has to be deleted first, Delete drops the
+// copies of other instances.
+func (c *TieredCache[K, V]) Set(key K, value V) {
+	c.remote.Set(key, value)
+	c.local.Set(key, value)
+}
+
+func (c *TieredCache[K, V]) SetWithTTL(key K, value V, ttl time.Duration) {
+	c.remote.SetWithTTL(key, value, ttl)
+	c.local.SetWithTTL(key, value, ttl)
+}
+
+func (c *TieredCache[K, V]) Get(key K) (value V, ok bool) {
+	if value, ok = c.local.Get(key); ok {
+		c.hits.Add(1)
+		return value, true
+	}
+
+	if value, ok = c.remote.Get(key); ok {
+		c.hits.Add(1)
+		c.local.Set(key, value)
+	} else {
+		c.misses.Add(1)
+	}
+
+	return value, ok
+}
+
+func (c *TieredCache[K, V]) Peek(key K) (value V, ok bool) {
+	if value, ok = c.local.Peek(key); ok {
+		return value, true
+	}
+
+	return c.remote.Peek(key)
+}
+
+func (c *TieredCache[K, V]) Delete(key K) bool {
+	local := c.local.Delete(key)
+	remote := c.remote.Delete(key)
+
+	c.invalidate(key)
+
+	return local || remote
+}
+
+// Clear removes all keys from both caches and the local caches of other
+// instances.
+func (c *TieredCache[K, V]) Clear() {
+	c.local.Clear()
+	c.remote.Clear()
+
+	c.publish(invalidation{Source: c.id, All: true})
+}
+
+// Purge is Clear that reports the error of the shared cache.
+func (c *TieredCache[K, V]) Purge() error {
+	c.local.Clear()
+	err := c.remote.Purge()
+
+	// keys removed before the error are dropped by other instances too
+	c.publish(invalidation{Source: c.id, All: true})
+
+	return err
+}
+
+// OnEvict registers the callback for evictions from the local cache.
+func (c *TieredCache[K, V]) OnEvict(fn EvictFunc[K, V]) {
+	c.local.OnEvict(fn)
+}
+
+// Keys returns the keys of the local cache.
+func (c *TieredCache[K, V]) Keys() []K {
+	return c.local.Keys()
+}
+
+func (c *TieredCache[K, V]) Len() int {
+	return c.local.Len()
+}
+
+func (c *TieredCache[K, V]) Cap() int {
+	return c.local.Cap()
+}
+
+// Stats returns the hits and misses of both caches together and the size
+// and evictions of the local cache.
+func (c *TieredCache[K, V]) Stats() Stats {
+	stats := c.local.Stats()
+	stats.Hits = c.hits.Load()
+	stats.Misses = c.misses.Load()
+
+	return stats
+}
+
+// Close closes the client of the shared cache.
+func (c *TieredCache[K, V]) Close() error {
+	return c.remote.Close()
+}
+
+// invalidate tells other instances to drop their local copy of the key.
+func (c *TieredCache[K, V]) invalidate(key K) {
+	c.publish(invalidation{Source: c.id, Key: formatKey(key)})
+}
+
+func (c *TieredCache[K, V]) publish(msg invalidation) {
+	message, err := json.Marshal(msg)
+	if err != nil {
+		c.log.Error(err, "failed to marshal invalidation", "key", msg.Key)
+		return
+	}
+
+	ctx, cancel := c.remote.context()
+	defer cancel()
+
+	err = c.remote.publish(ctx, c.channel, message)
+	if err != nil {
+		c.log.Error(err, "failed to publish invalidation", "key", msg.Key)
+	}
+}
+
+// Run drops local copies of keys changed by other instances until ctx is
+// done.
+func (c *TieredCache[K, V]) Run(ctx context.Context) {
+	sub := c.remote.client.Subscribe(ctx, c.channel)
+	defer sub.Close()
+
+	messages := sub.Channel()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg, ok := <-messages:
+			if !ok {
+				return
+			}
+
+			c.handle(msg.Payload)
+		}
+	}
+}
+
+func (c *TieredCache[K, V]) handle(payload string) {
+	var msg invalidation
+
+	err := json.Unmarshal([]byte(payload), &msg)
+	if err != nil {
+		c.log.Warn("invalid invalidation message", "payload", payload)
+		return
+	}
+
+	if msg.Source == c.id {
+		return
+	}
+
+	if msg.All {
+		c.local.Clear()
+		return
+	}
+
+	key, err := parseKey[K](msg.Key)
+	if err != nil {
+		c.log.Warn("invalid invalidation key", "key", msg.Key)
+		return
+	}
+
+	c.local.Delete(key)
+}
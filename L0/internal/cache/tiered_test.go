@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/require"
+
+	"github.com/imotkin/L0/internal/logger"
+)
+
+func TestTieredCacheReadThrough(t *testing.T) {
+	server := miniredis.RunT(t)
+
+	local, err := New[string, int](10)
+	require.NoError(t, err)
+
+	remote := newTestRedis[string, int](t, server, &Config{Redis: &RedisConfig{Prefix: "test:"}})
+	cache := NewTiered(logger.NewNoOp(), local, remote, "")
+
+	remote.Set("key", 1)
+	require.Zero(t, cache.Len())
+
+	v, ok := cache.Get("key")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+	require.Equal(t, 1, cache.Len())
+
+	// the local copy is served without the server
+	server.Close()
+
+	v, ok = cache.Get("key")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+}
+
+func TestTieredCacheInvalidation(t *testing.T) {
+	var (
+		server = miniredis.RunT(t)
+		cfg    = &Config{Redis: &RedisConfig{Prefix: "test:"}}
+		caches = make([]*TieredCache[string, int], 2)
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for i := range caches {
+		local, err := New[string, int](10)
+		require.NoError(t, err)
+
+		caches[i] = NewTiered(logger.NewNoOp(), local, newTestRedis[string, int](t, server, cfg), "")
+		go caches[i].Run(ctx)
+	}
+
+	require.Eventually(t, func() bool {
+		return server.PubSubNumSub(DefaultChannel)[DefaultChannel] == len(caches)
+	}, time.Second, 10*time.Millisecond)
+
+	first, second := caches[0], caches[1]
+
+	first.Set("key", 1)
+
+	v, ok := second.Get("key")
+	require.True(t, ok)
+	require.Equal(t, 1, v)
+
+	// loads are the same everywhere, so they aren't announced
+	first.Set("key", 1)
+	require.Never(t, func() bool {
+		_, ok := second.local.Peek("key")
+		return !ok
+	}, 50*time.Millisecond, 10*time.Millisecond)
+
+	// changes are stored after the old value is deleted
+	first.Delete("key")
+	first.Set("key", 2)
+
+	require.Eventually(t, func() bool {
+		v, ok := second.Get("key")
+		return ok && v == 2
+	}, time.Second, 10*time.Millisecond)
+
+	// an instance keeps its own local copy after its update
+	v, ok = first.local.Get("key")
+	require.True(t, ok)
+	require.Equal(t, 2, v)
+
+	require.True(t, first.Delete("key"))
+
+	require.Eventually(t, func() bool {
+		_, ok := second.Get("key")
+		return !ok
+	}, time.Second, 10*time.Millisecond)
+
+	first.Set("a", 1)
+	second.Set("b", 2)
+	second.Get("a")
+
+	first.Clear()
+
+	require.Eventually(t, func() bool {
+		return second.Len() == 0
+	}, time.Second, 10*time.Millisecond)
+
+	_, ok = second.Get("b")
+	require.False(t, ok)
+}
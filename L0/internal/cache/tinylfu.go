@@ -0,0 +1,188 @@
+package cache
+
+import "hash/maphash"
+
+// tinyLFU implements W-TinyLFU: new keys enter a small LRU window, keys
+// leaving the window compete with the eviction candidate of the main
+// segmented LRU and are admitted only when they are used more often, which
+// is estimated by a count-min sketch with periodic aging.
+type tinyLFU[K comparable] struct {
+	window    *lruList[K]
+	probation *lruList[K]
+	protected *lruList[K]
+
+	windowCap    int
+	protectedCap int
+	mainCap      int
+
+	sketch *sketch[K]
+}
+
+func newTinyLFU[K comparable](capacity int) *tinyLFU[K] {
+	windowCap := max(1, capacity/100)
+	mainCap := max(0, capacity-windowCap)
+
+	return &tinyLFU[K]{
+		window:       newLRUList[K](),
+		probation:    newLRUList[K](),
+		protected:    newLRUList[K](),
+		windowCap:    windowCap,
+		mainCap:      mainCap,
+		protectedCap: mainCap * 8 / 10,
+		sketch:       newSketch[K](capacity),
+	}
+}
+
+func (p *tinyLFU[K]) admit(key K) []K {
+	p.sketch.increment(key)
+	p.window.pushFront(key)
+
+	if p.window.len() <= p.windowCap {
+		return nil
+	}
+
+	candidate, _ := p.window.popBack()
+
+	if p.probation.len()+p.protected.len() < p.mainCap {
+		p.probation.pushFront(candidate)
+		return nil
+	}
+
+	victim, ok := p.probation.back()
+	if !ok {
+		victim, ok = p.protected.back()
+	}
+
+	if !ok {
+		return []K{candidate}
+	}
+
+	if p.sketch.estimate(candidate) <= p.sketch.estimate(victim) {
+		return []K{candidate}
+	}
+
+	if !p.probation.remove(victim) {
+		p.protected.remove(victim)
+	}
+
+	p.probation.pushFront(candidate)
+
+	return []K{victim}
+}
+
+func (p *tinyLFU[K]) touch(key K) {
+	p.sketch.increment(key)
+
+	switch {
+	case p.window.contains(key):
+		p.window.moveToFront(key)
+	case p.probation.contains(key):
+		p.probation.remove(key)
+		p.protected.pushFront(key)
+
+		if p.protected.len() > p.protectedCap {
+			demoted, _ := p.protected.popBack()
+			p.probation.pushFront(demoted)
+		}
+	case p.protected.contains(key):
+		p.protected.moveToFront(key)
+	}
+}
+
+func (p *tinyLFU[K]) remove(key K) {
+	if !p.window.remove(key) && !p.probation.remove(key) {
+		p.protected.remove(key)
+	}
+}
+
+func (p *tinyLFU[K]) victim() (K, bool) {
+	if key, ok := p.probation.popBack(); ok {
+		return key, true
+	}
+
+	if key, ok := p.window.popBack(); ok {
+		return key, true
+	}
+
+	return p.protected.popBack()
+}
+
+const sketchDepth = 4
+
+// sketch is a count-min sketch with 4-bit saturating counters. All counters
+// are halved after sampleSize increments to forget old popularity.
+type sketch[K comparable] struct {
+	seed       maphash.Seed
+	counters   [sketchDepth][]uint8
+	mask       uint64
+	additions  int
+	sampleSize int
+}
+
+func newSketch[K comparable](capacity int) *sketch[K] {
+	// a few counters per cached key keep collisions from letting rare keys
+	// look as popular as the eviction candidate
+	width := 16
+	for width < 4*capacity {
+		width <<= 1
+	}
+
+	s := &sketch[K]{
+		seed:       maphash.MakeSeed(),
+		mask:       uint64(width - 1),
+		sampleSize: 10 * max(capacity, 1),
+	}
+
+	for i := range s.counters {
+		s.counters[i] = make([]uint8, width)
+	}
+
+	return s
+}
+
+func (s *sketch[K]) indexes(key K) [sketchDepth]uint64 {
+	var (
+		h       = maphash.Comparable(s.seed, key)
+		lo, hi  = h, h>>32 | h<<32
+		indexes [sketchDepth]uint64
+	)
+
+	for i := range indexes {
+		indexes[i] = (lo + uint64(i)*hi) & s.mask
+	}
+
+	return indexes
+}
+
+func (s *sketch[K]) increment(key K) {
+	for i, index := range s.indexes(key) {
+		if s.counters[i][index] < 15 {
+			s.counters[i][index]++
+		}
+	}
+
+	s.additions++
+	if s.additions >= s.sampleSize {
+		s.reset()
+	}
+}
+
+func (s *sketch[K]) estimate(key K) uint8 {
+	estimate := uint8(15)
+
+	for i, index := range s.indexes(key) {
+		estimate = min(estimate, s.counters[i][index])
+	}
+
+	return estimate
+}
+
+func (s *sketch[K]) reset() {
+	for i := range s.counters {
+		for j := range s.counters[i] {
+			s.counters[i][j] >>= 1
+		}
+	}
+
+	s.additions /= 2
+}
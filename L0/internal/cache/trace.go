@@ -0,0 +1,108 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+// Recorder writes the key of every read to an access log, one key per line,
+// which can be replayed later to compare eviction policies.
+type Recorder[K comparable, V any] struct {
+	Cache[K, V]
+
+	mu sync.Mutex
+	w  *bufio.Writer
+}
+
+func NewRecorder[K comparable, V any](c Cache[K, V], w io.Writer) *Recorder[K, V] {
+	return &Recorder[K, V]{Cache: c, w: bufio.NewWriter(w)}
+}
+
+func (r *Recorder[K, V]) Get(key K) (V, bool) {
+	r.mu.Lock()
+	fmt.Fprintln(r.w, key)
+	r.mu.Unlock()
+
+	return r.Cache.Get(key)
+}
+
+// Purge clears the recorded cache and reports the error if the cache is
+// a Purger.
+func (r *Recorder[K, V]) Purge() error {
+	if p, ok := r.Cache.(Purger); ok {
+		return p.Purge()
+	}
+
+	r.Cache.Clear()
+
+	return nil
+}
+
+func (r *Recorder[K, V]) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.w.Flush()
+}
+
+type ReplayResult struct {
+	Policy string
+	Hits   int
+	Misses int
+}
+
+func (r ReplayResult) HitRatio() float64 {
+	if r.Hits+r.Misses == 0 {
+		return 0
+	}
+
+	return float64(r.Hits) / float64(r.Hits+r.Misses)
+}
+
+// ReadTrace reads an access log written by Recorder.
+func ReadTrace(r io.Reader) ([]string, error) {
+	var (
+		keys    []string
+		scanner = bufio.NewScanner(r)
+	)
+
+	for scanner.Scan() {
+		if key := strings.TrimSpace(scanner.Text()); key != "" {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys, scanner.Err()
+}
+
+// Replay runs the trace against a cache of every policy. Missed keys are
+// stored right away, as the service does after loading an order.
+func Replay(trace []string, capacity int, policies ...string) ([]ReplayResult, error) {
+	results := make([]ReplayResult, 0, len(policies))
+
+	for _, name := range policies {
+		cache, err := New(capacity, WithPolicy[string, struct{}](name))
+		if err != nil {
+			return nil, err
+		}
+
+		result := ReplayResult{Policy: name}
+
+		for _, key := range trace {
+			if _, ok := cache.Get(key); ok {
+				result.Hits++
+				continue
+			}
+
+			result.Misses++
+			cache.Set(key, struct{}{})
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
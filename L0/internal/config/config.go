@@ -7,20 +7,31 @@ import (
 	"github.com/knadh/koanf/v2"
 
 	"github.com/imotkin/L0/internal/api/handler"
+	"github.com/imotkin/L0/internal/api/rpc"
 	"github.com/imotkin/L0/internal/api/server"
+	"github.com/imotkin/L0/internal/auth"
 	"github.com/imotkin/L0/internal/broker"
 	"github.com/imotkin/L0/internal/cache"
 	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/outbox"
+	"github.com/imotkin/L0/internal/pii"
 	"github.com/imotkin/L0/internal/repo/postgres"
+	"github.com/imotkin/L0/internal/stats"
 )
 
 type Config struct {
-	Server   *server.Config   `koanf:"server"`
-	Postgres *postgres.Config `koanf:"postgres"`
-	Logging  *logger.Config   `koanf:"logging"`
-	Broker   *broker.Config   `koanf:"broker"`
-	Web      *handler.Config  `koanf:"web"`
-	Cache    *cache.Config    `koanf:"cache"`
+	Server   *server.Config       `koanf:"server"`
+	Limits   *handler.LimitConfig `koanf:"limits"`
+	GRPC     *rpc.Config          `koanf:"grpc"`
+	Postgres *postgres.Config     `koanf:"postgres"`
+	Logging  *logger.Config       `koanf:"logging"`
+	Broker   *broker.Config       `koanf:"broker"`
+	Web      *handler.Config      `koanf:"web"`
+	Cache    *cache.Config        `koanf:"cache"`
+	Outbox   *outbox.Config       `koanf:"outbox"`
+	Stats    *stats.Config        `koanf:"stats"`
+	Auth     *auth.Config         `koanf:"auth"`
+	PII      *pii.Config          `koanf:"pii"`
 }
 
 func Parse(path string) (*Config, error) {
@@ -45,10 +56,16 @@ func Parse(path string) (*Config, error) {
 func (c *Config) Validate() error {
 	return validation.ValidateStruct(c,
 		validation.Field(&c.Server, validation.Required),
+		validation.Field(&c.Limits),
+		validation.Field(&c.GRPC),
 		validation.Field(&c.Postgres, validation.Required),
 		validation.Field(&c.Logging, validation.Required),
 		validation.Field(&c.Broker, validation.Required),
 		validation.Field(&c.Web, validation.Required),
 		validation.Field(&c.Cache, validation.Required),
+		validation.Field(&c.Outbox, validation.Required),
+		validation.Field(&c.Stats),
+		validation.Field(&c.Auth, validation.Required),
+		validation.Field(&c.PII),
 	)
 }
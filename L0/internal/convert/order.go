@@ -0,0 +1,158 @@
+package convert
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/imotkin/L0/internal/entity"
+	orderv1 "github.com/imotkin/L0/pkg/pb/order/v1"
+)
+
+func OrderToProto(order entity.Order) *orderv1.Order {
+	items := make([]*orderv1.Item, len(order.Items))
+	for i, item := range order.Items {
+		items[i] = &orderv1.Item{
+			ChrtId:      int64(item.ChrtID),
+			TrackNumber: item.TrackNumber,
+			Price:       int64(item.Price),
+			Rid:         item.RID.String(),
+			Name:        item.Name,
+			Sale:        int64(item.Sale),
+			Size:        item.Size,
+			TotalPrice:  int64(item.TotalPrice),
+			NmId:        int64(item.NmID),
+			Brand:       item.Brand,
+			Status:      int64(item.Status),
+		}
+	}
+
+	msg := &orderv1.Order{
+		OrderUid:    order.UID.String(),
+		TrackNumber: order.TrackNumber,
+		Entry:       order.Entry,
+		Delivery: &orderv1.Delivery{
+			Name:    order.Delivery.Name,
+			Phone:   order.Delivery.Phone,
+			Zip:     order.Delivery.Zip,
+			City:    order.Delivery.City,
+			Address: order.Delivery.Address,
+			Region:  order.Delivery.Region,
+			Email:   order.Delivery.Email,
+		},
+		Payment: &orderv1.Payment{
+			Transaction:  order.Payment.Transaction.String(),
+			RequestId:    order.Payment.RequestID,
+			Currency:     order.Payment.Currency,
+			Provider:     order.Payment.Provider,
+			Amount:       int64(order.Payment.Amount),
+			PaymentDt:    int64(order.Payment.PaymentDt),
+			Bank:         order.Payment.Bank,
+			DeliveryCost: int64(order.Payment.DeliveryCost),
+			GoodsTotal:   int64(order.Payment.GoodsTotal),
+			CustomFee:    int64(order.Payment.CustomFee),
+		},
+		Items:             items,
+		Locale:            order.Locale,
+		InternalSignature: order.InternalSignature,
+		CustomerId:        order.CustomerID,
+		DeliveryService:   order.DeliveryService,
+		Shardkey:          order.ShardKey,
+		SmId:              int64(order.SmID),
+		OofShard:          order.Shard,
+		Status:            string(order.Status),
+	}
+
+	if !order.DateCreated.IsZero() {
+		msg.DateCreated = timestamppb.New(order.DateCreated)
+	}
+
+	return msg
+}
+
+func OrderFromProto(msg *orderv1.Order) (entity.Order, error) {
+	uid, err := parseUUID(msg.GetOrderUid())
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("parse order_uid: %w", err)
+	}
+
+	transaction, err := parseUUID(msg.GetPayment().GetTransaction())
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("parse transaction: %w", err)
+	}
+
+	var items []entity.Item
+	for _, item := range msg.GetItems() {
+		rid, err := parseUUID(item.GetRid())
+		if err != nil {
+			return entity.Order{}, fmt.Errorf("parse rid: %w", err)
+		}
+
+		items = append(items, entity.Item{
+			ChrtID:      int(item.GetChrtId()),
+			TrackNumber: item.GetTrackNumber(),
+			Price:       int(item.GetPrice()),
+			RID:         rid,
+			Name:        item.GetName(),
+			Sale:        int(item.GetSale()),
+			Size:        item.GetSize(),
+			TotalPrice:  int(item.GetTotalPrice()),
+			NmID:        int(item.GetNmId()),
+			Brand:       item.GetBrand(),
+			Status:      entity.ItemStatus(item.GetStatus()),
+		})
+	}
+
+	order := entity.Order{
+		UID:         uid,
+		TrackNumber: msg.GetTrackNumber(),
+		Entry:       msg.GetEntry(),
+		Delivery: entity.Delivery{
+			Name:    msg.GetDelivery().GetName(),
+			Phone:   msg.GetDelivery().GetPhone(),
+			Zip:     msg.GetDelivery().GetZip(),
+			City:    msg.GetDelivery().GetCity(),
+			Address: msg.GetDelivery().GetAddress(),
+			Region:  msg.GetDelivery().GetRegion(),
+			Email:   msg.GetDelivery().GetEmail(),
+		},
+		Payment: entity.Payment{
+			Transaction:  transaction,
+			RequestID:    msg.GetPayment().GetRequestId(),
+			Currency:     msg.GetPayment().GetCurrency(),
+			Provider:     msg.GetPayment().GetProvider(),
+			Amount:       int(msg.GetPayment().GetAmount()),
+			PaymentDt:    int(msg.GetPayment().GetPaymentDt()),
+			Bank:         msg.GetPayment().GetBank(),
+			DeliveryCost: int(msg.GetPayment().GetDeliveryCost()),
+			GoodsTotal:   int(msg.GetPayment().GetGoodsTotal()),
+			CustomFee:    int(msg.GetPayment().GetCustomFee()),
+		},
+		Items:             items,
+		Locale:            msg.GetLocale(),
+		InternalSignature: msg.GetInternalSignature(),
+		CustomerID:        msg.GetCustomerId(),
+		DeliveryService:   msg.GetDeliveryService(),
+		ShardKey:          msg.GetShardkey(),
+		SmID:              int(msg.GetSmId()),
+		Shard:             msg.GetOofShard(),
+		Status:            entity.Status(msg.GetStatus()),
+	}
+
+	if msg.GetDateCreated() != nil {
+		order.DateCreated = msg.GetDateCreated().AsTime()
+	}
+
+	return order, nil
+}
+
+// parseUUID keeps empty values as zero UUIDs, so they are reported by
+// order validation instead of failing the decoding.
+func parseUUID(s string) (uuid.UUID, error) {
+	if s == "" {
+		return uuid.Nil, nil
+	}
+
+	return uuid.Parse(s)
+}
@@ -0,0 +1,76 @@
+// Package entitytest provides the orders shared by the tests of other
+// packages.
+package entitytest
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+// Order returns a valid order with new UIDs, its fields are filled as in
+// the orders of the feed.
+func Order() entity.Order {
+	return entity.Order{
+		UID:         uuid.New(),
+		TrackNumber: "WBILMTESTTRACK",
+		Entry:       "WBIL",
+		Delivery: entity.Delivery{
+			Name:    "Иван Иванов",
+			Phone:   "+79999999999",
+			Zip:     "101000",
+			City:    "Москва",
+			Address: "Площадь Мира, стр. 15",
+			Region:  "Центральный",
+			Email:   "ivanov@example.com",
+		},
+		Payment: entity.Payment{
+			Transaction:  uuid.New(),
+			RequestID:    "request-1",
+			Currency:     "USD",
+			Provider:     "wbpay",
+			Amount:       1817,
+			PaymentDt:    1637907727,
+			Bank:         "alpha",
+			DeliveryCost: 1500,
+			GoodsTotal:   317,
+			CustomFee:    10,
+		},
+		Items: []entity.Item{
+			{
+				ChrtID:      9934930,
+				TrackNumber: "WBILMTESTTRACK",
+				Price:       453,
+				RID:         uuid.New(),
+				Name:        "Mascaras",
+				Sale:        30,
+				Size:        "0",
+				TotalPrice:  317,
+				NmID:        2389212,
+				Brand:       "Vivienne Sabo",
+				Status:      entity.ItemStatusCreated,
+			},
+		},
+		Locale:            "en",
+		InternalSignature: "sign-123",
+		CustomerID:        "test",
+		DeliveryService:   "meest",
+		ShardKey:          "9",
+		SmID:              99,
+		DateCreated:       time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC),
+		Shard:             "1",
+		Status:            entity.StatusCreated,
+	}
+}
+
+// Orders returns n orders with different UIDs.
+func Orders(n int) []entity.Order {
+	orders := make([]entity.Order, n)
+	for i := range orders {
+		orders[i] = Order()
+	}
+
+	return orders
+}
@@ -3,5 +3,7 @@ package entity
 import "errors"
 
 var (
-	ErrOrderNotFound = errors.New("order not found")
+	ErrOrderNotFound     = errors.New("order not found")
+	ErrInvalidTransition = errors.New("invalid status transition")
+	ErrUnknownEvent      = errors.New("unknown event type")
 )
@@ -0,0 +1,51 @@
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+type EventType string
+
+const (
+	EventOrderAccepted EventType = "order.accepted"
+	EventStatusChanged EventType = "order.status_changed"
+)
+
+type Event struct {
+	ID        int64
+	Type      EventType
+	Key       string
+	Payload   []byte
+	Attempts  int
+	CreatedAt time.Time
+}
+
+// Value decodes the payload into the value of the event type, so it can be
+// published with the codec of the topic.
+func (e Event) Value() (any, error) {
+	var (
+		value any
+		err   error
+	)
+
+	switch e.Type {
+	case EventOrderAccepted:
+		var order Order
+		err = json.Unmarshal(e.Payload, &order)
+		value = order
+	case EventStatusChanged:
+		var change StatusChange
+		err = json.Unmarshal(e.Payload, &change)
+		value = change
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownEvent, e.Type)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", e.Type, err)
+	}
+
+	return value, nil
+}
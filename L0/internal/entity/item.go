@@ -6,17 +6,17 @@ import (
 )
 
 type Item struct {
-	ChrtID      int       `json:"chrt_id,omitempty"`
-	TrackNumber string    `json:"track_number,omitempty"`
-	Price       int       `json:"price,omitempty"`
-	RID         uuid.UUID `json:"rid,omitempty"`
-	Name        string    `json:"name,omitempty"`
-	Sale        int       `json:"sale,omitempty"`
-	Size        string    `json:"size,omitempty"`
-	TotalPrice  int       `json:"total_price,omitempty"`
-	NmID        int       `json:"nm_id,omitempty"`
-	Brand       string    `json:"brand,omitempty"`
-	Status      int       `json:"status,omitempty"`
+	ChrtID      int        `json:"chrt_id,omitempty"`
+	TrackNumber string     `json:"track_number,omitempty"`
+	Price       int        `json:"price,omitempty"`
+	RID         uuid.UUID  `json:"rid,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Sale        int        `json:"sale,omitempty"`
+	Size        string     `json:"size,omitempty"`
+	TotalPrice  int        `json:"total_price,omitempty"`
+	NmID        int        `json:"nm_id,omitempty"`
+	Brand       string     `json:"brand,omitempty"`
+	Status      ItemStatus `json:"status,omitempty"`
 }
 
 func (i Item) Validate() error {
@@ -22,6 +22,7 @@ type Order struct {
 	SmID              int       `json:"sm_id,omitempty"`
 	DateCreated       time.Time `json:"date_created,omitzero"`
 	Shard             string    `json:"oof_shard,omitempty"`
+	Status            Status    `json:"status,omitempty"`
 }
 
 func (o Order) Validate() error {
@@ -40,5 +41,6 @@ func (o Order) Validate() error {
 		validation.Field(&o.SmID, validation.Required),
 		validation.Field(&o.DateCreated, validation.Required),
 		validation.Field(&o.Shard, validation.Required),
+		validation.Field(&o.Status, validation.In(Statuses...)),
 	)
 }
@@ -0,0 +1,20 @@
+package entity
+
+import "unsafe"
+
+// Size returns the approximate number of bytes occupied by the order.
+func (o Order) Size() int {
+	size := int(unsafe.Sizeof(o)) +
+		len(o.TrackNumber) + len(o.Entry) + len(o.Locale) + len(o.InternalSignature) +
+		len(o.CustomerID) + len(o.DeliveryService) + len(o.ShardKey) + len(o.Shard) + len(o.Status) +
+		len(o.Delivery.Name) + len(o.Delivery.Phone) + len(o.Delivery.Zip) + len(o.Delivery.City) +
+		len(o.Delivery.Address) + len(o.Delivery.Region) + len(o.Delivery.Email) +
+		len(o.Payment.RequestID) + len(o.Payment.Currency) + len(o.Payment.Provider) + len(o.Payment.Bank)
+
+	for _, item := range o.Items {
+		size += int(unsafe.Sizeof(item)) +
+			len(item.TrackNumber) + len(item.Name) + len(item.Size) + len(item.Brand)
+	}
+
+	return size
+}
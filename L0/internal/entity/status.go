@@ -0,0 +1,116 @@
+package entity
+
+import (
+	"slices"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusCreated    Status = "created"
+	StatusPaid       Status = "paid"
+	StatusAssembling Status = "assembling"
+	StatusShipped    Status = "shipped"
+	StatusDelivered  Status = "delivered"
+	StatusCancelled  Status = "cancelled"
+	StatusReturned   Status = "returned"
+)
+
+var Statuses = []any{
+	StatusCreated,
+	StatusPaid,
+	StatusAssembling,
+	StatusShipped,
+	StatusDelivered,
+	StatusCancelled,
+	StatusReturned,
+}
+
+var transitions = map[Status][]Status{
+	StatusCreated:    {StatusPaid, StatusCancelled},
+	StatusPaid:       {StatusAssembling, StatusCancelled},
+	StatusAssembling: {StatusShipped, StatusCancelled},
+	StatusShipped:    {StatusDelivered, StatusReturned},
+	StatusDelivered:  {StatusReturned},
+}
+
+func (s Status) CanTransition(to Status) bool {
+	return slices.Contains(transitions[s], to)
+}
+
+// ItemStatus is the numeric status code of an item. The order feed defines
+// only 202, an accepted item, the other codes are assigned by the service to
+// follow the lifecycle of the order. Items of the feed with other codes are
+// accepted and stored as is.
+type ItemStatus int
+
+const (
+	ItemStatusCreated    ItemStatus = 202
+	ItemStatusPaid       ItemStatus = 203
+	ItemStatusAssembling ItemStatus = 204
+	ItemStatusShipped    ItemStatus = 205
+	ItemStatusDelivered  ItemStatus = 206
+	ItemStatusCancelled  ItemStatus = 207
+	ItemStatusReturned   ItemStatus = 208
+)
+
+var ItemStatuses = []any{
+	ItemStatusCreated,
+	ItemStatusPaid,
+	ItemStatusAssembling,
+	ItemStatusShipped,
+	ItemStatusDelivered,
+	ItemStatusCancelled,
+	ItemStatusReturned,
+}
+
+var itemStatuses = map[ItemStatus]Status{
+	ItemStatusCreated:    StatusCreated,
+	ItemStatusPaid:       StatusPaid,
+	ItemStatusAssembling: StatusAssembling,
+	ItemStatusShipped:    StatusShipped,
+	ItemStatusDelivered:  StatusDelivered,
+	ItemStatusCancelled:  StatusCancelled,
+	ItemStatusReturned:   StatusReturned,
+}
+
+// Status returns the lifecycle status of the item code, unknown codes have
+// no status.
+func (s ItemStatus) Status() Status {
+	return itemStatuses[s]
+}
+
+func (s ItemStatus) CanTransition(to ItemStatus) bool {
+	return s.Status().CanTransition(to.Status())
+}
+
+// ItemStatusOf returns the item code of the lifecycle status.
+func ItemStatusOf(status Status) ItemStatus {
+	for code, s := range itemStatuses {
+		if s == status {
+			return code
+		}
+	}
+
+	return 0
+}
+
+type StatusChange struct {
+	OrderUID  uuid.UUID `json:"order_uid"`
+	From      Status    `json:"from,omitempty"`
+	To        Status    `json:"to"`
+	Comment   string    `json:"comment,omitempty"`
+	ChangedAt time.Time `json:"changed_at"`
+}
+
+func (c StatusChange) Validate() error {
+	return validation.ValidateStruct(&c,
+		validation.Field(&c.OrderUID, validation.Required),
+		validation.Field(&c.To, validation.Required, validation.In(Statuses...)),
+		validation.Field(&c.Comment, validation.Length(0, 500)),
+	)
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItemStatus(t *testing.T) {
+	for _, s := range ItemStatuses {
+		code := s.(ItemStatus)
+		require.Equal(t, code, ItemStatusOf(code.Status()))
+	}
+
+	require.True(t, ItemStatusCreated.CanTransition(ItemStatusPaid))
+	require.False(t, ItemStatusDelivered.CanTransition(ItemStatusCreated))
+	require.False(t, ItemStatus(999).CanTransition(ItemStatusPaid))
+	require.Equal(t, ItemStatus(0), ItemStatusOf("lost"))
+}
+
+func TestItemValidate(t *testing.T) {
+	item := Item{
+		ChrtID:      9934930,
+		TrackNumber: "WBILMTESTTRACK",
+		RID:         uuid.New(),
+		Name:        "Mascaras",
+		TotalPrice:  317,
+		NmID:        2389212,
+		Status:      ItemStatusCreated,
+	}
+
+	require.NoError(t, item.Validate())
+
+	item.Status = 0
+	require.NoError(t, item.Validate(), "status is optional")
+
+	item.Status = 999
+	require.NoError(t, item.Validate(), "codes the feed doesn't define are kept")
+}
@@ -0,0 +1,99 @@
+package export
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+type csvWriter struct {
+	w      *csv.Writer
+	header bool
+	record []string
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	return &csvWriter{w: csv.NewWriter(w), record: make([]string, len(Columns))}
+}
+
+func (c *csvWriter) Write(order entity.Order) error {
+	err := c.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	for _, row := range rows(order) {
+		for i, v := range row {
+			c.record[i] = formatValue(v)
+		}
+
+		err = c.w.Write(c.record)
+		if err != nil {
+			return fmt.Errorf("write csv row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *csvWriter) Close() error {
+	err := c.writeHeader()
+	if err != nil {
+		return err
+	}
+
+	c.w.Flush()
+
+	return c.w.Error()
+}
+
+func (c *csvWriter) writeHeader() error {
+	if c.header {
+		return nil
+	}
+
+	c.header = true
+
+	err := c.w.Write(Columns)
+	if err != nil {
+		return fmt.Errorf("write csv header: %w", err)
+	}
+
+	return nil
+}
+
+func formatValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return escapeFormula(v)
+	case int:
+		return strconv.Itoa(v)
+	case time.Time:
+		return v.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// number matches text which is a number, such as a phone number, so it can't
+// be a formula.
+var number = regexp.MustCompile(`^\+?\d+$`)
+
+// escapeFormula prefixes text which spreadsheets would evaluate as a formula
+// with a quote, so a field sent by a client can't run in the analyst's
+// spreadsheet. Numbers are written as they are.
+func escapeFormula(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) && !number.MatchString(s) {
+		return "'" + s
+	}
+
+	return s
+}
@@ -0,0 +1,103 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+const (
+	FormatCSV    = "csv"
+	FormatNDJSON = "ndjson"
+	FormatXLSX   = "xlsx"
+)
+
+var Formats = []any{FormatCSV, FormatNDJSON, FormatXLSX}
+
+var ErrUnknownFormat = errors.New("unknown export format")
+
+var contentTypes = map[string]string{
+	FormatCSV:    "text/csv; charset=utf-8",
+	FormatNDJSON: "application/x-ndjson",
+	FormatXLSX:   "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Writer writes orders as flat rows: one row per item with the order,
+// delivery and payment fields repeated. Orders without items take one row.
+// Close must be called to flush the output.
+type Writer interface {
+	Write(order entity.Order) error
+	Close() error
+}
+
+func NewWriter(format string, w io.Writer) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatNDJSON:
+		return newNDJSONWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownFormat, format)
+	}
+}
+
+func ContentType(format string) string {
+	return contentTypes[format]
+}
+
+// Columns are the names of the row values in order.
+var Columns = []string{
+	"order_uid", "track_number", "entry", "locale", "customer_id",
+	"delivery_service", "shardkey", "sm_id", "date_created", "oof_shard", "status",
+
+	"delivery_name", "delivery_phone", "delivery_zip", "delivery_city",
+	"delivery_address", "delivery_region", "delivery_email",
+
+	"payment_transaction", "payment_request_id", "payment_currency", "payment_provider",
+	"payment_amount", "payment_dt", "payment_bank", "payment_delivery_cost",
+	"payment_goods_total", "payment_custom_fee",
+
+	"item_chrt_id", "item_track_number", "item_price", "item_rid", "item_name",
+	"item_sale", "item_size", "item_total_price", "item_nm_id", "item_brand", "item_status",
+}
+
+const itemColumns = 11
+
+// rows flattens the order, values of a missing item are nil.
+func rows(order entity.Order) [][]any {
+	var (
+		d = order.Delivery
+		p = order.Payment
+	)
+
+	head := []any{
+		order.UID.String(), order.TrackNumber, order.Entry, order.Locale, order.CustomerID,
+		order.DeliveryService, order.ShardKey, order.SmID, order.DateCreated.UTC(), order.Shard, string(order.Status),
+
+		d.Name, d.Phone, d.Zip, d.City, d.Address, d.Region, d.Email,
+
+		p.Transaction.String(), p.RequestID, p.Currency, p.Provider,
+		p.Amount, time.Unix(int64(p.PaymentDt), 0).UTC(), p.Bank, p.DeliveryCost,
+		p.GoodsTotal, p.CustomFee,
+	}
+
+	if len(order.Items) == 0 {
+		return [][]any{append(head, make([]any, itemColumns)...)}
+	}
+
+	rows := make([][]any, len(order.Items))
+
+	for i, item := range order.Items {
+		rows[i] = append(head[:len(head):len(head)],
+			item.ChrtID, item.TrackNumber, item.Price, item.RID.String(), item.Name,
+			item.Sale, item.Size, item.TotalPrice, item.NmID, item.Brand, int(item.Status),
+		)
+	}
+
+	return rows
+}
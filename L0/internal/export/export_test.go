@@ -0,0 +1,130 @@
+package export
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"github.com/xuri/excelize/v2"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/entity/entitytest"
+)
+
+// testOrders returns an order with two items and an order without items.
+func testOrders() []entity.Order {
+	order := entitytest.Order()
+	order.UID = uuid.MustParse("b563feb7-b2b8-4b6a-9f5d-000000000001")
+	order.Items[0].Name = `Mascaras, "Vivienne"`
+	order.Items = append(order.Items, entity.Item{Name: "Lipstick", Price: 317, NmID: 2389213})
+
+	empty := entitytest.Order()
+	empty.UID = uuid.MustParse("b563feb7-b2b8-4b6a-9f5d-000000000002")
+	empty.TrackNumber = "WBILMTESTTRACK2"
+	empty.Items = nil
+
+	return []entity.Order{order, empty}
+}
+
+func write(t *testing.T, format string, orders []entity.Order) []byte {
+	var buf bytes.Buffer
+
+	w, err := NewWriter(format, &buf)
+	require.NoError(t, err)
+
+	for _, order := range orders {
+		require.NoError(t, w.Write(order))
+	}
+
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestCSV(t *testing.T) {
+	records, err := csv.NewReader(bytes.NewReader(write(t, FormatCSV, testOrders()))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 4)
+	require.Equal(t, Columns, records[0])
+
+	row := make(map[string]string)
+	for i, column := range Columns {
+		row[column] = records[1][i]
+	}
+
+	require.Equal(t, "b563feb7-b2b8-4b6a-9f5d-000000000001", row["order_uid"])
+	require.Equal(t, "2026-10-18T12:00:00Z", row["date_created"])
+	require.Equal(t, "Москва", row["delivery_city"])
+	require.Equal(t, "2021-11-26T06:22:07Z", row["payment_dt"])
+	require.Equal(t, `Mascaras, "Vivienne"`, row["item_name"])
+	require.Equal(t, "2389212", row["item_nm_id"])
+
+	require.Equal(t, "Lipstick", records[2][slices.Index(Columns, "item_name")])
+	require.Empty(t, records[3][slices.Index(Columns, "item_name")])
+
+	// the header is written even without orders
+	require.Equal(t, strings.Join(Columns, ",")+"\n", string(write(t, FormatCSV, nil)))
+}
+
+func TestCSVFormula(t *testing.T) {
+	order := testOrders()[1]
+	order.Delivery.Name = "=HYPERLINK(\"http://example.com\")"
+	order.Delivery.City = "@SUM(A1:A2)"
+	order.Delivery.Email = "\t=1+1"
+	order.Delivery.Region = "\r=1+1"
+	order.Delivery.Phone = "+79990000000"
+	order.Payment.Amount = -1
+
+	records, err := csv.NewReader(bytes.NewReader(write(t, FormatCSV, []entity.Order{order}))).ReadAll()
+	require.NoError(t, err)
+
+	row := records[1]
+	require.Equal(t, `'=HYPERLINK("http://example.com")`, row[slices.Index(Columns, "delivery_name")])
+	require.Equal(t, "'@SUM(A1:A2)", row[slices.Index(Columns, "delivery_city")])
+	require.Equal(t, "'\t=1+1", row[slices.Index(Columns, "delivery_email")])
+	require.Equal(t, "'\r=1+1", row[slices.Index(Columns, "delivery_region")])
+	require.Equal(t, "+79990000000", row[slices.Index(Columns, "delivery_phone")], "phone numbers aren't escaped")
+	require.Equal(t, "-1", row[slices.Index(Columns, "payment_amount")], "numbers aren't escaped")
+}
+
+func TestNDJSON(t *testing.T) {
+	lines := strings.Split(strings.TrimSpace(string(write(t, FormatNDJSON, testOrders()))), "\n")
+	require.Len(t, lines, 3)
+
+	var row map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+	require.Len(t, row, len(Columns))
+	require.Equal(t, "WBILMTESTTRACK", row["track_number"])
+	require.Equal(t, float64(453), row["item_price"])
+	require.True(t, strings.HasPrefix(lines[0], `{"order_uid":`))
+
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &row))
+	require.Nil(t, row["item_name"])
+}
+
+func TestXLSX(t *testing.T) {
+	f, err := excelize.OpenReader(bytes.NewReader(write(t, FormatXLSX, testOrders())))
+	require.NoError(t, err)
+	defer f.Close()
+
+	rows, err := f.GetRows(sheet)
+	require.NoError(t, err)
+	require.Len(t, rows, 4)
+	require.Equal(t, Columns, rows[0])
+	require.Equal(t, "Lipstick", rows[2][slices.Index(Columns, "item_name")])
+
+	price, err := f.GetCellType(sheet, "AE2")
+	require.NoError(t, err)
+	require.NotEqual(t, excelize.CellTypeSharedString, price)
+}
+
+func TestUnknownFormat(t *testing.T) {
+	_, err := NewWriter("xml", &bytes.Buffer{})
+	require.ErrorIs(t, err, ErrUnknownFormat)
+}
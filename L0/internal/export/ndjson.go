@@ -0,0 +1,56 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+// ndjsonWriter writes every row as a JSON object with keys in the order of
+// Columns.
+type ndjsonWriter struct {
+	w    *bufio.Writer
+	line []byte
+}
+
+func newNDJSONWriter(w io.Writer) *ndjsonWriter {
+	return &ndjsonWriter{w: bufio.NewWriter(w)}
+}
+
+func (n *ndjsonWriter) Write(order entity.Order) error {
+	for _, row := range rows(order) {
+		n.line = append(n.line[:0], '{')
+
+		for i, v := range row {
+			if i > 0 {
+				n.line = append(n.line, ',')
+			}
+
+			value, err := json.Marshal(v)
+			if err != nil {
+				return fmt.Errorf("encode %s: %w", Columns[i], err)
+			}
+
+			n.line = append(n.line, '"')
+			n.line = append(n.line, Columns[i]...)
+			n.line = append(n.line, '"', ':')
+			n.line = append(n.line, value...)
+		}
+
+		n.line = append(n.line, '}', '\n')
+
+		_, err := n.w.Write(n.line)
+		if err != nil {
+			return fmt.Errorf("write ndjson row: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (n *ndjsonWriter) Close() error {
+	return n.w.Flush()
+}
@@ -0,0 +1,95 @@
+package export
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+const sheet = "Orders"
+
+// xlsxWriter keeps the rows in a temporary file of the stream writer, since
+// the workbook can be written only after the last row.
+type xlsxWriter struct {
+	w      io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXWriter(w io.Writer) (*xlsxWriter, error) {
+	file := excelize.NewFile()
+
+	err := file.SetSheetName("Sheet1", sheet)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("rename sheet: %w", err)
+	}
+
+	stream, err := file.NewStreamWriter(sheet)
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("create stream writer: %w", err)
+	}
+
+	x := &xlsxWriter{w: w, file: file, stream: stream}
+
+	header := make([]any, len(Columns))
+	for i, column := range Columns {
+		header[i] = column
+	}
+
+	err = x.writeRow(header)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+
+	return x, nil
+}
+
+func (x *xlsxWriter) Write(order entity.Order) error {
+	for _, row := range rows(order) {
+		err := x.writeRow(row)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (x *xlsxWriter) writeRow(row []any) error {
+	x.row++
+
+	cell, err := excelize.CoordinatesToCellName(1, x.row)
+	if err != nil {
+		return fmt.Errorf("xlsx row %d: %w", x.row, err)
+	}
+
+	err = x.stream.SetRow(cell, row)
+	if err != nil {
+		return fmt.Errorf("write xlsx row %d: %w", x.row, err)
+	}
+
+	return nil
+}
+
+func (x *xlsxWriter) Close() error {
+	defer x.file.Close()
+
+	err := x.stream.Flush()
+	if err != nil {
+		return fmt.Errorf("flush xlsx rows: %w", err)
+	}
+
+	err = x.file.Write(x.w)
+	if err != nil {
+		return fmt.Errorf("write xlsx: %w", err)
+	}
+
+	return nil
+}
@@ -84,7 +84,8 @@ func New(format Format, level Level, w io.Writer) Logger {
 	var handler slog.Handler
 
 	opts := &slog.HandlerOptions{
-		Level: ParseLevel(level),
+		Level:       ParseLevel(level),
+		ReplaceAttr: Redact,
 	}
 
 	switch format {
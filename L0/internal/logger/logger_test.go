@@ -207,3 +207,56 @@ func TestLoggerJSONError(t *testing.T) {
 		})
 	}
 }
+
+func TestLoggerRedact(t *testing.T) {
+	cases := []struct {
+		msg        string
+		args       []any
+		expected   []string
+		unexpected []string
+	}{
+		{
+			"order was added",
+			[]any{slog.String("phone", "+79991234599"), slog.String("email", "ivanov@example.com")},
+			[]string{`phone=[REDACTED]`, `email=[REDACTED]`},
+			[]string{"+79991234599", "ivanov@example.com"},
+		},
+		{
+			"delivery",
+			[]any{slog.Any("delivery", map[string]string{"address": "Площадь Мира, стр. 15"})},
+			[]string{`delivery=[REDACTED]`},
+			[]string{"Площадь Мира"},
+		},
+		{
+			"order of ivanov@example.com was added",
+			[]any{slog.Any("error", errors.New(`duplicate key (phone)=(+79991234599)`))},
+			[]string{"i*****@example.com", `+7999*****99`},
+			[]string{"+79991234599", "ivanov@example.com"},
+		},
+		{
+			"nothing to redact",
+			[]any{slog.String("uid", "b563feb7-b2b8-4b6a-9f5d-000000000001"), slog.Int("count", 3)},
+			[]string{"uid=b563feb7-b2b8-4b6a-9f5d-000000000001", "count=3"},
+			nil,
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.msg, func(t *testing.T) {
+			var (
+				buf bytes.Buffer
+				l   = New(FormatText, LevelInfo, &buf)
+			)
+
+			l.Info(tt.msg, tt.args...)
+
+			for _, s := range tt.expected {
+				require.Contains(t, buf.String(), s)
+			}
+
+			for _, s := range tt.unexpected {
+				require.NotContains(t, buf.String(), s)
+			}
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package logger
+
+import (
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/imotkin/L0/internal/pii"
+)
+
+const redacted = "[REDACTED]"
+
+// redactKeys are the attributes holding personal data, their values are
+// never written.
+var redactKeys = map[string]bool{
+	"name":     true,
+	"phone":    true,
+	"email":    true,
+	"address":  true,
+	"delivery": true,
+	"payment":  true,
+	"order":    true,
+}
+
+var (
+	emailPattern = regexp.MustCompile(`[\p{L}\p{N}._%+-]+@[\p{L}\p{N}.-]+\.\p{L}{2,}`)
+	phonePattern = regexp.MustCompile(`\+\d{11}`)
+)
+
+// Redact is the ReplaceAttr hook of the handlers: attributes named after
+// personal data are replaced entirely, and emails and phones are masked in
+// the messages, errors and other strings, e.g. in database errors that
+// quote the values.
+func Redact(_ []string, a slog.Attr) slog.Attr {
+	if redactKeys[strings.ToLower(a.Key)] {
+		return slog.String(a.Key, redacted)
+	}
+
+	switch a.Value.Kind() {
+	case slog.KindString:
+		return slog.String(a.Key, redactText(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			return slog.String(a.Key, redactText(err.Error()))
+		}
+	}
+
+	return a
+}
+
+func redactText(s string) string {
+	// most of the strings have neither emails nor phones
+	if !strings.ContainsAny(s, "@+") {
+		return s
+	}
+
+	s = emailPattern.ReplaceAllStringFunc(s, pii.MaskEmail)
+	s = phonePattern.ReplaceAllStringFunc(s, pii.MaskPhone)
+
+	return s
+}
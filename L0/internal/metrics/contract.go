@@ -1,13 +1,31 @@
 package metrics
 
+import (
+	"time"
+
+	"github.com/imotkin/L0/internal/cache"
+)
+
 type Metrics interface {
 	IncRequests()
 	IncOrders()
 	IncFailed()
+	IncRetries()
 	IncCacheGet()
 	IncCacheSet()
+	IncCacheCoalesced()
+	IncCacheNegativeHits()
+	SetCacheStats(stats cache.Stats)
 	IncPostgresGet()
 	IncPostgresSet()
 	SetKafkaStatus(int)
 	SetPostgresStatus(int)
+	IncOutboxPublished()
+	IncOutboxFailed()
+	SetOutboxPending(int)
+	SetWorkerQueue(worker, depth int)
+	ObserveWorkerLatency(worker int, d time.Duration)
+	IncRejected(route, reason string)
+	SetInFlight(n int)
+	SetRateLimitClients(n int)
 }
@@ -2,7 +2,11 @@ package metrics
 
 import (
 	"net/http"
+	"strconv"
+	"sync"
+	"time"
 
+	"github.com/imotkin/L0/internal/cache"
 	"github.com/imotkin/L0/internal/logger"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -12,6 +16,13 @@ import (
 type metrics struct {
 	counters map[string]prometheus.Counter
 	gauges   map[string]prometheus.Gauge
+
+	workerQueue   *prometheus.GaugeVec
+	workerLatency *prometheus.HistogramVec
+	rejected      *prometheus.CounterVec
+
+	mu         sync.Mutex
+	cacheStats cache.Stats
 }
 
 func New(log logger.Logger) (Metrics, error) {
@@ -31,6 +42,11 @@ func New(log logger.Logger) (Metrics, error) {
 			Help: "Общее число ошибок при добавлении заказов",
 		}),
 
+		"RetriesTotal": promauto.NewCounter(prometheus.CounterOpts{
+			Name: "retries_total",
+			Help: "Общее число повторных попыток обработки заказов",
+		}),
+
 		"CacheGetTotal": promauto.NewCounter(prometheus.CounterOpts{
 			Name: "cache_get_total",
 			Help: "Общее число полученных заказов из кэша",
@@ -41,6 +57,16 @@ func New(log logger.Logger) (Metrics, error) {
 			Help: "Общее число добавленных заказов в кэш",
 		}),
 
+		"CacheCoalescedTotal": promauto.NewCounter(prometheus.CounterOpts{
+			Name: "cache_coalesced_total",
+			Help: "Общее число запросов заказа, объединённых с уже выполняющимся запросом к базе данных",
+		}),
+
+		"CacheNegativeHitsTotal": promauto.NewCounter(prometheus.CounterOpts{
+			Name: "cache_negative_hits_total",
+			Help: "Общее число запросов несуществующих заказов, обработанных без обращения к базе данных",
+		}),
+
 		"PostgresGetTotal": promauto.NewCounter(prometheus.CounterOpts{
 			Name: "pg_get_total",
 			Help: "Общее число полученных заказов из базы данных",
@@ -50,6 +76,31 @@ func New(log logger.Logger) (Metrics, error) {
 			Name: "pg_set_total",
 			Help: "Общее число добавленных заказов в базу данных",
 		}),
+
+		"OutboxPublishedTotal": promauto.NewCounter(prometheus.CounterOpts{
+			Name: "outbox_published_total",
+			Help: "Общее число опубликованных событий из outbox",
+		}),
+
+		"OutboxFailedTotal": promauto.NewCounter(prometheus.CounterOpts{
+			Name: "outbox_failed_total",
+			Help: "Общее число ошибок при публикации событий из outbox",
+		}),
+
+		"CacheHitsTotal": promauto.NewCounter(prometheus.CounterOpts{
+			Name: "cache_hits_total",
+			Help: "Общее число попаданий в кэш",
+		}),
+
+		"CacheMissesTotal": promauto.NewCounter(prometheus.CounterOpts{
+			Name: "cache_misses_total",
+			Help: "Общее число промахов кэша",
+		}),
+
+		"CacheEvictionsTotal": promauto.NewCounter(prometheus.CounterOpts{
+			Name: "cache_evictions_total",
+			Help: "Общее число вытесненных из кэша заказов",
+		}),
 	}
 
 	gauges := map[string]prometheus.Gauge{
@@ -62,11 +113,65 @@ func New(log logger.Logger) (Metrics, error) {
 			Name: "postgres_status",
 			Help: "Текущий статус для PostgreSQL (1 - доступна, 0 - нет)",
 		}),
+
+		"OutboxPending": promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "outbox_pending",
+			Help: "Текущее число неопубликованных событий в outbox",
+		}),
+
+		"CacheSize": promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "cache_size",
+			Help: "Текущее число заказов в кэше",
+		}),
+
+		"CacheCapacity": promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "cache_capacity",
+			Help: "Максимальное число заказов в кэше",
+		}),
+
+		"CacheBytes": promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "cache_bytes",
+			Help: "Примерный размер заказов в кэше в байтах",
+		}),
+
+		"CacheHitRatio": promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "cache_hit_ratio",
+			Help: "Доля попаданий в кэш с момента запуска",
+		}),
+
+		"InFlight": promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "http_in_flight_requests",
+			Help: "Текущее число обрабатываемых HTTP-запросов",
+		}),
+
+		"RateLimitClients": promauto.NewGauge(prometheus.GaugeOpts{
+			Name: "rate_limit_clients",
+			Help: "Текущее число клиентов с ограничением частоты запросов",
+		}),
 	}
 
+	workerQueue := promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "worker_queue_depth",
+		Help: "Текущее число пачек сообщений в очереди обработчика",
+	}, []string{"worker"})
+
+	workerLatency := promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "worker_processing_seconds",
+		Help:    "Время обработки пачки сообщений обработчиком",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"worker"})
+
+	rejected := promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_rejected_total",
+		Help: "Общее число отклонённых HTTP-запросов по маршруту и причине (rate, concurrency, body)",
+	}, []string{"route", "reason"})
+
 	return &metrics{
-		counters: counters,
-		gauges:   gauges,
+		counters:      counters,
+		gauges:        gauges,
+		workerQueue:   workerQueue,
+		workerLatency: workerLatency,
+		rejected:      rejected,
 	}, nil
 }
 
@@ -86,6 +191,10 @@ func (m *metrics) IncFailed() {
 	m.IncCounter("FailedTotal")
 }
 
+func (m *metrics) IncRetries() {
+	m.IncCounter("RetriesTotal")
+}
+
 func (m *metrics) IncCacheGet() {
 	m.IncCounter("CacheGetTotal")
 }
@@ -94,6 +203,40 @@ func (m *metrics) IncCacheSet() {
 	m.IncCounter("CacheSetTotal")
 }
 
+func (m *metrics) IncCacheCoalesced() {
+	m.IncCounter("CacheCoalescedTotal")
+}
+
+func (m *metrics) IncCacheNegativeHits() {
+	m.IncCounter("CacheNegativeHitsTotal")
+}
+
+// SetCacheStats updates the cache metrics. Counters are increased by the
+// difference with the previous stats.
+func (m *metrics) SetCacheStats(stats cache.Stats) {
+	m.mu.Lock()
+	prev := m.cacheStats
+	m.cacheStats = stats
+	m.mu.Unlock()
+
+	m.counters["CacheHitsTotal"].Add(float64(delta(prev.Hits, stats.Hits)))
+	m.counters["CacheMissesTotal"].Add(float64(delta(prev.Misses, stats.Misses)))
+	m.counters["CacheEvictionsTotal"].Add(float64(delta(prev.Evictions, stats.Evictions)))
+
+	m.gauges["CacheSize"].Set(float64(stats.Size))
+	m.gauges["CacheCapacity"].Set(float64(stats.Capacity))
+	m.gauges["CacheBytes"].Set(float64(stats.Bytes))
+	m.gauges["CacheHitRatio"].Set(stats.HitRatio())
+}
+
+func delta(prev, cur uint64) uint64 {
+	if cur < prev {
+		return cur
+	}
+
+	return cur - prev
+}
+
 func (m *metrics) IncPostgresGet() {
 	m.IncCounter("PostgresGetTotal")
 }
@@ -110,6 +253,38 @@ func (m *metrics) SetPostgresStatus(i int) {
 	m.gauges["PostgresStatus"].Set(float64(i))
 }
 
+func (m *metrics) IncOutboxPublished() {
+	m.IncCounter("OutboxPublishedTotal")
+}
+
+func (m *metrics) IncOutboxFailed() {
+	m.IncCounter("OutboxFailedTotal")
+}
+
+func (m *metrics) SetOutboxPending(i int) {
+	m.gauges["OutboxPending"].Set(float64(i))
+}
+
+func (m *metrics) SetWorkerQueue(worker, depth int) {
+	m.workerQueue.WithLabelValues(strconv.Itoa(worker)).Set(float64(depth))
+}
+
+func (m *metrics) ObserveWorkerLatency(worker int, d time.Duration) {
+	m.workerLatency.WithLabelValues(strconv.Itoa(worker)).Observe(d.Seconds())
+}
+
+func (m *metrics) IncRejected(route, reason string) {
+	m.rejected.WithLabelValues(route, reason).Inc()
+}
+
+func (m *metrics) SetInFlight(n int) {
+	m.gauges["InFlight"].Set(float64(n))
+}
+
+func (m *metrics) SetRateLimitClients(n int) {
+	m.gauges["RateLimitClients"].Set(float64(n))
+}
+
 func Handler() http.Handler {
 	return promhttp.Handler()
 }
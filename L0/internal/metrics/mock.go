@@ -11,7 +11,9 @@ package metrics
 
 import (
 	reflect "reflect"
+	time "time"
 
+	cache "github.com/imotkin/L0/internal/cache"
 	gomock "go.uber.org/mock/gomock"
 )
 
@@ -39,6 +41,18 @@ func (m *MockMetrics) EXPECT() *MockMetricsMockRecorder {
 	return m.recorder
 }
 
+// IncCacheCoalesced mocks base method.
+func (m *MockMetrics) IncCacheCoalesced() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncCacheCoalesced")
+}
+
+// IncCacheCoalesced indicates an expected call of IncCacheCoalesced.
+func (mr *MockMetricsMockRecorder) IncCacheCoalesced() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncCacheCoalesced", reflect.TypeOf((*MockMetrics)(nil).IncCacheCoalesced))
+}
+
 // IncCacheGet mocks base method.
 func (m *MockMetrics) IncCacheGet() {
 	m.ctrl.T.Helper()
@@ -51,6 +65,18 @@ func (mr *MockMetricsMockRecorder) IncCacheGet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncCacheGet", reflect.TypeOf((*MockMetrics)(nil).IncCacheGet))
 }
 
+// IncCacheNegativeHits mocks base method.
+func (m *MockMetrics) IncCacheNegativeHits() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncCacheNegativeHits")
+}
+
+// IncCacheNegativeHits indicates an expected call of IncCacheNegativeHits.
+func (mr *MockMetricsMockRecorder) IncCacheNegativeHits() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncCacheNegativeHits", reflect.TypeOf((*MockMetrics)(nil).IncCacheNegativeHits))
+}
+
 // IncCacheSet mocks base method.
 func (m *MockMetrics) IncCacheSet() {
 	m.ctrl.T.Helper()
@@ -87,6 +113,30 @@ func (mr *MockMetricsMockRecorder) IncOrders() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncOrders", reflect.TypeOf((*MockMetrics)(nil).IncOrders))
 }
 
+// IncOutboxFailed mocks base method.
+func (m *MockMetrics) IncOutboxFailed() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncOutboxFailed")
+}
+
+// IncOutboxFailed indicates an expected call of IncOutboxFailed.
+func (mr *MockMetricsMockRecorder) IncOutboxFailed() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncOutboxFailed", reflect.TypeOf((*MockMetrics)(nil).IncOutboxFailed))
+}
+
+// IncOutboxPublished mocks base method.
+func (m *MockMetrics) IncOutboxPublished() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncOutboxPublished")
+}
+
+// IncOutboxPublished indicates an expected call of IncOutboxPublished.
+func (mr *MockMetricsMockRecorder) IncOutboxPublished() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncOutboxPublished", reflect.TypeOf((*MockMetrics)(nil).IncOutboxPublished))
+}
+
 // IncPostgresGet mocks base method.
 func (m *MockMetrics) IncPostgresGet() {
 	m.ctrl.T.Helper()
@@ -111,6 +161,18 @@ func (mr *MockMetricsMockRecorder) IncPostgresSet() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncPostgresSet", reflect.TypeOf((*MockMetrics)(nil).IncPostgresSet))
 }
 
+// IncRejected mocks base method.
+func (m *MockMetrics) IncRejected(route, reason string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncRejected", route, reason)
+}
+
+// IncRejected indicates an expected call of IncRejected.
+func (mr *MockMetricsMockRecorder) IncRejected(route, reason any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncRejected", reflect.TypeOf((*MockMetrics)(nil).IncRejected), route, reason)
+}
+
 // IncRequests mocks base method.
 func (m *MockMetrics) IncRequests() {
 	m.ctrl.T.Helper()
@@ -123,6 +185,54 @@ func (mr *MockMetricsMockRecorder) IncRequests() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncRequests", reflect.TypeOf((*MockMetrics)(nil).IncRequests))
 }
 
+// IncRetries mocks base method.
+func (m *MockMetrics) IncRetries() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "IncRetries")
+}
+
+// IncRetries indicates an expected call of IncRetries.
+func (mr *MockMetricsMockRecorder) IncRetries() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncRetries", reflect.TypeOf((*MockMetrics)(nil).IncRetries))
+}
+
+// ObserveWorkerLatency mocks base method.
+func (m *MockMetrics) ObserveWorkerLatency(worker int, d time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ObserveWorkerLatency", worker, d)
+}
+
+// ObserveWorkerLatency indicates an expected call of ObserveWorkerLatency.
+func (mr *MockMetricsMockRecorder) ObserveWorkerLatency(worker, d any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ObserveWorkerLatency", reflect.TypeOf((*MockMetrics)(nil).ObserveWorkerLatency), worker, d)
+}
+
+// SetCacheStats mocks base method.
+func (m *MockMetrics) SetCacheStats(stats cache.Stats) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetCacheStats", stats)
+}
+
+// SetCacheStats indicates an expected call of SetCacheStats.
+func (mr *MockMetricsMockRecorder) SetCacheStats(stats any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetCacheStats", reflect.TypeOf((*MockMetrics)(nil).SetCacheStats), stats)
+}
+
+// SetInFlight mocks base method.
+func (m *MockMetrics) SetInFlight(n int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetInFlight", n)
+}
+
+// SetInFlight indicates an expected call of SetInFlight.
+func (mr *MockMetricsMockRecorder) SetInFlight(n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetInFlight", reflect.TypeOf((*MockMetrics)(nil).SetInFlight), n)
+}
+
 // SetKafkaStatus mocks base method.
 func (m *MockMetrics) SetKafkaStatus(arg0 int) {
 	m.ctrl.T.Helper()
@@ -135,6 +245,18 @@ func (mr *MockMetricsMockRecorder) SetKafkaStatus(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetKafkaStatus", reflect.TypeOf((*MockMetrics)(nil).SetKafkaStatus), arg0)
 }
 
+// SetOutboxPending mocks base method.
+func (m *MockMetrics) SetOutboxPending(arg0 int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetOutboxPending", arg0)
+}
+
+// SetOutboxPending indicates an expected call of SetOutboxPending.
+func (mr *MockMetricsMockRecorder) SetOutboxPending(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOutboxPending", reflect.TypeOf((*MockMetrics)(nil).SetOutboxPending), arg0)
+}
+
 // SetPostgresStatus mocks base method.
 func (m *MockMetrics) SetPostgresStatus(arg0 int) {
 	m.ctrl.T.Helper()
@@ -146,3 +268,27 @@ func (mr *MockMetricsMockRecorder) SetPostgresStatus(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPostgresStatus", reflect.TypeOf((*MockMetrics)(nil).SetPostgresStatus), arg0)
 }
+
+// SetRateLimitClients mocks base method.
+func (m *MockMetrics) SetRateLimitClients(n int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetRateLimitClients", n)
+}
+
+// SetRateLimitClients indicates an expected call of SetRateLimitClients.
+func (mr *MockMetricsMockRecorder) SetRateLimitClients(n any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRateLimitClients", reflect.TypeOf((*MockMetrics)(nil).SetRateLimitClients), n)
+}
+
+// SetWorkerQueue mocks base method.
+func (m *MockMetrics) SetWorkerQueue(worker, depth int) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetWorkerQueue", worker, depth)
+}
+
+// SetWorkerQueue indicates an expected call of SetWorkerQueue.
+func (mr *MockMetricsMockRecorder) SetWorkerQueue(worker, depth any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkerQueue", reflect.TypeOf((*MockMetrics)(nil).SetWorkerQueue), worker, depth)
+}
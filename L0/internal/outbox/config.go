@@ -0,0 +1,28 @@
+package outbox
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+type Config struct {
+	Interval   time.Duration `koanf:"interval"`
+	BatchSize  int           `koanf:"batch_size"`
+	Lease      time.Duration `koanf:"lease"`
+	MinBackoff time.Duration `koanf:"min_backoff"`
+	MaxBackoff time.Duration `koanf:"max_backoff"`
+	// Retention is how long published events are kept, 24h by default.
+	Retention time.Duration `koanf:"retention"`
+}
+
+func (c *Config) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Interval, validation.Required),
+		validation.Field(&c.BatchSize, validation.Required, validation.Min(1)),
+		validation.Field(&c.Lease, validation.Required),
+		validation.Field(&c.MinBackoff, validation.Required),
+		validation.Field(&c.MaxBackoff, validation.Required, validation.Min(c.MinBackoff)),
+		validation.Field(&c.Retention, validation.Min(time.Duration(0))),
+	)
+}
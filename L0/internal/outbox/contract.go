@@ -0,0 +1,20 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+type Store interface {
+	ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]entity.Event, error)
+	MarkPublished(ctx context.Context, ids ...int64) error
+	MarkFailed(ctx context.Context, id int64, reason string, next time.Time) error
+	PendingEvents(ctx context.Context) (int, error)
+	DeletePublished(ctx context.Context, before time.Time) (int, error)
+}
+
+type Publisher interface {
+	Send(ctx context.Context, topic, key string, value any) error
+}
@@ -0,0 +1,159 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/outbox/contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/outbox/contract.go -destination=internal/outbox/mock.go -package=outbox
+//
+
+// Package outbox is a generated GoMock package.
+package outbox
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	entity "github.com/imotkin/L0/internal/entity"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// ClaimEvents mocks base method.
+func (m *MockStore) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]entity.Event, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ClaimEvents", ctx, limit, lease)
+	ret0, _ := ret[0].([]entity.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ClaimEvents indicates an expected call of ClaimEvents.
+func (mr *MockStoreMockRecorder) ClaimEvents(ctx, limit, lease any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimEvents", reflect.TypeOf((*MockStore)(nil).ClaimEvents), ctx, limit, lease)
+}
+
+// DeletePublished mocks base method.
+func (m *MockStore) DeletePublished(ctx context.Context, before time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePublished", ctx, before)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeletePublished indicates an expected call of DeletePublished.
+func (mr *MockStoreMockRecorder) DeletePublished(ctx, before any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePublished", reflect.TypeOf((*MockStore)(nil).DeletePublished), ctx, before)
+}
+
+// MarkFailed mocks base method.
+func (m *MockStore) MarkFailed(ctx context.Context, id int64, reason string, next time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MarkFailed", ctx, id, reason, next)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkFailed indicates an expected call of MarkFailed.
+func (mr *MockStoreMockRecorder) MarkFailed(ctx, id, reason, next any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkFailed", reflect.TypeOf((*MockStore)(nil).MarkFailed), ctx, id, reason, next)
+}
+
+// MarkPublished mocks base method.
+func (m *MockStore) MarkPublished(ctx context.Context, ids ...int64) error {
+	m.ctrl.T.Helper()
+	varargs := []any{ctx}
+	for _, a := range ids {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MarkPublished", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MarkPublished indicates an expected call of MarkPublished.
+func (mr *MockStoreMockRecorder) MarkPublished(ctx any, ids ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]any{ctx}, ids...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MarkPublished", reflect.TypeOf((*MockStore)(nil).MarkPublished), varargs...)
+}
+
+// PendingEvents mocks base method.
+func (m *MockStore) PendingEvents(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PendingEvents", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PendingEvents indicates an expected call of PendingEvents.
+func (mr *MockStoreMockRecorder) PendingEvents(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PendingEvents", reflect.TypeOf((*MockStore)(nil).PendingEvents), ctx)
+}
+
+// MockPublisher is a mock of Publisher interface.
+type MockPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockPublisherMockRecorder
+	isgomock struct{}
+}
+
+// MockPublisherMockRecorder is the mock recorder for MockPublisher.
+type MockPublisherMockRecorder struct {
+	mock *MockPublisher
+}
+
+// NewMockPublisher creates a new mock instance.
+func NewMockPublisher(ctrl *gomock.Controller) *MockPublisher {
+	mock := &MockPublisher{ctrl: ctrl}
+	mock.recorder = &MockPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPublisher) EXPECT() *MockPublisherMockRecorder {
+	return m.recorder
+}
+
+// Send mocks base method.
+func (m *MockPublisher) Send(ctx context.Context, topic, key string, value any) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Send", ctx, topic, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Send indicates an expected call of Send.
+func (mr *MockPublisherMockRecorder) Send(ctx, topic, key, value any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Send", reflect.TypeOf((*MockPublisher)(nil).Send), ctx, topic, key, value)
+}
@@ -0,0 +1,204 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+const (
+	defaultRetention = 24 * time.Hour
+	pruneInterval    = time.Hour
+)
+
+type Relay struct {
+	store  Store
+	pub    Publisher
+	topics map[entity.EventType]string
+	cfg    *Config
+	log    logger.Logger
+	mc     metrics.Metrics
+	now    func() time.Time
+	pruned time.Time
+}
+
+func NewRelay(
+	log logger.Logger,
+	cfg *Config,
+	store Store,
+	pub Publisher,
+	topics map[entity.EventType]string,
+	mc metrics.Metrics,
+) *Relay {
+	return &Relay{
+		store:  store,
+		pub:    pub,
+		topics: topics,
+		cfg:    cfg,
+		log:    log.With("source", "outbox-relay"),
+		mc:     mc,
+		now:    time.Now,
+	}
+}
+
+func (r *Relay) Run(ctx context.Context) {
+	r.log.Info("outbox relay was started", slog.Duration("interval", r.cfg.Interval))
+	defer r.log.Info("outbox relay was stopped")
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			for {
+				n, err := r.relayBatch(ctx)
+				if err != nil {
+					r.log.Error(err, "failed to relay outbox events")
+					break
+				}
+
+				// the batch was full, so there may be more events waiting
+				if n < r.cfg.BatchSize || ctx.Err() != nil {
+					break
+				}
+			}
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			r.prune(ctx)
+
+			pending, err := r.store.PendingEvents(ctx)
+			if err != nil {
+				r.log.Error(err, "failed to count pending events")
+				continue
+			}
+
+			r.mc.SetOutboxPending(pending)
+		}
+	}
+}
+
+// relayBatch publishes a batch of events. Once claimed, the batch is
+// published to the end even if ctx is done, so the shutdown doesn't abort the
+// publishes in flight. Publishing is bounded by the lease, since events of an
+// expired lease are claimed again anyway.
+func (r *Relay) relayBatch(ctx context.Context) (int, error) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), r.cfg.Lease)
+	defer cancel()
+
+	return r.relay(ctx)
+}
+
+func (r *Relay) relay(ctx context.Context) (int, error) {
+	events, err := r.store.ClaimEvents(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		return 0, fmt.Errorf("claim events: %w", err)
+	}
+
+	var (
+		published = make([]int64, 0, len(events))
+		failed    = make(map[string]struct{})
+	)
+
+	for _, event := range events {
+		// the later events of a failed key stay leased, so they aren't
+		// published before it
+		if _, ok := failed[event.Key]; ok {
+			continue
+		}
+
+		err := r.publish(ctx, event)
+		if err != nil {
+			failed[event.Key] = struct{}{}
+
+			r.log.Error(
+				err,
+				"failed to publish outbox event",
+				slog.Int64("id", event.ID),
+				slog.String("type", string(event.Type)),
+				slog.Int("attempts", event.Attempts+1),
+			)
+			r.mc.IncOutboxFailed()
+
+			next := r.now().Add(r.backoff(event.Attempts))
+
+			err = r.store.MarkFailed(ctx, event.ID, err.Error(), next)
+			if err != nil {
+				return 0, fmt.Errorf("mark event %d as failed: %w", event.ID, err)
+			}
+
+			continue
+		}
+
+		published = append(published, event.ID)
+		r.mc.IncOutboxPublished()
+	}
+
+	if len(published) > 0 {
+		err = r.store.MarkPublished(ctx, published...)
+		if err != nil {
+			return 0, fmt.Errorf("mark events as published: %w", err)
+		}
+	}
+
+	return len(events), nil
+}
+
+func (r *Relay) publish(ctx context.Context, event entity.Event) error {
+	topic, ok := r.topics[event.Type]
+	if !ok {
+		return fmt.Errorf("no topic for event type %q", event.Type)
+	}
+
+	value, err := event.Value()
+	if err != nil {
+		return err
+	}
+
+	return r.pub.Send(ctx, topic, event.Key, value)
+}
+
+// prune deletes the events published before the retention, it runs at most
+// once per pruneInterval.
+func (r *Relay) prune(ctx context.Context) {
+	now := r.now()
+	if now.Sub(r.pruned) < pruneInterval {
+		return
+	}
+
+	retention := r.cfg.Retention
+	if retention == 0 {
+		retention = defaultRetention
+	}
+
+	n, err := r.store.DeletePublished(ctx, now.Add(-retention))
+	if err != nil {
+		r.log.Error(err, "failed to delete published events")
+		return
+	}
+
+	r.pruned = now
+
+	if n > 0 {
+		r.log.Info("published events were deleted", slog.Int("count", n))
+	}
+}
+
+func (r *Relay) backoff(attempts int) time.Duration {
+	d := r.cfg.MinBackoff << min(attempts, 30)
+	if d <= 0 || d > r.cfg.MaxBackoff {
+		return r.cfg.MaxBackoff
+	}
+
+	return d
+}
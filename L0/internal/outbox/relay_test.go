@@ -0,0 +1,192 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+)
+
+var testConfig = &Config{
+	Interval:   time.Second,
+	BatchSize:  10,
+	Lease:      time.Minute,
+	MinBackoff: time.Second,
+	MaxBackoff: time.Minute,
+}
+
+var testTopics = map[entity.EventType]string{
+	entity.EventOrderAccepted: "orders-accepted",
+	entity.EventStatusChanged: "orders-status",
+}
+
+func TestRelayPublished(t *testing.T) {
+	var (
+		ctrl  = gomock.NewController(t)
+		store = NewMockStore(ctrl)
+		pub   = NewMockPublisher(ctrl)
+		mc    = metrics.NewMockMetrics(ctrl)
+		relay = NewRelay(logger.NewNoOp(), testConfig, store, pub, testTopics, mc)
+	)
+
+	events := []entity.Event{
+		{ID: 1, Type: entity.EventOrderAccepted, Key: "a", Payload: []byte(`{"track_number":"WBILMTESTTRACK"}`)},
+		{ID: 2, Type: entity.EventStatusChanged, Key: "a", Payload: []byte(`{"to":"paid"}`)},
+	}
+
+	store.EXPECT().ClaimEvents(gomock.Any(), 10, time.Minute).Return(events, nil)
+
+	// events are published as values, so they are encoded with the codec of the topic
+	gomock.InOrder(
+		pub.EXPECT().Send(gomock.Any(), "orders-accepted", "a", entity.Order{TrackNumber: "WBILMTESTTRACK"}).Return(nil),
+		pub.EXPECT().Send(gomock.Any(), "orders-status", "a", entity.StatusChange{To: entity.StatusPaid}).Return(nil),
+	)
+
+	mc.EXPECT().IncOutboxPublished().Times(2)
+
+	store.EXPECT().MarkPublished(gomock.Any(), int64(1), int64(2)).Return(nil)
+
+	n, err := relay.relay(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+}
+
+func TestRelayFailed(t *testing.T) {
+	var (
+		ctrl  = gomock.NewController(t)
+		store = NewMockStore(ctrl)
+		pub   = NewMockPublisher(ctrl)
+		mc    = metrics.NewMockMetrics(ctrl)
+		relay = NewRelay(logger.NewNoOp(), testConfig, store, pub, testTopics, mc)
+		now   = time.Now()
+	)
+
+	relay.now = func() time.Time { return now }
+
+	events := []entity.Event{
+		{ID: 1, Type: entity.EventOrderAccepted, Key: "a", Payload: []byte(`{}`), Attempts: 2},
+		{ID: 2, Type: "unknown", Key: "b"},
+		{ID: 3, Type: entity.EventStatusChanged, Key: "a", Payload: []byte(`{}`)},
+	}
+
+	store.EXPECT().ClaimEvents(gomock.Any(), 10, time.Minute).Return(events, nil)
+
+	// the event 3 follows the failed event of its key, so it isn't sent
+	pub.EXPECT().Send(gomock.Any(), "orders-accepted", "a", gomock.Any()).Return(errors.New("broker is down"))
+
+	mc.EXPECT().IncOutboxFailed().Times(2)
+
+	store.EXPECT().MarkFailed(gomock.Any(), int64(1), gomock.Any(), now.Add(4*time.Second)).Return(nil)
+	store.EXPECT().MarkFailed(gomock.Any(), int64(2), gomock.Any(), now.Add(time.Second)).Return(nil)
+
+	n, err := relay.relay(context.Background())
+
+	require.NoError(t, err)
+	require.Equal(t, 3, n)
+}
+
+func TestRelayBackoff(t *testing.T) {
+	relay := NewRelay(logger.NewNoOp(), testConfig, nil, nil, nil, nil)
+
+	cases := []struct {
+		attempts int
+		expected time.Duration
+	}{
+		{0, time.Second},
+		{1, 2 * time.Second},
+		{5, 32 * time.Second},
+		{6, time.Minute},
+		{100, time.Minute},
+	}
+
+	for _, tt := range cases {
+		require.Equal(t, tt.expected, relay.backoff(tt.attempts))
+	}
+}
+
+func TestRelayPrune(t *testing.T) {
+	var (
+		ctrl  = gomock.NewController(t)
+		store = NewMockStore(ctrl)
+		cfg   = *testConfig
+		now   = time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	)
+
+	cfg.Retention = 2 * time.Hour
+
+	relay := NewRelay(logger.NewNoOp(), &cfg, store, nil, nil, nil)
+	relay.now = func() time.Time { return now }
+
+	store.EXPECT().DeletePublished(gomock.Any(), now.Add(-2*time.Hour)).Return(3, nil)
+	relay.prune(context.Background())
+
+	// pruned recently
+	now = now.Add(time.Minute)
+	relay.prune(context.Background())
+
+	now = now.Add(pruneInterval)
+	store.EXPECT().DeletePublished(gomock.Any(), now.Add(-2*time.Hour)).Return(0, errors.New("connection refused"))
+	relay.prune(context.Background())
+
+	// failed pruning is retried on the next tick
+	now = now.Add(time.Second)
+	store.EXPECT().DeletePublished(gomock.Any(), now.Add(-2*time.Hour)).Return(0, nil)
+	relay.prune(context.Background())
+}
+
+func TestRelayShutdown(t *testing.T) {
+	var (
+		ctrl  = gomock.NewController(t)
+		store = NewMockStore(ctrl)
+		pub   = NewMockPublisher(ctrl)
+		mc    = metrics.NewMockMetrics(ctrl)
+		cfg   = *testConfig
+		sent  = make(chan struct{})
+		done  = make(chan struct{})
+	)
+
+	cfg.Interval = time.Millisecond
+
+	relay := NewRelay(logger.NewNoOp(), &cfg, store, pub, testTopics, mc)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	store.EXPECT().ClaimEvents(gomock.Any(), 10, time.Minute).
+		Return([]entity.Event{{ID: 1, Type: entity.EventOrderAccepted, Key: "a", Payload: []byte(`{}`)}}, nil)
+
+	pub.EXPECT().Send(gomock.Any(), "orders-accepted", "a", gomock.Any()).
+		DoAndReturn(func(ctx context.Context, _, _ string, _ any) error {
+			close(sent)
+			<-done
+
+			return ctx.Err()
+		})
+
+	mc.EXPECT().IncOutboxPublished()
+	store.EXPECT().MarkPublished(gomock.Any(), int64(1)).Return(nil)
+
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+		relay.Run(ctx)
+	}()
+
+	<-sent
+	cancel()
+	close(done)
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("relay wasn't stopped")
+	}
+}
@@ -0,0 +1,78 @@
+package pii
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// Config of the field-level encryption. New values are encrypted with the
+// Primary key, the other keys of the ring are kept to decrypt values written
+// before the rotation.
+type Config struct {
+	Enabled  bool     `koanf:"enabled"`
+	Primary  string   `koanf:"primary"`
+	Keys     []Key    `koanf:"keys"`
+	IndexKey string   `koanf:"index_key"`
+	Fields   []string `koanf:"fields"`
+}
+
+func (c *Config) Validate() error {
+	ids := make([]any, 0, len(c.Keys))
+	for _, k := range c.Keys {
+		ids = append(ids, k.ID)
+	}
+
+	// the keys of a disabled config are placeholders until they're generated
+	skip := validation.Skip.When(!c.Enabled)
+
+	return validation.ValidateStruct(c,
+		validation.Field(&c.Primary, skip, validation.Required, validation.In(ids...)),
+		validation.Field(&c.Keys, skip, validation.Required),
+		validation.Field(&c.IndexKey, skip, validation.Required, validation.By(secret(indexKeySize, 0))),
+		validation.Field(&c.Fields, validation.Each(validation.In(Fields...))),
+	)
+}
+
+var keyID = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Key is an AES-256 key encoded in base64.
+type Key struct {
+	ID     string `koanf:"id"`
+	Secret string `koanf:"secret"`
+}
+
+func (k Key) Validate() error {
+	return validation.ValidateStruct(&k,
+		validation.Field(&k.ID, validation.Required, validation.Match(keyID)),
+		validation.Field(&k.Secret, validation.Required, validation.By(secret(keySize, keySize))),
+	)
+}
+
+// secret checks the length of the decoded key, maxSize 0 means no limit.
+func secret(minSize, maxSize int) validation.RuleFunc {
+	return func(value any) error {
+		s, _ := value.(string)
+		if s == "" {
+			return nil
+		}
+
+		key, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return errors.New("must be encoded in base64")
+		}
+
+		if maxSize > 0 && len(key) != maxSize {
+			return fmt.Errorf("must be %d bytes long", maxSize)
+		}
+
+		if len(key) < minSize {
+			return fmt.Errorf("must be at least %d bytes long", minSize)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,275 @@
+package pii
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	FieldName      = "delivery.name"
+	FieldPhone     = "delivery.phone"
+	FieldEmail     = "delivery.email"
+	FieldAddress   = "delivery.address"
+	FieldRequestID = "payment.request_id"
+)
+
+// Fields can be encrypted, all of them are encrypted by default.
+var Fields = []any{FieldName, FieldPhone, FieldEmail, FieldAddress, FieldRequestID}
+
+const (
+	keySize      = 32
+	indexKeySize = 32
+
+	// prefix marks encrypted values, values without it are stored in plain
+	// text and are returned as is.
+	prefix = "enc:v1:"
+)
+
+var ErrInvalidValue = errors.New("invalid encrypted value")
+
+// KeyRing encrypts values with envelope encryption: every value is sealed
+// with its own random data key, and the data key is sealed with the primary
+// key of the ring. The value is stored as
+//
+//	enc:v1:<key id>:<sealed data key>:<sealed value>
+//
+// so rotating the primary key only reseals the data keys. The value is bound
+// to the field and the order, it can't be decrypted in another row.
+type KeyRing struct {
+	primary string
+	keys    map[string]cipher.AEAD
+	index   []byte
+	fields  map[string]bool
+}
+
+func NewKeyRing(cfg *Config) (*KeyRing, error) {
+	if len(cfg.Keys) == 0 {
+		return nil, errors.New("key ring has no keys")
+	}
+
+	k := &KeyRing{
+		primary: cfg.Primary,
+		keys:    make(map[string]cipher.AEAD, len(cfg.Keys)),
+		fields:  make(map[string]bool, len(Fields)),
+	}
+
+	for _, key := range cfg.Keys {
+		secret, err := base64.StdEncoding.DecodeString(key.Secret)
+		if err != nil {
+			return nil, fmt.Errorf("decode key %q: %w", key.ID, err)
+		}
+
+		aead, err := newAEAD(secret)
+		if err != nil {
+			return nil, fmt.Errorf("create cipher of key %q: %w", key.ID, err)
+		}
+
+		k.keys[key.ID] = aead
+	}
+
+	if _, ok := k.keys[k.primary]; !ok {
+		return nil, fmt.Errorf("primary key %q is not in the ring", k.primary)
+	}
+
+	index, err := base64.StdEncoding.DecodeString(cfg.IndexKey)
+	if err != nil {
+		return nil, fmt.Errorf("decode index key: %w", err)
+	}
+
+	k.index = index
+
+	fields := cfg.Fields
+	if len(fields) == 0 {
+		for _, f := range Fields {
+			fields = append(fields, f.(string))
+		}
+	}
+
+	for _, f := range fields {
+		k.fields[f] = true
+	}
+
+	return k, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// Encrypts reports whether new values of the field are encrypted.
+func (k *KeyRing) Encrypts(field string) bool {
+	return k.fields[field]
+}
+
+// Seal encrypts the value of the field with the primary key. Values of
+// fields that aren't encrypted and empty values are returned as is.
+func (k *KeyRing) Seal(field, value string, order uuid.UUID) (string, error) {
+	if !k.Encrypts(field) || value == "" {
+		return value, nil
+	}
+
+	dataKey := make([]byte, keySize)
+	rand.Read(dataKey)
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("create data cipher: %w", err)
+	}
+
+	sealedKey := seal(k.keys[k.primary], dataKey, keyData(k.primary))
+	sealedValue := seal(aead, []byte(value), valueData(field, order))
+
+	return encode(k.primary, sealedKey, sealedValue), nil
+}
+
+// Open decrypts the value of the field. Values stored in plain text are
+// returned as is, so encryption can be enabled on existing data.
+func (k *KeyRing) Open(field, value string, order uuid.UUID) (string, error) {
+	if !strings.HasPrefix(value, prefix) {
+		return value, nil
+	}
+
+	_, dataKey, sealedValue, err := k.dataKey(value)
+	if err != nil {
+		return "", err
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return "", fmt.Errorf("create data cipher: %w", err)
+	}
+
+	plain, err := open(aead, sealedValue, valueData(field, order))
+	if err != nil {
+		return "", fmt.Errorf("%w: open %s: %w", ErrInvalidValue, field, err)
+	}
+
+	return string(plain), nil
+}
+
+// Rotate brings the stored value in line with the ring: the data key of a
+// value sealed with an old key is resealed with the primary one, plain text
+// values of encrypted fields are sealed, and values of fields that aren't
+// encrypted anymore are opened. It reports whether the value has changed.
+func (k *KeyRing) Rotate(field, value string, order uuid.UUID) (string, bool, error) {
+	sealed := strings.HasPrefix(value, prefix)
+
+	switch {
+	case value == "":
+		return value, false, nil
+	case !sealed && k.Encrypts(field):
+		v, err := k.Seal(field, value, order)
+		return v, err == nil, err
+	case !sealed:
+		return value, false, nil
+	case !k.Encrypts(field):
+		v, err := k.Open(field, value, order)
+		return v, err == nil, err
+	}
+
+	id, dataKey, sealedValue, err := k.dataKey(value)
+	if err != nil {
+		return "", false, err
+	}
+
+	if id == k.primary {
+		return value, false, nil
+	}
+
+	sealedKey := seal(k.keys[k.primary], dataKey, keyData(k.primary))
+
+	return encode(k.primary, sealedKey, sealedValue), true, nil
+}
+
+// Index returns the blind index of the value: a keyed hash that allows
+// exact lookups of encrypted values. Fields that aren't encrypted have no
+// index.
+func (k *KeyRing) Index(field, value string) string {
+	if !k.Encrypts(field) || value == "" {
+		return ""
+	}
+
+	mac := hmac.New(sha256.New, k.index)
+	mac.Write([]byte(field + ":" + strings.ToLower(strings.TrimSpace(value))))
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (k *KeyRing) dataKey(value string) (id string, dataKey, sealedValue []byte, err error) {
+	parts := strings.Split(strings.TrimPrefix(value, prefix), ":")
+	if len(parts) != 3 {
+		return "", nil, nil, ErrInvalidValue
+	}
+
+	id = parts[0]
+
+	aead, ok := k.keys[id]
+	if !ok {
+		return "", nil, nil, fmt.Errorf("%w: unknown key %q", ErrInvalidValue, id)
+	}
+
+	sealedKey, err := base64.RawStdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("%w: decode data key: %w", ErrInvalidValue, err)
+	}
+
+	sealedValue, err = base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("%w: decode value: %w", ErrInvalidValue, err)
+	}
+
+	dataKey, err = open(aead, sealedKey, keyData(id))
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("%w: open data key: %w", ErrInvalidValue, err)
+	}
+
+	return id, dataKey, sealedValue, nil
+}
+
+func keyData(id string) []byte {
+	return []byte("key:" + id)
+}
+
+func valueData(field string, order uuid.UUID) []byte {
+	return slices.Concat([]byte(field+":"), order[:])
+}
+
+func encode(id string, sealedKey, sealedValue []byte) string {
+	return prefix + id + ":" +
+		base64.RawStdEncoding.EncodeToString(sealedKey) + ":" +
+		base64.RawStdEncoding.EncodeToString(sealedValue)
+}
+
+// seal prepends the random nonce to the ciphertext.
+func seal(aead cipher.AEAD, plain, data []byte) []byte {
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plain)+aead.Overhead())
+	rand.Read(nonce)
+
+	return aead.Seal(nonce, nonce, plain, data)
+}
+
+func open(aead cipher.AEAD, sealed, data []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("value is too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+
+	return aead.Open(nil, nonce, ciphertext, data)
+}
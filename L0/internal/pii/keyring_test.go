@@ -0,0 +1,209 @@
+package pii
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	oldKey   = Key{ID: "2026-04", Secret: base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))}
+	newKey   = Key{ID: "2026-10", Secret: base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210"))}
+	indexKey = base64.StdEncoding.EncodeToString([]byte("index-key-0123456789-index-key-0"))
+	testKeys = []Key{oldKey, newKey}
+)
+
+func TestKeyRingSeal(t *testing.T) {
+	k, err := NewKeyRing(&Config{Primary: newKey.ID, Keys: testKeys, IndexKey: indexKey})
+	require.NoError(t, err)
+
+	order := uuid.New()
+
+	sealed, err := k.Seal(FieldPhone, "+79991234599", order)
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(sealed, prefix+newKey.ID+":"))
+	require.NotContains(t, sealed, "79991234599")
+
+	again, err := k.Seal(FieldPhone, "+79991234599", order)
+	require.NoError(t, err)
+	require.NotEqual(t, sealed, again, "values must be sealed with random keys")
+
+	plain, err := k.Open(FieldPhone, sealed, order)
+	require.NoError(t, err)
+	require.Equal(t, "+79991234599", plain)
+
+	_, err = k.Open(FieldPhone, sealed, uuid.New())
+	require.ErrorIs(t, err, ErrInvalidValue, "value of another order")
+
+	_, err = k.Open(FieldEmail, sealed, order)
+	require.ErrorIs(t, err, ErrInvalidValue, "value of another field")
+
+	tampered := sealed[:len(sealed)-2] + "AA"
+	_, err = k.Open(FieldPhone, tampered, order)
+	require.ErrorIs(t, err, ErrInvalidValue)
+
+	_, err = k.Open(FieldPhone, prefix+"2025-01:AAAA:AAAA", order)
+	require.ErrorIs(t, err, ErrInvalidValue, "unknown key")
+
+	plain, err = k.Open(FieldPhone, "+79991234599", order)
+	require.NoError(t, err)
+	require.Equal(t, "+79991234599", plain, "plain text values are returned as is")
+
+	empty, err := k.Seal(FieldPhone, "", order)
+	require.NoError(t, err)
+	require.Empty(t, empty)
+}
+
+func TestKeyRingFields(t *testing.T) {
+	k, err := NewKeyRing(&Config{Primary: newKey.ID, Keys: testKeys, IndexKey: indexKey, Fields: []string{FieldPhone}})
+	require.NoError(t, err)
+
+	order := uuid.New()
+
+	require.True(t, k.Encrypts(FieldPhone))
+	require.False(t, k.Encrypts(FieldName))
+
+	name, err := k.Seal(FieldName, "Иван Иванов", order)
+	require.NoError(t, err)
+	require.Equal(t, "Иван Иванов", name)
+
+	all, err := NewKeyRing(&Config{Primary: newKey.ID, Keys: testKeys, IndexKey: indexKey})
+	require.NoError(t, err)
+
+	for _, f := range Fields {
+		require.True(t, all.Encrypts(f.(string)), "fields are encrypted by default")
+	}
+}
+
+func TestKeyRingRotate(t *testing.T) {
+	old, err := NewKeyRing(&Config{Primary: oldKey.ID, Keys: testKeys, IndexKey: indexKey})
+	require.NoError(t, err)
+
+	k, err := NewKeyRing(&Config{Primary: newKey.ID, Keys: testKeys, IndexKey: indexKey, Fields: []string{FieldPhone, FieldEmail}})
+	require.NoError(t, err)
+
+	order := uuid.New()
+
+	sealed, err := old.Seal(FieldPhone, "+79991234599", order)
+	require.NoError(t, err)
+
+	rotated, changed, err := k.Rotate(FieldPhone, sealed, order)
+	require.NoError(t, err)
+	require.True(t, changed)
+	require.True(t, strings.HasPrefix(rotated, prefix+newKey.ID+":"))
+
+	// the value itself isn't resealed, only its data key
+	require.Equal(t, sealed[strings.LastIndex(sealed, ":"):], rotated[strings.LastIndex(rotated, ":"):])
+
+	plain, err := k.Open(FieldPhone, rotated, order)
+	require.NoError(t, err)
+	require.Equal(t, "+79991234599", plain)
+
+	same, changed, err := k.Rotate(FieldPhone, rotated, order)
+	require.NoError(t, err)
+	require.False(t, changed)
+	require.Equal(t, rotated, same)
+
+	email, changed, err := k.Rotate(FieldEmail, "ivanov@example.com", order)
+	require.NoError(t, err)
+	require.True(t, changed, "plain text values are sealed")
+	require.True(t, strings.HasPrefix(email, prefix))
+
+	name, err := old.Seal(FieldName, "Иван Иванов", order)
+	require.NoError(t, err)
+
+	name, changed, err = k.Rotate(FieldName, name, order)
+	require.NoError(t, err)
+	require.True(t, changed, "values of fields that aren't encrypted are opened")
+	require.Equal(t, "Иван Иванов", name)
+
+	_, changed, err = k.Rotate(FieldName, "Иван Иванов", order)
+	require.NoError(t, err)
+	require.False(t, changed)
+}
+
+func TestKeyRingIndex(t *testing.T) {
+	k, err := NewKeyRing(&Config{Primary: newKey.ID, Keys: testKeys, IndexKey: indexKey, Fields: []string{FieldEmail}})
+	require.NoError(t, err)
+
+	index := k.Index(FieldEmail, "ivanov@example.com")
+	require.Len(t, index, 64)
+	require.Equal(t, index, k.Index(FieldEmail, " Ivanov@Example.com "))
+	require.NotEqual(t, index, k.Index(FieldEmail, "petrov@example.com"))
+	require.Empty(t, k.Index(FieldPhone, "+79991234599"), "phones aren't encrypted")
+	require.Empty(t, k.Index(FieldEmail, ""))
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   Config
+		valid bool
+	}{
+		{
+			name:  "disabled",
+			cfg:   Config{},
+			valid: true,
+		},
+		{
+			name: "disabled with placeholders",
+			cfg: Config{Primary: "2026-10", IndexKey: "change-me", Keys: []Key{
+				{ID: "2026-10", Secret: "change-me"},
+			}},
+			valid: true,
+		},
+		{
+			name:  "enabled",
+			cfg:   Config{Enabled: true, Primary: newKey.ID, Keys: []Key{newKey}, IndexKey: indexKey},
+			valid: true,
+		},
+		{
+			name:  "no keys",
+			cfg:   Config{Enabled: true, Primary: newKey.ID, IndexKey: indexKey},
+			valid: false,
+		},
+		{
+			name:  "unknown primary",
+			cfg:   Config{Enabled: true, Primary: oldKey.ID, Keys: []Key{newKey}, IndexKey: indexKey},
+			valid: false,
+		},
+		{
+			name: "short key",
+			cfg: Config{Enabled: true, Primary: "short", IndexKey: indexKey, Keys: []Key{
+				{ID: "short", Secret: base64.StdEncoding.EncodeToString([]byte("0123456789abcdef"))},
+			}},
+			valid: false,
+		},
+		{
+			name: "key id with separator",
+			cfg: Config{Enabled: true, Primary: "2026:10", IndexKey: indexKey, Keys: []Key{
+				{ID: "2026:10", Secret: newKey.Secret},
+			}},
+			valid: false,
+		},
+		{
+			name:  "no index key",
+			cfg:   Config{Enabled: true, Primary: newKey.ID, Keys: []Key{newKey}},
+			valid: false,
+		},
+		{
+			name:  "unknown field",
+			cfg:   Config{Enabled: true, Primary: newKey.ID, Keys: []Key{newKey}, IndexKey: indexKey, Fields: []string{"delivery.city"}},
+			valid: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,112 @@
+package pii
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/entity"
+)
+
+const maskRune = '*'
+
+// MaskRole is the lowest role that sees the personal data of orders.
+const MaskRole = auth.RoleSupport
+
+// Masked reports whether the caller sees orders with masked personal data.
+// Without auth the calls have no caller and orders aren't masked.
+func Masked(ctx context.Context) bool {
+	id, ok := auth.FromContext(ctx)
+	return ok && !id.Role.Allows(MaskRole)
+}
+
+// MaskOrder hides the personal data of the order, the rest of the order is
+// kept as is.
+func MaskOrder(order entity.Order) entity.Order {
+	order.Delivery.Name = MaskWords(order.Delivery.Name)
+	order.Delivery.Phone = MaskPhone(order.Delivery.Phone)
+	order.Delivery.Email = MaskEmail(order.Delivery.Email)
+	order.Delivery.Address = MaskWords(order.Delivery.Address)
+	order.Payment.RequestID = MaskTail(order.Payment.RequestID, 4)
+
+	return order
+}
+
+// MaskPhone keeps the country and operator codes and the last two digits:
+// +79991234599 becomes +7999*****99.
+func MaskPhone(phone string) string {
+	return maskMiddle(phone, 5, 2)
+}
+
+// MaskEmail keeps the first letter of the mailbox and the domain:
+// ivanov@example.com becomes i*****@example.com.
+func MaskEmail(email string) string {
+	name, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return MaskWords(email)
+	}
+
+	return maskMiddle(name, 1, 0) + "@" + domain
+}
+
+// MaskWords keeps the first letter of every word and the punctuation:
+// Иван Иванов becomes И*** И*****.
+func MaskWords(s string) string {
+	var (
+		b     strings.Builder
+		first = true
+	)
+
+	b.Grow(len(s))
+
+	for _, r := range s {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			b.WriteRune(r)
+			first = true
+		case first:
+			b.WriteRune(r)
+			first = false
+		default:
+			b.WriteRune(maskRune)
+		}
+	}
+
+	return b.String()
+}
+
+// MaskTail keeps only the last n characters.
+func MaskTail(s string, n int) string {
+	return maskMiddle(s, 0, n)
+}
+
+// maskMiddle keeps the head and the tail of the string, strings not longer
+// than the kept parts are masked entirely.
+func maskMiddle(s string, head, tail int) string {
+	n := utf8.RuneCountInString(s)
+	if n == 0 {
+		return s
+	}
+
+	if n <= head+tail {
+		head, tail = 0, 0
+	}
+
+	var b strings.Builder
+
+	b.Grow(len(s))
+
+	i := 0
+	for _, r := range s {
+		if i < head || i >= n-tail {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(maskRune)
+		}
+		i++
+	}
+
+	return b.String()
+}
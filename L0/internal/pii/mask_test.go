@@ -0,0 +1,64 @@
+package pii
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+func TestMask(t *testing.T) {
+	tests := []struct {
+		name     string
+		mask     func(string) string
+		value    string
+		expected string
+	}{
+		{"phone", MaskPhone, "+79991234599", "+7999*****99"},
+		{"short phone", MaskPhone, "+7999", "*****"},
+		{"email", MaskEmail, "ivanov@example.com", "i*****@example.com"},
+		{"one letter email", MaskEmail, "i@example.com", "*@example.com"},
+		{"not an email", MaskEmail, "ivanov", "i*****"},
+		{"name", MaskWords, "Иван Иванов", "И*** И*****"},
+		{"address", MaskWords, "Площадь Мира, стр. 15", "П****** М***, с**. 1*"},
+		{"tail", func(s string) string { return MaskTail(s, 4) }, "a1b2c3d4", "****c3d4"},
+		{"empty", MaskPhone, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.mask(tt.value))
+		})
+	}
+}
+
+func TestMaskOrder(t *testing.T) {
+	order := entity.Order{
+		UID: uuid.New(),
+		Delivery: entity.Delivery{
+			Name:    "Иван Иванов",
+			Phone:   "+79991234599",
+			Zip:     "101000",
+			City:    "Москва",
+			Address: "Площадь Мира, стр. 15",
+			Email:   "ivanov@example.com",
+		},
+		Payment: entity.Payment{RequestID: "request-1234", Amount: 1817},
+	}
+
+	masked := MaskOrder(order)
+
+	require.Equal(t, entity.Delivery{
+		Name:    "И*** И*****",
+		Phone:   "+7999*****99",
+		Zip:     "101000",
+		City:    "Москва",
+		Address: "П****** М***, с**. 1*",
+		Email:   "i*****@example.com",
+	}, masked.Delivery)
+	require.Equal(t, "********1234", masked.Payment.RequestID)
+	require.Equal(t, 1817, masked.Payment.Amount)
+	require.Equal(t, "Иван Иванов", order.Delivery.Name, "the order itself isn't changed")
+}
@@ -0,0 +1,96 @@
+package pii
+
+import (
+	"fmt"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+type orderField struct {
+	field string
+	value *string
+}
+
+// orderFields returns the personal data of the order that can be encrypted.
+func orderFields(order *entity.Order) []orderField {
+	return []orderField{
+		{FieldName, &order.Delivery.Name},
+		{FieldPhone, &order.Delivery.Phone},
+		{FieldEmail, &order.Delivery.Email},
+		{FieldAddress, &order.Delivery.Address},
+		{FieldRequestID, &order.Payment.RequestID},
+	}
+}
+
+// SealOrder encrypts the personal data of the order with the primary key.
+func (k *KeyRing) SealOrder(order entity.Order) (entity.Order, error) {
+	for _, f := range orderFields(&order) {
+		v, err := k.Seal(f.field, *f.value, order.UID)
+		if err != nil {
+			return entity.Order{}, fmt.Errorf("encrypt %s: %w", f.field, err)
+		}
+
+		*f.value = v
+	}
+
+	return order, nil
+}
+
+// OpenOrder decrypts the personal data of the order, values stored in plain
+// text are kept as is.
+func (k *KeyRing) OpenOrder(order entity.Order) (entity.Order, error) {
+	for _, f := range orderFields(&order) {
+		v, err := k.Open(f.field, *f.value, order.UID)
+		if err != nil {
+			return entity.Order{}, err
+		}
+
+		*f.value = v
+	}
+
+	return order, nil
+}
+
+// Serializer is the serializer of the cache.
+type Serializer interface {
+	Marshal(v any) ([]byte, error)
+	Unmarshal(data []byte, v any) error
+}
+
+// NewSerializer seals the personal data of orders before they're serialized
+// by next, so the orders aren't stored in plain text outside of the
+// database. Other values are passed to next as is.
+func NewSerializer(keys *KeyRing, next Serializer) Serializer {
+	return &serializer{keys: keys, next: next}
+}
+
+type serializer struct {
+	keys *KeyRing
+	next Serializer
+}
+
+func (s *serializer) Marshal(v any) ([]byte, error) {
+	if order, ok := v.(entity.Order); ok {
+		sealed, err := s.keys.SealOrder(order)
+		if err != nil {
+			return nil, err
+		}
+
+		v = sealed
+	}
+
+	return s.next.Marshal(v)
+}
+
+func (s *serializer) Unmarshal(data []byte, v any) error {
+	err := s.next.Unmarshal(data, v)
+	if err != nil {
+		return err
+	}
+
+	if order, ok := v.(*entity.Order); ok {
+		*order, err = s.keys.OpenOrder(*order)
+	}
+
+	return err
+}
@@ -0,0 +1,82 @@
+package pii
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+type jsonSerializer struct{}
+
+func (jsonSerializer) Marshal(v any) ([]byte, error) {
+	return json.Marshal(v)
+}
+
+func (jsonSerializer) Unmarshal(data []byte, v any) error {
+	return json.Unmarshal(data, v)
+}
+
+func TestKeyRingSealOrder(t *testing.T) {
+	k, err := NewKeyRing(&Config{Primary: newKey.ID, Keys: testKeys, IndexKey: indexKey, Fields: []string{FieldPhone, FieldEmail}})
+	require.NoError(t, err)
+
+	order := entity.Order{
+		UID: uuid.New(),
+		Delivery: entity.Delivery{
+			Name:  "Иван Иванов",
+			Phone: "+79991234599",
+			Email: "ivanov@example.com",
+		},
+	}
+
+	sealed, err := k.SealOrder(order)
+	require.NoError(t, err)
+	require.Equal(t, order.Delivery.Name, sealed.Delivery.Name, "field isn't encrypted")
+	require.True(t, strings.HasPrefix(sealed.Delivery.Phone, prefix))
+	require.True(t, strings.HasPrefix(sealed.Delivery.Email, prefix))
+
+	opened, err := k.OpenOrder(sealed)
+	require.NoError(t, err)
+	require.Equal(t, order, opened)
+
+	opened, err = k.OpenOrder(order)
+	require.NoError(t, err)
+	require.Equal(t, order, opened, "plain text values are kept")
+
+	sealed.UID = uuid.New()
+
+	_, err = k.OpenOrder(sealed)
+	require.ErrorIs(t, err, ErrInvalidValue)
+}
+
+func TestSerializer(t *testing.T) {
+	k, err := NewKeyRing(&Config{Primary: newKey.ID, Keys: testKeys, IndexKey: indexKey})
+	require.NoError(t, err)
+
+	var (
+		s     = NewSerializer(k, jsonSerializer{})
+		order = entity.Order{
+			UID:      uuid.New(),
+			Delivery: entity.Delivery{Phone: "+79991234599", City: "Москва"},
+		}
+	)
+
+	data, err := s.Marshal(order)
+	require.NoError(t, err)
+	require.NotContains(t, string(data), "79991234599")
+	require.Contains(t, string(data), "Москва")
+
+	var got entity.Order
+
+	require.NoError(t, s.Unmarshal(data, &got))
+	require.Equal(t, order, got)
+
+	data, err = s.Marshal("+79991234599")
+	require.NoError(t, err)
+	require.JSONEq(t, `"+79991234599"`, string(data), "other values are passed as is")
+}
@@ -2,14 +2,24 @@ package repo
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
 
 	"github.com/imotkin/L0/internal/entity"
 )
 
+// ErrInvalidOrder is returned for orders the database rejects, e.g. for a
+// value out of range or a violated constraint. Storing them again fails too.
+var ErrInvalidOrder = errors.New("order is rejected by the database")
+
 type Repository interface {
 	AddOrder(ctx context.Context, order entity.Order) (bool, error)
+	AddOrders(ctx context.Context, orders []entity.Order) ([]uuid.UUID, error)
 	GetOrder(ctx context.Context, id uuid.UUID) (entity.Order, error)
-	List(ctx context.Context) ([]entity.Order, error)
+	List(ctx context.Context, query ListQuery) (Page, error)
+	Export(ctx context.Context, query ListQuery, fn func(entity.Order) error) error
+	Search(ctx context.Context, query SearchQuery) (SearchPage, error)
+	UpdateStatus(ctx context.Context, change entity.StatusChange) (entity.StatusChange, error)
+	StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.StatusChange, error)
 }
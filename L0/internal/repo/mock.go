@@ -57,6 +57,35 @@ func (mr *MockRepositoryMockRecorder) AddOrder(ctx, order any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrder", reflect.TypeOf((*MockRepository)(nil).AddOrder), ctx, order)
 }
 
+// AddOrders mocks base method.
+func (m *MockRepository) AddOrders(ctx context.Context, orders []entity.Order) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrders", ctx, orders)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddOrders indicates an expected call of AddOrders.
+func (mr *MockRepositoryMockRecorder) AddOrders(ctx, orders any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrders", reflect.TypeOf((*MockRepository)(nil).AddOrders), ctx, orders)
+}
+
+// Export mocks base method.
+func (m *MockRepository) Export(ctx context.Context, query ListQuery, fn func(entity.Order) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, query, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockRepositoryMockRecorder) Export(ctx, query, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockRepository)(nil).Export), ctx, query, fn)
+}
+
 // GetOrder mocks base method.
 func (m *MockRepository) GetOrder(ctx context.Context, id uuid.UUID) (entity.Order, error) {
 	m.ctrl.T.Helper()
@@ -73,16 +102,61 @@ func (mr *MockRepositoryMockRecorder) GetOrder(ctx, id any) *gomock.Call {
 }
 
 // List mocks base method.
-func (m *MockRepository) List(ctx context.Context) ([]entity.Order, error) {
+func (m *MockRepository) List(ctx context.Context, query ListQuery) (Page, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "List", ctx)
-	ret0, _ := ret[0].([]entity.Order)
+	ret := m.ctrl.Call(m, "List", ctx, query)
+	ret0, _ := ret[0].(Page)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // List indicates an expected call of List.
-func (mr *MockRepositoryMockRecorder) List(ctx any) *gomock.Call {
+func (mr *MockRepositoryMockRecorder) List(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx, query)
+}
+
+// Search mocks base method.
+func (m *MockRepository) Search(ctx context.Context, query SearchQuery) (SearchPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query)
+	ret0, _ := ret[0].(SearchPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockRepositoryMockRecorder) Search(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockRepository)(nil).Search), ctx, query)
+}
+
+// StatusHistory mocks base method.
+func (m *MockRepository) StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "StatusHistory", ctx, id)
+	ret0, _ := ret[0].([]entity.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// StatusHistory indicates an expected call of StatusHistory.
+func (mr *MockRepositoryMockRecorder) StatusHistory(ctx, id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StatusHistory", reflect.TypeOf((*MockRepository)(nil).StatusHistory), ctx, id)
+}
+
+// UpdateStatus mocks base method.
+func (m *MockRepository) UpdateStatus(ctx context.Context, change entity.StatusChange) (entity.StatusChange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateStatus", ctx, change)
+	ret0, _ := ret[0].(entity.StatusChange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateStatus indicates an expected call of UpdateStatus.
+func (mr *MockRepositoryMockRecorder) UpdateStatus(ctx, change any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepository)(nil).List), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateStatus", reflect.TypeOf((*MockRepository)(nil).UpdateStatus), ctx, change)
 }
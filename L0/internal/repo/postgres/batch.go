@@ -0,0 +1,199 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/pii"
+)
+
+// AddOrders stores orders in a single transaction: orders are inserted with
+// one pgx.Batch round trip to find out which of them are new, and the rest
+// of the rows are written only for new orders with COPY.
+func (p *Postgres) AddOrders(ctx context.Context, orders []entity.Order) ([]uuid.UUID, error) {
+	if len(orders) == 0 {
+		return nil, nil
+	}
+
+	ids, err := p.addOrdersTx(ctx, orders)
+	return ids, rejected(err)
+}
+
+func (p *Postgres) addOrdersTx(ctx context.Context, orders []entity.Order) ([]uuid.UUID, error) {
+	tx, err := p.pool.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+		IsoLevel:   pgx.ReadCommitted,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	inserted, err := p.addOrdersBatch(ctx, tx, orders)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add orders: %w", err)
+	}
+
+	if len(inserted) == 0 {
+		return nil, nil
+	}
+
+	err = p.copyDeliveries(ctx, tx, inserted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add deliveries: %w", err)
+	}
+
+	err = p.copyPayments(ctx, tx, inserted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add payments: %w", err)
+	}
+
+	err = p.copyItems(ctx, tx, inserted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add items: %w", err)
+	}
+
+	err = p.addOrdersEvents(ctx, tx, inserted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add order events: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(inserted))
+	for _, order := range inserted {
+		ids = append(ids, order.UID)
+	}
+
+	return ids, nil
+}
+
+func (p *Postgres) addOrdersBatch(ctx context.Context, tx pgx.Tx, orders []entity.Order) ([]entity.Order, error) {
+	batch := new(pgx.Batch)
+
+	for _, order := range orders {
+		batch.Queue(insertOrderQuery, orderFields(order)...)
+	}
+
+	results := tx.SendBatch(ctx, batch)
+
+	inserted := make([]entity.Order, 0, len(orders))
+
+	for _, order := range orders {
+		tag, err := results.Exec()
+		if err != nil {
+			results.Close()
+			return nil, fmt.Errorf("insert order %s: %w", order.UID, err)
+		}
+
+		if tag.RowsAffected() > 0 {
+			inserted = append(inserted, order)
+		}
+	}
+
+	err := results.Close()
+	if err != nil {
+		return nil, fmt.Errorf("close batch: %w", err)
+	}
+
+	return inserted, nil
+}
+
+func (p *Postgres) copyDeliveries(ctx context.Context, tx pgx.Tx, orders []entity.Order) error {
+	columns := []string{
+		"order_id", "name", "phone", "zip", "city",
+		"address", "region", "email", "phone_index", "email_index",
+	}
+
+	rows := pgx.CopyFromSlice(len(orders), func(i int) ([]any, error) {
+		return p.deliveryRow(orders[i].UID, orders[i].Delivery)
+	})
+
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"deliveries"}, columns, rows)
+
+	return err
+}
+
+func (p *Postgres) copyPayments(ctx context.Context, tx pgx.Tx, orders []entity.Order) error {
+	columns := []string{
+		"order_id", "transaction", "request_id", "currency", "provider",
+		"amount", "payment_dt", "bank", "delivery_cost", "goods_total", "custom_fee",
+	}
+
+	rows := pgx.CopyFromSlice(len(orders), func(i int) ([]any, error) {
+		pm := orders[i].Payment
+
+		requestID, err := p.seal(pii.FieldRequestID, pm.RequestID, orders[i].UID)
+		if err != nil {
+			return nil, err
+		}
+
+		return []any{
+			orders[i].UID, pm.Transaction, requestID, pm.Currency, pm.Provider,
+			pm.Amount, time.Unix(int64(pm.PaymentDt), 0), pm.Bank, pm.DeliveryCost,
+			pm.GoodsTotal, pm.CustomFee,
+		}, nil
+	})
+
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"payments"}, columns, rows)
+
+	return err
+}
+
+func (p *Postgres) copyItems(ctx context.Context, tx pgx.Tx, orders []entity.Order) error {
+	columns := []string{
+		"order_id", "chrt_id", "track_number", "price", "rid", "name",
+		"sale", "size", "total_price", "nm_id", "brand", "status",
+	}
+
+	var rows [][]any
+
+	for _, order := range orders {
+		for _, item := range order.Items {
+			rows = append(rows, []any{
+				order.UID, item.ChrtID, item.TrackNumber, item.Price, item.RID, item.Name,
+				item.Sale, item.Size, item.TotalPrice, item.NmID, item.Brand, item.Status,
+			})
+		}
+	}
+
+	_, err := tx.CopyFrom(ctx, pgx.Identifier{"items"}, columns, pgx.CopyFromRows(rows))
+
+	return err
+}
+
+func (p *Postgres) addOrdersEvents(ctx context.Context, tx pgx.Tx, orders []entity.Order) error {
+	batch := new(pgx.Batch)
+
+	for _, order := range orders {
+		sealed, err := p.sealOrder(order)
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(sealed)
+		if err != nil {
+			return fmt.Errorf("encode event payload: %w", err)
+		}
+
+		batch.Queue(
+			`INSERT INTO order_status_history (order_id, to_status) VALUES ($1, $2)`,
+			order.UID, orderStatus(order),
+		)
+		batch.Queue(
+			`INSERT INTO outbox (event_type, key, payload) VALUES ($1, $2, $3)`,
+			entity.EventOrderAccepted, order.UID.String(), payload,
+		)
+	}
+
+	return tx.SendBatch(ctx, batch).Close()
+}
@@ -0,0 +1,305 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/pii"
+)
+
+const rotateBatchSize = 500
+
+// seal encrypts the value of the field when the key ring is set.
+func (p *Postgres) seal(field, value string, order uuid.UUID) (string, error) {
+	if p.keys == nil {
+		return value, nil
+	}
+
+	v, err := p.keys.Seal(field, value, order)
+	if err != nil {
+		return "", fmt.Errorf("encrypt %s: %w", field, err)
+	}
+
+	return v, nil
+}
+
+// index returns the blind index of the value, nil is stored as NULL.
+func (p *Postgres) index(field, value string) *string {
+	if p.keys == nil {
+		return nil
+	}
+
+	if idx := p.keys.Index(field, value); idx != "" {
+		return &idx
+	}
+
+	return nil
+}
+
+// deliveryRow returns the values of the deliveries columns in the order of
+// addDelivery and copyDeliveries.
+func (p *Postgres) deliveryRow(id uuid.UUID, d entity.Delivery) ([]any, error) {
+	sealed := d
+
+	for _, f := range []struct {
+		field string
+		value *string
+	}{
+		{pii.FieldName, &sealed.Name},
+		{pii.FieldPhone, &sealed.Phone},
+		{pii.FieldEmail, &sealed.Email},
+		{pii.FieldAddress, &sealed.Address},
+	} {
+		v, err := p.seal(f.field, *f.value, id)
+		if err != nil {
+			return nil, err
+		}
+
+		*f.value = v
+	}
+
+	return []any{
+		id, sealed.Name, sealed.Phone, sealed.Zip, sealed.City,
+		sealed.Address, sealed.Region, sealed.Email,
+		p.index(pii.FieldPhone, d.Phone), p.index(pii.FieldEmail, d.Email),
+	}, nil
+}
+
+// openOrder decrypts the personal data of the order. Without the key ring
+// the values are returned as they are stored.
+func (p *Postgres) openOrder(order *entity.Order) error {
+	if p.keys == nil {
+		return nil
+	}
+
+	opened, err := p.keys.OpenOrder(*order)
+	if err != nil {
+		return fmt.Errorf("decrypt order %s: %w", order.UID, err)
+	}
+
+	*order = opened
+
+	return nil
+}
+
+// sealOrder encrypts the personal data of the order when the key ring is
+// set.
+func (p *Postgres) sealOrder(order entity.Order) (entity.Order, error) {
+	if p.keys == nil {
+		return order, nil
+	}
+
+	return p.keys.SealOrder(order)
+}
+
+// openEvent decrypts the order of the event sealed by sealOrder.
+func (p *Postgres) openEvent(event *entity.Event) error {
+	if p.keys == nil || event.Type != entity.EventOrderAccepted {
+		return nil
+	}
+
+	var order entity.Order
+
+	err := json.Unmarshal(event.Payload, &order)
+	if err != nil {
+		return fmt.Errorf("decode event %d payload: %w", event.ID, err)
+	}
+
+	err = p.openOrder(&order)
+	if err != nil {
+		return err
+	}
+
+	event.Payload, err = json.Marshal(order)
+	if err != nil {
+		return fmt.Errorf("encode event %d payload: %w", event.ID, err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) openOrders(orders []entity.Order) error {
+	for i := range orders {
+		err := p.openOrder(&orders[i])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+type sealedRow struct {
+	id         uuid.UUID
+	name       string
+	phone      string
+	email      string
+	address    string
+	requestID  string
+	phoneIndex *string
+	emailIndex *string
+}
+
+// RotateKeys brings the stored personal data in line with the key ring:
+// values sealed with old keys are resealed with the primary key, values
+// stored in plain text are encrypted and the blind indexes are filled.
+// Orders are processed in batches, each in its own transaction, so the
+// rotation can be stopped and started again. It returns the number of
+// updated orders.
+func (p *Postgres) RotateKeys(ctx context.Context) (int, error) {
+	if p.keys == nil {
+		return 0, errors.New("encryption is not configured")
+	}
+
+	var (
+		after   uuid.UUID
+		updated int
+	)
+
+	for {
+		n, last, err := p.rotateBatch(ctx, after)
+		if err != nil {
+			return updated, err
+		}
+
+		updated += n
+
+		if last == uuid.Nil {
+			return updated, nil
+		}
+
+		after = last
+	}
+}
+
+// rotateBatch rotates the batch of orders after the id, it returns the id of
+// the last order of the batch or uuid.Nil if there are no more orders.
+func (p *Postgres) rotateBatch(ctx context.Context, after uuid.UUID) (int, uuid.UUID, error) {
+	tx, err := p.pool.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+		IsoLevel:   pgx.ReadCommitted,
+	})
+	if err != nil {
+		return 0, uuid.Nil, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		SELECT d.order_id, COALESCE(d.name, ''), COALESCE(d.phone, ''), COALESCE(d.email, ''),
+		       COALESCE(d.address, ''), COALESCE(p.request_id, ''), d.phone_index, d.email_index
+		  FROM deliveries d
+		  JOIN payments p ON p.order_id = d.order_id
+		 WHERE d.order_id > $1
+		 ORDER BY d.order_id
+		 LIMIT $2
+		   FOR UPDATE OF d, p`, after, rotateBatchSize,
+	)
+	if err != nil {
+		return 0, uuid.Nil, fmt.Errorf("run deliveries query: %w", err)
+	}
+
+	sealed, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (r sealedRow, err error) {
+		err = row.Scan(&r.id, &r.name, &r.phone, &r.email, &r.address, &r.requestID, &r.phoneIndex, &r.emailIndex)
+		return r, err
+	})
+	if err != nil {
+		return 0, uuid.Nil, fmt.Errorf("collect deliveries: %w", err)
+	}
+
+	if len(sealed) == 0 {
+		return 0, uuid.Nil, nil
+	}
+
+	batch := new(pgx.Batch)
+
+	for _, r := range sealed {
+		changed, err := p.rotateRow(&r)
+		if err != nil {
+			return 0, uuid.Nil, err
+		}
+
+		if !changed {
+			continue
+		}
+
+		batch.Queue(`
+			UPDATE deliveries
+			   SET name = $2, phone = $3, email = $4, address = $5, phone_index = $6, email_index = $7
+			 WHERE order_id = $1`,
+			r.id, r.name, r.phone, r.email, r.address, r.phoneIndex, r.emailIndex,
+		)
+		batch.Queue(`UPDATE payments SET request_id = $2 WHERE order_id = $1`, r.id, r.requestID)
+	}
+
+	err = tx.SendBatch(ctx, batch).Close()
+	if err != nil {
+		return 0, uuid.Nil, fmt.Errorf("update deliveries: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return 0, uuid.Nil, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	last := sealed[len(sealed)-1].id
+	if len(sealed) < rotateBatchSize {
+		last = uuid.Nil
+	}
+
+	return batch.Len() / 2, last, nil
+}
+
+// rotateRow rotates the values of the row in place and reports whether the
+// row has to be updated.
+func (p *Postgres) rotateRow(r *sealedRow) (bool, error) {
+	var changed bool
+
+	for _, f := range []struct {
+		field string
+		value *string
+		index **string
+	}{
+		{pii.FieldName, &r.name, nil},
+		{pii.FieldPhone, &r.phone, &r.phoneIndex},
+		{pii.FieldEmail, &r.email, &r.emailIndex},
+		{pii.FieldAddress, &r.address, nil},
+		{pii.FieldRequestID, &r.requestID, nil},
+	} {
+		if f.index != nil {
+			plain, err := p.keys.Open(f.field, *f.value, r.id)
+			if err != nil {
+				return false, fmt.Errorf("decrypt order %s: %w", r.id, err)
+			}
+
+			idx := p.index(f.field, plain)
+			if !equalIndex(idx, *f.index) {
+				*f.index = idx
+				changed = true
+			}
+		}
+
+		v, ok, err := p.keys.Rotate(f.field, *f.value, r.id)
+		if err != nil {
+			return false, fmt.Errorf("rotate order %s: %w", r.id, err)
+		}
+
+		*f.value = v
+		changed = changed || ok
+	}
+
+	return changed, nil
+}
+
+func equalIndex(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return *a == *b
+}
@@ -0,0 +1,76 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/repo"
+)
+
+const exportBatchSize = 500
+
+// Export calls fn for every order matching the filters of the query, in the
+// order of List. Orders are fetched in batches from a server-side cursor, so
+// only one batch is kept in memory. Limit of the query is not applied.
+func (p *Postgres) Export(ctx context.Context, q repo.ListQuery, fn func(entity.Order) error) error {
+	tx, err := p.pool.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadOnly,
+		IsoLevel:   pgx.RepeatableRead,
+	})
+	if err != nil {
+		return fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	where, args := listFilter(q)
+
+	query := fmt.Sprintf(
+		`DECLARE orders_export NO SCROLL CURSOR FOR
+        SELECT %s
+        FROM orders o
+        LEFT JOIN deliveries d ON d.order_id = o.id
+        LEFT JOIN payments p ON p.order_id = o.id
+        %s
+        ORDER BY o.date_created DESC, o.id DESC`, listColumns, where,
+	)
+
+	_, err = tx.Exec(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("declare export cursor: %w", err)
+	}
+
+	fetch := fmt.Sprintf("FETCH %d FROM orders_export", exportBatchSize)
+
+	for {
+		rows, err := tx.Query(ctx, fetch)
+		if err != nil {
+			return fmt.Errorf("fetch orders: %w", err)
+		}
+
+		orders, err := pgx.CollectRows(rows, scanListRow)
+		if err != nil {
+			return fmt.Errorf("collect orders: %w", err)
+		}
+
+		err = p.openOrders(orders)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range orders {
+			err = fn(order)
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(orders) < exportBatchSize {
+			break
+		}
+	}
+
+	return tx.Commit(ctx)
+}
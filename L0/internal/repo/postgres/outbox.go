@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+func (p *Postgres) addEvent(ctx context.Context, tx pgx.Tx, kind entity.EventType, key string, value any) error {
+	payload, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("encode event payload: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO outbox (event_type, key, payload) VALUES ($1, $2, $3)`,
+		kind, key, payload,
+	)
+
+	return err
+}
+
+// ClaimEvents claims only the earliest pending event of a key, so the events
+// of a key are published in order: the next one is claimed once the previous
+// one is published.
+func (p *Postgres) ClaimEvents(ctx context.Context, limit int, lease time.Duration) ([]entity.Event, error) {
+	query := `
+		UPDATE outbox SET next_attempt_at = now() + $2::interval
+		 WHERE id IN (
+			SELECT id FROM outbox o
+			 WHERE published_at IS NULL AND next_attempt_at <= now()
+			   AND NOT EXISTS (
+				SELECT 1 FROM outbox prev
+				 WHERE prev.key = o.key AND prev.published_at IS NULL AND prev.id < o.id
+			   )
+			 ORDER BY id
+			 LIMIT $1
+			   FOR UPDATE SKIP LOCKED
+		 )
+		RETURNING id, event_type, key, payload, attempts, created_at`
+
+	rows, err := p.pool.Query(ctx, query, limit, lease)
+	if err != nil {
+		return nil, fmt.Errorf("run claim query: %w", err)
+	}
+
+	events, err := pgx.CollectRows(rows, pgx.RowToStructByPos[entity.Event])
+	if err != nil {
+		return nil, fmt.Errorf("collect events: %w", err)
+	}
+
+	for i := range events {
+		err = p.openEvent(&events[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// RETURNING doesn't keep the order of the subquery
+	slices.SortFunc(events, func(a, b entity.Event) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return events, nil
+}
+
+func (p *Postgres) MarkPublished(ctx context.Context, ids ...int64) error {
+	_, err := p.pool.Exec(ctx,
+		`UPDATE outbox SET published_at = now(), last_error = NULL WHERE id = ANY($1)`,
+		ids,
+	)
+	if err != nil {
+		return fmt.Errorf("mark events as published: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) MarkFailed(ctx context.Context, id int64, reason string, next time.Time) error {
+	_, err := p.pool.Exec(ctx,
+		`UPDATE outbox
+		    SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+		  WHERE id = $1`,
+		id, reason, next,
+	)
+	if err != nil {
+		return fmt.Errorf("mark event as failed: %w", err)
+	}
+
+	return nil
+}
+
+func (p *Postgres) PendingEvents(ctx context.Context) (int, error) {
+	var count int
+
+	err := p.pool.QueryRow(ctx,
+		`SELECT count(*) FROM outbox WHERE published_at IS NULL`,
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("count pending events: %w", err)
+	}
+
+	return count, nil
+}
+
+func (p *Postgres) DeletePublished(ctx context.Context, before time.Time) (int, error) {
+	tag, err := p.pool.Exec(ctx, `DELETE FROM outbox WHERE published_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete published events: %w", err)
+	}
+
+	return int(tag.RowsAffected()), nil
+}
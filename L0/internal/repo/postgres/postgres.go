@@ -4,21 +4,35 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/pressly/goose/v3"
 
 	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/pii"
+	"github.com/imotkin/L0/internal/repo"
 )
 
 type Postgres struct {
 	pool *pgxpool.Pool
+	keys *pii.KeyRing
 }
 
-func New(ctx context.Context, url string) (*Postgres, error) {
+type Option func(*Postgres)
+
+// WithKeyRing encrypts the personal data of orders with the key ring.
+func WithKeyRing(keys *pii.KeyRing) Option {
+	return func(p *Postgres) {
+		p.keys = keys
+	}
+}
+
+func New(ctx context.Context, url string, opts ...Option) (*Postgres, error) {
 	pool, err := pgxpool.New(ctx, url)
 	if err != nil {
 		return nil, fmt.Errorf("connect database: %w", err)
@@ -29,7 +43,13 @@ func New(ctx context.Context, url string) (*Postgres, error) {
 		return nil, fmt.Errorf("ping database: %w", err)
 	}
 
-	return &Postgres{pool: pool}, nil
+	p := &Postgres{pool: pool}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p, nil
 }
 
 func (p *Postgres) MigrateUp(ctx context.Context, path string) error {
@@ -60,61 +80,152 @@ func (p *Postgres) MigrateDown(ctx context.Context, path string) error {
 	return goose.DownContext(ctx, db, path)
 }
 
-func (p *Postgres) List(ctx context.Context) ([]entity.Order, error) {
-	query :=
-		`SELECT
-            o.id, o.track_number, o.entry, o.locale, o.internal_signature,
-            o.customer_id, o.delivery_service, o.shardkey, o.sm_id,
-            o.date_created, o.oof_shard,
-            d.name, d.phone, d.zip, d.city, d.address, d.region, d.email,
-            p.transaction, p.request_id, p.currency, p.provider, p.amount,
-            EXTRACT(EPOCH FROM p.payment_dt)::BIGINT, p.bank, p.delivery_cost, p.goods_total, p.custom_fee,
-			(SELECT COALESCE(jsonb_agg(item), '[]'::jsonb) 
-     			FROM (SELECT * FROM items WHERE order_id = o.id) item
-    		) AS items_json
+func (p *Postgres) List(ctx context.Context, q repo.ListQuery) (repo.Page, error) {
+	limit := q.Limit
+	if limit <= 0 {
+		limit = repo.DefaultLimit
+	}
+
+	where, args := listFilter(q)
+	args = append(args, limit+1)
+
+	query := fmt.Sprintf(
+		`SELECT %s
         FROM orders o
         LEFT JOIN deliveries d ON d.order_id = o.id
         LEFT JOIN payments p ON p.order_id = o.id
-	`
+        %s
+        ORDER BY o.date_created DESC, o.id DESC
+        LIMIT $%d`, listColumns, where, len(args),
+	)
 
 	tx, err := p.pool.BeginTx(ctx, pgx.TxOptions{
 		AccessMode: pgx.ReadOnly,
 		IsoLevel:   pgx.RepeatableRead,
 	})
 	if err != nil {
-		return nil, fmt.Errorf("begin transaction: %w", err)
+		return repo.Page{}, fmt.Errorf("begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx)
 
-	rows, err := tx.Query(ctx, query)
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return repo.Page{}, fmt.Errorf("run orders query: %w", err)
+	}
+
+	orders, err := pgx.CollectRows(rows, scanListRow)
+	if err != nil {
+		return repo.Page{}, fmt.Errorf("collect orders: %w", err)
+	}
+
+	err = p.openOrders(orders)
 	if err != nil {
-		return nil, fmt.Errorf("run orders query: %w", err)
+		return repo.Page{}, err
 	}
 
-	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (order entity.Order, err error) {
-		fields := []any{
-			&order.UID, &order.TrackNumber, &order.Entry, &order.Locale, &order.InternalSignature,
-			&order.CustomerID, &order.DeliveryService, &order.ShardKey, &order.SmID,
-			&order.DateCreated, &order.Shard,
+	page := repo.Page{Orders: orders}
 
-			&order.Delivery.Name, &order.Delivery.Phone, &order.Delivery.Zip, &order.Delivery.City,
-			&order.Delivery.Address, &order.Delivery.Region, &order.Delivery.Email,
+	if len(orders) > limit {
+		page.Orders = orders[:limit]
+		page.Next = repo.CursorOf(page.Orders[limit-1])
+	}
 
-			&order.Payment.Transaction, &order.Payment.RequestID, &order.Payment.Currency,
-			&order.Payment.Provider, &order.Payment.Amount, &order.Payment.PaymentDt,
-			&order.Payment.Bank, &order.Payment.DeliveryCost, &order.Payment.GoodsTotal,
-			&order.Payment.CustomFee,
+	return page, nil
+}
 
-			&order.Items,
-		}
+// listFilter returns the WHERE clause for the filters of the query, the
+// arguments start from $1. Limit is not applied.
+func listFilter(q repo.ListQuery) (string, []any) {
+	var (
+		conds []string
+		args  []any
+	)
+
+	arg := func(v any) string {
+		args = append(args, v)
+		return fmt.Sprintf("$%d", len(args))
+	}
 
-		err = rows.Scan(fields...)
-		if err != nil {
-			return entity.Order{}, fmt.Errorf("scan order fields: %w", err)
-		}
+	if q.After != nil {
+		conds = append(conds, fmt.Sprintf(
+			"(o.date_created, o.id) < (%s, %s)", arg(q.After.DateCreated), arg(q.After.ID),
+		))
+	}
 
-		return order, nil
-	})
+	if len(q.IDs) > 0 {
+		conds = append(conds, "o.id = ANY("+arg(q.IDs)+")")
+	}
+
+	if q.CustomerID != "" {
+		conds = append(conds, "o.customer_id = "+arg(q.CustomerID))
+	}
+
+	if q.TrackNumber != "" {
+		conds = append(conds, "o.track_number = "+arg(q.TrackNumber))
+	}
+
+	if q.DeliveryService != "" {
+		conds = append(conds, "o.delivery_service = "+arg(q.DeliveryService))
+	}
+
+	if q.Locale != "" {
+		conds = append(conds, "o.locale = "+arg(q.Locale))
+	}
+
+	if !q.From.IsZero() {
+		conds = append(conds, "o.date_created >= "+arg(q.From))
+	}
+
+	if !q.To.IsZero() {
+		conds = append(conds, "o.date_created < "+arg(q.To))
+	}
+
+	if len(conds) == 0 {
+		return "", nil
+	}
+
+	return "WHERE " + strings.Join(conds, " AND "), args
+}
+
+// listColumns are scanned by scanListRow, items are aggregated into JSON.
+const listColumns = `
+            o.id, o.track_number, o.entry, o.locale, o.internal_signature,
+            o.customer_id, o.delivery_service, o.shardkey, o.sm_id,
+            o.date_created, o.oof_shard, o.status,
+            d.name, d.phone, d.zip, d.city, d.address, d.region, d.email,
+            p.transaction, p.request_id, p.currency, p.provider, p.amount,
+            EXTRACT(EPOCH FROM p.payment_dt)::BIGINT, p.bank, p.delivery_cost, p.goods_total, p.custom_fee,
+            (SELECT COALESCE(jsonb_agg(item), '[]'::jsonb)
+               FROM (
+                SELECT chrt_id, track_number, price, rid, name,
+                       sale, size, total_price, nm_id, brand, status
+                  FROM items WHERE order_id = o.id
+               ) item
+            ) AS items_json`
+
+func scanListRow(row pgx.CollectableRow) (order entity.Order, err error) {
+	fields := []any{
+		&order.UID, &order.TrackNumber, &order.Entry, &order.Locale, &order.InternalSignature,
+		&order.CustomerID, &order.DeliveryService, &order.ShardKey, &order.SmID,
+		&order.DateCreated, &order.Shard, &order.Status,
+
+		&order.Delivery.Name, &order.Delivery.Phone, &order.Delivery.Zip, &order.Delivery.City,
+		&order.Delivery.Address, &order.Delivery.Region, &order.Delivery.Email,
+
+		&order.Payment.Transaction, &order.Payment.RequestID, &order.Payment.Currency,
+		&order.Payment.Provider, &order.Payment.Amount, &order.Payment.PaymentDt,
+		&order.Payment.Bank, &order.Payment.DeliveryCost, &order.Payment.GoodsTotal,
+		&order.Payment.CustomFee,
+
+		&order.Items,
+	}
+
+	err = row.Scan(fields...)
+	if err != nil {
+		return entity.Order{}, fmt.Errorf("scan order fields: %w", err)
+	}
+
+	return order, nil
 }
 
 func (p *Postgres) GetOrder(ctx context.Context, id uuid.UUID) (entity.Order, error) {
@@ -131,7 +242,7 @@ func (p *Postgres) GetOrder(ctx context.Context, id uuid.UUID) (entity.Order, er
         SELECT
             o.id, o.track_number, o.entry, o.locale, o.internal_signature,
             o.customer_id, o.delivery_service, o.shardkey, o.sm_id,
-            o.date_created, o.oof_shard,
+            o.date_created, o.oof_shard, o.status,
             d.name, d.phone, d.zip, d.city, d.address, d.region, d.email,
             p.transaction, p.request_id, p.currency, p.provider, p.amount,
             EXTRACT(EPOCH FROM p.payment_dt)::BIGINT, p.bank, p.delivery_cost, p.goods_total, p.custom_fee
@@ -145,7 +256,7 @@ func (p *Postgres) GetOrder(ctx context.Context, id uuid.UUID) (entity.Order, er
 	fields := []any{
 		&order.UID, &order.TrackNumber, &order.Entry, &order.Locale, &order.InternalSignature,
 		&order.CustomerID, &order.DeliveryService, &order.ShardKey, &order.SmID,
-		&order.DateCreated, &order.Shard,
+		&order.DateCreated, &order.Shard, &order.Status,
 
 		&order.Delivery.Name, &order.Delivery.Phone, &order.Delivery.Zip, &order.Delivery.City,
 		&order.Delivery.Address, &order.Delivery.Region, &order.Delivery.Email,
@@ -165,6 +276,11 @@ func (p *Postgres) GetOrder(ctx context.Context, id uuid.UUID) (entity.Order, er
 		return entity.Order{}, fmt.Errorf("run order query: %w", err)
 	}
 
+	err = p.openOrder(&order)
+	if err != nil {
+		return entity.Order{}, err
+	}
+
 	itemsQuery := `
 		SELECT chrt_id, track_number, price, rid, name, 
 			   sale, size, total_price, nm_id, brand, status
@@ -192,6 +308,11 @@ func (p *Postgres) GetOrder(ctx context.Context, id uuid.UUID) (entity.Order, er
 }
 
 func (p *Postgres) AddOrder(ctx context.Context, order entity.Order) (bool, error) {
+	inserted, err := p.addOrderTx(ctx, order)
+	return inserted, rejected(err)
+}
+
+func (p *Postgres) addOrderTx(ctx context.Context, order entity.Order) (bool, error) {
 	tx, err := p.pool.BeginTx(ctx, pgx.TxOptions{
 		AccessMode: pgx.ReadWrite,
 		IsoLevel:   pgx.ReadCommitted,
@@ -207,7 +328,7 @@ func (p *Postgres) AddOrder(ctx context.Context, order entity.Order) (bool, erro
 	}
 
 	if !inserted {
-		return true, nil
+		return false, nil
 	}
 
 	err = p.addDelivery(ctx, tx, order.UID, order.Delivery)
@@ -227,6 +348,16 @@ func (p *Postgres) AddOrder(ctx context.Context, order entity.Order) (bool, erro
 		}
 	}
 
+	sealed, err := p.sealOrder(order)
+	if err != nil {
+		return false, fmt.Errorf("failed to add order event: %w", err)
+	}
+
+	err = p.addEvent(ctx, tx, entity.EventOrderAccepted, order.UID.String(), sealed)
+	if err != nil {
+		return false, fmt.Errorf("failed to add order event: %w", err)
+	}
+
 	err = tx.Commit(ctx)
 	if err != nil {
 		return false, fmt.Errorf("commit transaction: %w", err)
@@ -235,15 +366,35 @@ func (p *Postgres) AddOrder(ctx context.Context, order entity.Order) (bool, erro
 	return true, nil
 }
 
-func (p *Postgres) addOrder(ctx context.Context, tx pgx.Tx, order entity.Order) (bool, error) {
-	query := `
-		INSERT INTO orders (
-			id, track_number, entry, locale, internal_signature, customer_id, 
-			delivery_service, shardkey, sm_id, date_created, oof_shard
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, DEFAULT)
-		ON CONFLICT DO NOTHING`
+// rejected marks errors of invalid data and violated constraints with
+// repo.ErrInvalidOrder, they are distinguished by the SQLSTATE class.
+func rejected(err error) error {
+	var pgErr *pgconn.PgError
 
-	fields := []any{
+	if errors.As(err, &pgErr) && (strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")) {
+		return fmt.Errorf("%w: %w", repo.ErrInvalidOrder, err)
+	}
+
+	return err
+}
+
+const insertOrderQuery = `
+	INSERT INTO orders (
+		id, track_number, entry, locale, internal_signature, customer_id, 
+		delivery_service, shardkey, sm_id, date_created, oof_shard, status
+	) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, DEFAULT, $11)
+	ON CONFLICT DO NOTHING`
+
+func orderStatus(order entity.Order) entity.Status {
+	if order.Status == "" {
+		return entity.StatusCreated
+	}
+
+	return order.Status
+}
+
+func orderFields(order entity.Order) []any {
+	return []any{
 		order.UID,
 		order.TrackNumber,
 		order.Entry,
@@ -254,11 +405,22 @@ func (p *Postgres) addOrder(ctx context.Context, tx pgx.Tx, order entity.Order)
 		order.ShardKey,
 		order.SmID,
 		order.DateCreated,
+		orderStatus(order),
+	}
+}
+
+func (p *Postgres) addOrder(ctx context.Context, tx pgx.Tx, order entity.Order) (bool, error) {
+	tag, err := tx.Exec(ctx, insertOrderQuery, orderFields(order)...)
+	if err != nil || tag.RowsAffected() == 0 {
+		return false, err
 	}
 
-	tag, err := tx.Exec(ctx, query, fields...)
+	_, err = tx.Exec(ctx,
+		`INSERT INTO order_status_history (order_id, to_status) VALUES ($1, $2)`,
+		order.UID, orderStatus(order),
+	)
 
-	return tag.RowsAffected() > 0, err
+	return err == nil, err
 }
 
 func (p *Postgres) addItem(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, item entity.Item) error {
@@ -291,22 +453,16 @@ func (p *Postgres) addItem(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, it
 func (p *Postgres) addDelivery(ctx context.Context, tx pgx.Tx, orderID uuid.UUID, delivery entity.Delivery) error {
 	query := `
 		INSERT INTO deliveries (
-			order_id, name, phone, zip,
-			city, address, region, email
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+			order_id, name, phone, zip, city,
+			address, region, email, phone_index, email_index
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`
 
-	fields := []any{
-		orderID,
-		delivery.Name,
-		delivery.Phone,
-		delivery.Zip,
-		delivery.City,
-		delivery.Address,
-		delivery.Region,
-		delivery.Email,
+	fields, err := p.deliveryRow(orderID, delivery)
+	if err != nil {
+		return err
 	}
 
-	_, err := tx.Exec(ctx, query, fields...)
+	_, err = tx.Exec(ctx, query, fields...)
 
 	return err
 }
@@ -318,10 +474,15 @@ func (p *Postgres) addPayment(ctx context.Context, tx pgx.Tx, orderID uuid.UUID,
 			amount, payment_dt, bank, delivery_cost, goods_total, custom_fee
 		) VALUES ($1, $2, $3, $4, $5, $6, to_timestamp($7), $8, $9, $10, $11)`
 
+	requestID, err := p.seal(pii.FieldRequestID, payment.RequestID, orderID)
+	if err != nil {
+		return err
+	}
+
 	fields := []any{
 		orderID,
 		payment.Transaction,
-		payment.RequestID,
+		requestID,
 		payment.Currency,
 		payment.Provider,
 		payment.Amount,
@@ -332,11 +493,100 @@ func (p *Postgres) addPayment(ctx context.Context, tx pgx.Tx, orderID uuid.UUID,
 		payment.CustomFee,
 	}
 
-	_, err := tx.Exec(ctx, query, fields...)
+	_, err = tx.Exec(ctx, query, fields...)
 
 	return err
 }
 
+func (p *Postgres) UpdateStatus(ctx context.Context, change entity.StatusChange) (entity.StatusChange, error) {
+	tx, err := p.pool.BeginTx(ctx, pgx.TxOptions{
+		AccessMode: pgx.ReadWrite,
+		IsoLevel:   pgx.ReadCommitted,
+	})
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	err = tx.QueryRow(ctx,
+		`SELECT status FROM orders WHERE id = $1 FOR UPDATE`, change.OrderUID,
+	).Scan(&change.From)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return entity.StatusChange{}, entity.ErrOrderNotFound
+		}
+
+		return entity.StatusChange{}, fmt.Errorf("get order status: %w", err)
+	}
+
+	if !change.From.CanTransition(change.To) {
+		return entity.StatusChange{}, fmt.Errorf(
+			"%w: %s -> %s", entity.ErrInvalidTransition, change.From, change.To,
+		)
+	}
+
+	err = tx.QueryRow(ctx,
+		`UPDATE orders SET status = $2, updated_at = now() WHERE id = $1 RETURNING updated_at`,
+		change.OrderUID, change.To,
+	).Scan(&change.ChangedAt)
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("update order status: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		`UPDATE items SET status = $2 WHERE order_id = $1`,
+		change.OrderUID, entity.ItemStatusOf(change.To),
+	)
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("update items status: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO order_status_history (order_id, from_status, to_status, comment, changed_at)
+		 VALUES ($1, $2, $3, NULLIF($4, ''), $5)`,
+		change.OrderUID, change.From, change.To, change.Comment, change.ChangedAt,
+	)
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("add status history: %w", err)
+	}
+
+	err = p.addEvent(ctx, tx, entity.EventStatusChanged, change.OrderUID.String(), change)
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("add status event: %w", err)
+	}
+
+	err = tx.Commit(ctx)
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("commit transaction: %w", err)
+	}
+
+	return change, nil
+}
+
+func (p *Postgres) StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.StatusChange, error) {
+	query := `
+		SELECT order_id, COALESCE(from_status, ''), to_status, COALESCE(comment, ''), changed_at
+		  FROM order_status_history
+		 WHERE order_id = $1
+		 ORDER BY changed_at, id`
+
+	rows, err := p.pool.Query(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("run history query: %w", err)
+	}
+
+	history, err := pgx.CollectRows(rows, pgx.RowToStructByPos[entity.StatusChange])
+	if err != nil {
+		return nil, fmt.Errorf("collect status history: %w", err)
+	}
+
+	if len(history) == 0 {
+		return nil, entity.ErrOrderNotFound
+	}
+
+	return history, nil
+}
+
 func (p *Postgres) Ping(ctx context.Context) error {
 	return p.pool.Ping(ctx)
 }
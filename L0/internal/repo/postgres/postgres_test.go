@@ -2,8 +2,12 @@ package postgres
 
 import (
 	"context"
+	"encoding/base64"
+	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,74 +16,31 @@ import (
 	pg "github.com/testcontainers/testcontainers-go/modules/postgres"
 
 	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/entity/entitytest"
+	"github.com/imotkin/L0/internal/pii"
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/stats"
 )
 
+// NewOrder returns an order of two items with its own customer and track
+// number, created now, so the orders of the tests can be told apart.
 func NewOrder() entity.Order {
-	return entity.Order{
-		UID:         uuid.New(),
-		TrackNumber: uuid.NewString(),
-		Entry:       "WBIL",
-		Delivery: entity.Delivery{
-			Name:    "Иван Иванов",
-			Phone:   "+79999999999",
-			Zip:     "101000",
-			City:    "Москва",
-			Address: "Площадь Мира, стр. 15",
-			Region:  "Центральный",
-			Email:   "ivanov@example.com",
-		},
-		Payment: entity.Payment{
-			Transaction:  uuid.New(),
-			RequestID:    uuid.NewString(),
-			Currency:     "USD",
-			Provider:     "wbpay",
-			Amount:       1817,
-			PaymentDt:    1637907727,
-			Bank:         "alpha",
-			DeliveryCost: 1500,
-			GoodsTotal:   317,
-			CustomFee:    0,
-		},
-		Items: []entity.Item{
-			{
-				ChrtID:      9934930,
-				TrackNumber: "WBILMTESTTRACK",
-				Price:       453,
-				RID:         uuid.New(),
-				Name:        "Product 1",
-				Sale:        30,
-				Size:        "0",
-				TotalPrice:  317,
-				NmID:        2389212,
-				Brand:       "ABC",
-				Status:      202,
-			},
-			{
-				ChrtID:      9934931,
-				TrackNumber: "WBILMTESTTRACK",
-				Price:       453,
-				RID:         uuid.New(),
-				Name:        "Product 2",
-				Sale:        30,
-				Size:        "0",
-				TotalPrice:  317,
-				NmID:        2389212,
-				Brand:       "DEF",
-				Status:      202,
-			},
-		},
-		Locale:            "en",
-		InternalSignature: "sign-123",
-		CustomerID:        uuid.NewString(),
-		DeliveryService:   "DHL",
-		ShardKey:          "9",
-		SmID:              99,
-		DateCreated:       time.Now().Truncate(0),
-		Shard:             "1",
-	}
+	order := entitytest.Order()
+	order.TrackNumber = uuid.NewString()
+	order.CustomerID = uuid.NewString()
+	order.DateCreated = time.Now().Truncate(0)
+	order.Items[0].Brand = "ABC"
+
+	item := order.Items[0]
+	item.ChrtID++
+	item.RID = uuid.New()
+	item.Brand = "DEF"
+	order.Items = append(order.Items, item)
+
+	return order
 }
 
-func migrationsPath(t *testing.T, name string) string {
+func migrationsPath(t testing.TB, name string) string {
 	t.Helper()
 
 	dir, err := os.Getwd()
@@ -107,7 +68,9 @@ func migrationsPath(t *testing.T, name string) string {
 	}
 }
 
-func TestIntegrationPostgres(t *testing.T) {
+func newTestPostgres(t testing.TB) *Postgres {
+	t.Helper()
+
 	ctx := context.Background()
 
 	container, err := pg.Run(
@@ -135,6 +98,15 @@ func TestIntegrationPostgres(t *testing.T) {
 	err = postgres.MigrateUp(ctx, migrationsPath(t, "migrations"))
 	require.NoError(t, err)
 
+	return postgres
+}
+
+func TestIntegrationPostgres(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		postgres = newTestPostgres(t)
+	)
+
 	order := NewOrder()
 
 	t.Run("AddOrder", func(t *testing.T) {
@@ -150,6 +122,72 @@ func TestIntegrationPostgres(t *testing.T) {
 		require.Equal(t, order, got)
 	})
 
+	t.Run("UpdateStatus", func(t *testing.T) {
+		change, err := postgres.UpdateStatus(ctx, entity.StatusChange{
+			OrderUID: order.UID,
+			To:       entity.StatusPaid,
+		})
+		require.NoError(t, err)
+		require.Equal(t, entity.StatusCreated, change.From)
+
+		_, err = postgres.UpdateStatus(ctx, entity.StatusChange{
+			OrderUID: order.UID,
+			To:       entity.StatusDelivered,
+		})
+		require.ErrorIs(t, err, entity.ErrInvalidTransition)
+
+		history, err := postgres.StatusHistory(ctx, order.UID)
+		require.NoError(t, err)
+		require.Len(t, history, 2)
+
+		got, err := postgres.GetOrder(ctx, order.UID)
+		require.NoError(t, err)
+
+		for _, item := range got.Items {
+			require.Equal(t, entity.ItemStatusPaid, item.Status, "items follow the order")
+		}
+
+		order.Status = entity.StatusPaid
+
+		for i := range order.Items {
+			order.Items[i].Status = entity.ItemStatusPaid
+		}
+	})
+
+	t.Run("Outbox", func(t *testing.T) {
+		events, err := postgres.ClaimEvents(ctx, 100, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, events, 1, "events of a key are claimed one by one")
+		require.Equal(t, entity.EventOrderAccepted, events[0].Type)
+
+		again, err := postgres.ClaimEvents(ctx, 100, time.Minute)
+		require.NoError(t, err)
+		require.Empty(t, again) // claimed events are leased
+
+		err = postgres.MarkPublished(ctx, events[0].ID)
+		require.NoError(t, err)
+
+		next, err := postgres.ClaimEvents(ctx, 100, time.Minute)
+		require.NoError(t, err)
+		require.Len(t, next, 1)
+		require.Equal(t, entity.EventStatusChanged, next[0].Type)
+
+		err = postgres.MarkPublished(ctx, next[0].ID)
+		require.NoError(t, err)
+
+		pending, err := postgres.PendingEvents(ctx)
+		require.NoError(t, err)
+		require.Zero(t, pending)
+
+		deleted, err := postgres.DeletePublished(ctx, time.Now().Add(-time.Hour))
+		require.NoError(t, err)
+		require.Zero(t, deleted)
+
+		deleted, err = postgres.DeletePublished(ctx, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		require.Equal(t, 2, deleted)
+	})
+
 	t.Run("List", func(t *testing.T) {
 		orders := make([]entity.Order, 0, 11)
 		orders = append(orders, order) // add previous test order
@@ -160,9 +198,337 @@ func TestIntegrationPostgres(t *testing.T) {
 			orders = append(orders, order)
 		}
 
-		got, err := postgres.List(ctx)
+		slices.Reverse(orders) // newest orders go first
+
+		got, err := postgres.List(ctx, repo.ListQuery{Limit: 100})
 		require.NoError(t, err)
 
-		require.Equal(t, orders, got)
+		require.Equal(t, orders, got.Orders)
+		require.Nil(t, got.Next)
+	})
+
+	t.Run("ListPages", func(t *testing.T) {
+		var (
+			got   []entity.Order
+			query = repo.ListQuery{Limit: 4}
+		)
+
+		for {
+			page, err := postgres.List(ctx, query)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page.Orders), query.Limit)
+
+			got = append(got, page.Orders...)
+
+			if page.Next == nil {
+				break
+			}
+
+			query.After = page.Next
+		}
+
+		require.Len(t, got, 11)
+	})
+
+	t.Run("ListFilter", func(t *testing.T) {
+		got, err := postgres.List(ctx, repo.ListQuery{CustomerID: order.CustomerID})
+		require.NoError(t, err)
+
+		require.Equal(t, []entity.Order{order}, got.Orders)
+	})
+
+	t.Run("ListIDs", func(t *testing.T) {
+		got, err := postgres.List(ctx, repo.ListQuery{IDs: []uuid.UUID{order.UID, uuid.New()}})
+		require.NoError(t, err)
+
+		require.Equal(t, []entity.Order{order}, got.Orders)
+	})
+
+	t.Run("Export", func(t *testing.T) {
+		list, err := postgres.List(ctx, repo.ListQuery{Limit: 100})
+		require.NoError(t, err)
+
+		var got []entity.Order
+
+		err = postgres.Export(ctx, repo.ListQuery{}, func(o entity.Order) error {
+			got = append(got, o)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, list.Orders, got)
+
+		got = got[:0]
+
+		err = postgres.Export(ctx, repo.ListQuery{CustomerID: order.CustomerID}, func(o entity.Order) error {
+			got = append(got, o)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, []entity.Order{order}, got)
+
+		stop := errors.New("stop")
+
+		err = postgres.Export(ctx, repo.ListQuery{}, func(entity.Order) error { return stop })
+		require.ErrorIs(t, err, stop)
+	})
+
+	t.Run("AddOrders", func(t *testing.T) {
+		orders := []entity.Order{NewOrder(), order, NewOrder(), NewOrder()}
+
+		ids, err := postgres.AddOrders(ctx, orders)
+		require.NoError(t, err)
+
+		require.Equal(t, []uuid.UUID{orders[0].UID, orders[2].UID, orders[3].UID}, ids)
+
+		got, err := postgres.GetOrder(ctx, orders[2].UID)
+		require.NoError(t, err)
+
+		require.Equal(t, orders[2], got)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		found := NewOrder()
+		found.Delivery.Email = "petrov_100@example.org"
+		found.Delivery.City = "Казань"
+		found.Items[0].Brand = "Vivienne Sabo"
+
+		_, err := postgres.AddOrder(ctx, found)
+		require.NoError(t, err)
+
+		cases := []struct {
+			query repo.SearchQuery
+			count int
+		}{
+			{query: repo.SearchQuery{Text: "petrov_100", Field: repo.SearchEmail}, count: 1},
+			{query: repo.SearchQuery{Text: "petrov%", Field: repo.SearchEmail}, count: 0},
+			{query: repo.SearchQuery{Text: "vivienne"}, count: 1},
+			{query: repo.SearchQuery{Text: "Казань"}, count: 1},
+			{query: repo.SearchQuery{Text: found.TrackNumber[:8], Field: repo.SearchTrackNumber}, count: 1},
+			{query: repo.SearchQuery{Text: "Казань", Field: repo.SearchBrand}, count: 0},
+		}
+
+		for _, tt := range cases {
+			page, err := postgres.Search(ctx, tt.query)
+			require.NoError(t, err)
+			require.Len(t, page.Orders, tt.count, tt.query)
+
+			if tt.count > 0 {
+				require.Equal(t, found, page.Orders[0])
+			}
+		}
 	})
+
+	t.Run("SearchPages", func(t *testing.T) {
+		var (
+			seen  = make(map[uuid.UUID]struct{})
+			query = repo.SearchQuery{Text: "Иванов", Limit: 3}
+		)
+
+		for {
+			page, err := postgres.Search(ctx, query)
+			require.NoError(t, err)
+
+			for _, order := range page.Orders {
+				seen[order.UID] = struct{}{}
+			}
+
+			if page.Next == 0 {
+				break
+			}
+
+			query.Offset = page.Next
+		}
+
+		require.Greater(t, len(seen), 3)
+	})
+
+	t.Run("Stats", func(t *testing.T) {
+		created := time.Date(2020, 1, 7, 12, 0, 0, 0, time.UTC)
+
+		for _, bank := range []string{"alpha", "alpha", "sber"} {
+			order := NewOrder()
+			order.DateCreated = created
+			order.Payment.Bank = bank
+
+			_, err := postgres.AddOrder(ctx, order)
+			require.NoError(t, err)
+		}
+
+		require.NoError(t, postgres.RefreshStats(ctx))
+
+		query := stats.Query{
+			From:   time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+			To:     time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+			Period: stats.PeriodWeek,
+		}
+
+		basket, err := postgres.Basket(ctx, query)
+		require.NoError(t, err)
+		require.Equal(t, stats.NewBasket(3, 6, 3*1817), basket)
+
+		points, err := postgres.Timeline(ctx, query)
+		require.NoError(t, err)
+		require.Equal(t, []stats.Point{
+			{Date: time.Date(2020, 1, 6, 0, 0, 0, 0, time.UTC), Orders: 3, Items: 6, GMV: 3 * 1817},
+		}, points)
+
+		query.Dimension = stats.DimensionBank
+
+		banks, err := postgres.Breakdown(ctx, query)
+		require.NoError(t, err)
+		require.Equal(t, []stats.Entry{
+			{Key: "alpha", Orders: 2, Items: 4, Revenue: 2 * 1817},
+			{Key: "sber", Orders: 1, Items: 2, Revenue: 1817},
+		}, banks)
+
+		query.Limit = 1
+
+		brands, err := postgres.TopBrands(ctx, query)
+		require.NoError(t, err)
+		require.Equal(t, []stats.Entry{{Key: "ABC", Items: 3, Revenue: 3 * 317}}, brands)
+
+		products, err := postgres.TopProducts(ctx, query)
+		require.NoError(t, err)
+		require.Equal(t, []stats.Entry{{Key: "2389212", Items: 6, Revenue: 6 * 317}}, products)
+	})
+
+	t.Run("Encryption", func(t *testing.T) {
+		keyRing := func(primary string) *pii.KeyRing {
+			keys, err := pii.NewKeyRing(&pii.Config{
+				Enabled: true,
+				Primary: primary,
+				Keys: []pii.Key{
+					{ID: "2026-04", Secret: base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef"))},
+					{ID: "2026-10", Secret: base64.StdEncoding.EncodeToString([]byte("fedcba9876543210fedcba9876543210"))},
+				},
+				IndexKey: base64.StdEncoding.EncodeToString([]byte("index-key-0123456789-index-key-0")),
+			})
+			require.NoError(t, err)
+
+			return keys
+		}
+
+		stored := func(id uuid.UUID) (phone, requestID string) {
+			err := postgres.pool.QueryRow(ctx,
+				`SELECT d.phone, p.request_id FROM deliveries d JOIN payments p USING (order_id) WHERE d.order_id = $1`, id,
+			).Scan(&phone, &requestID)
+			require.NoError(t, err)
+
+			return phone, requestID
+		}
+
+		encrypted := &Postgres{pool: postgres.pool, keys: keyRing("2026-04")}
+
+		order := NewOrder()
+		order.Delivery.Phone = "+79990001122"
+
+		_, err := encrypted.AddOrder(ctx, order)
+		require.NoError(t, err)
+
+		got, err := encrypted.GetOrder(ctx, order.UID)
+		require.NoError(t, err)
+		require.Equal(t, order, got)
+
+		phone, requestID := stored(order.UID)
+		require.True(t, strings.HasPrefix(phone, "enc:v1:2026-04:"), phone)
+		require.NotContains(t, requestID, order.Payment.RequestID)
+
+		var payload []byte
+
+		err = postgres.pool.QueryRow(ctx, `SELECT payload FROM outbox WHERE key = $1`, order.UID.String()).Scan(&payload)
+		require.NoError(t, err)
+		require.NotContains(t, string(payload), "+79990001122")
+
+		event := entity.Event{Type: entity.EventOrderAccepted, Payload: payload}
+		require.NoError(t, encrypted.openEvent(&event))
+
+		value, err := event.Value()
+		require.NoError(t, err)
+		require.Equal(t, order, value)
+
+		page, err := encrypted.Search(ctx, repo.SearchQuery{Text: "+79990001122", Field: repo.SearchPhone})
+		require.NoError(t, err)
+		require.Equal(t, []entity.Order{order}, page.Orders)
+
+		_, err = encrypted.Search(ctx, repo.SearchQuery{Text: "Иван", Field: repo.SearchName})
+		require.ErrorIs(t, err, repo.ErrEncryptedField)
+
+		plain := NewOrder()
+		plain.Delivery.Email = "sidorov_200@example.org"
+
+		_, err = postgres.AddOrder(ctx, plain)
+		require.NoError(t, err)
+
+		rotated := &Postgres{pool: postgres.pool, keys: keyRing("2026-10")}
+
+		n, err := rotated.RotateKeys(ctx)
+		require.NoError(t, err)
+		require.Greater(t, n, 1)
+
+		n, err = rotated.RotateKeys(ctx)
+		require.NoError(t, err)
+		require.Zero(t, n)
+
+		for _, o := range []entity.Order{order, plain} {
+			phone, _ := stored(o.UID)
+			require.True(t, strings.HasPrefix(phone, "enc:v1:2026-10:"), phone)
+
+			got, err := rotated.GetOrder(ctx, o.UID)
+			require.NoError(t, err)
+			require.Equal(t, o, got)
+		}
+
+		page, err = rotated.Search(ctx, repo.SearchQuery{Text: "sidorov_200@example.org", Field: repo.SearchEmail})
+		require.NoError(t, err)
+		require.Equal(t, []entity.Order{plain}, page.Orders)
+	})
+}
+
+const benchBatchSize = 100
+
+func newBenchOrders(n int) []entity.Order {
+	orders := make([]entity.Order, n)
+	for i := range orders {
+		orders[i] = NewOrder()
+	}
+
+	return orders
+}
+
+func BenchmarkAddOrder(b *testing.B) {
+	var (
+		ctx      = context.Background()
+		postgres = newTestPostgres(b)
+	)
+
+	for b.Loop() {
+		b.StopTimer()
+		orders := newBenchOrders(benchBatchSize)
+		b.StartTimer()
+
+		for _, order := range orders {
+			_, err := postgres.AddOrder(ctx, order)
+			require.NoError(b, err)
+		}
+	}
+
+	b.ReportMetric(float64(b.N*benchBatchSize)/b.Elapsed().Seconds(), "orders/s")
+}
+
+func BenchmarkAddOrders(b *testing.B) {
+	var (
+		ctx      = context.Background()
+		postgres = newTestPostgres(b)
+	)
+
+	for b.Loop() {
+		b.StopTimer()
+		orders := newBenchOrders(benchBatchSize)
+		b.StartTimer()
+
+		_, err := postgres.AddOrders(ctx, orders)
+		require.NoError(b, err)
+	}
+
+	b.ReportMetric(float64(b.N*benchBatchSize)/b.Elapsed().Seconds(), "orders/s")
 }
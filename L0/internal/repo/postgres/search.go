@@ -0,0 +1,168 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/imotkin/L0/internal/pii"
+	"github.com/imotkin/L0/internal/repo"
+)
+
+type searchColumn struct {
+	table  string
+	key    string
+	column string
+	// field is set for columns that can be encrypted, encrypted values are
+	// only matched exactly by the blind index column.
+	field string
+	index string
+}
+
+var searchColumns = map[string]searchColumn{
+	repo.SearchPhone: {
+		table: "deliveries", key: "order_id", column: "phone",
+		field: pii.FieldPhone, index: "phone_index",
+	},
+	repo.SearchEmail: {
+		table: "deliveries", key: "order_id", column: "email",
+		field: pii.FieldEmail, index: "email_index",
+	},
+	repo.SearchName: {
+		table: "deliveries", key: "order_id", column: "name",
+		field: pii.FieldName,
+	},
+	repo.SearchCity:        {table: "deliveries", key: "order_id", column: "city"},
+	repo.SearchTrackNumber: {table: "orders", key: "id", column: "track_number"},
+	repo.SearchItemName:    {table: "items", key: "order_id", column: "name"},
+	repo.SearchBrand:       {table: "items", key: "order_id", column: "brand"},
+}
+
+// Substring matches ($2) use the trigram indexes and are ranked by
+// similarity, word matches use the tsvector columns and are ranked by
+// ts_rank. The tsvector of deliveries covers only the city, so the personal
+// data that can be encrypted is never matched by words, and it has no
+// trigram indexes. Encrypted fields are matched exactly by the blind index
+// and get the rank of an exact substring match. An order matched in several
+// fields gets the sum of the ranks.
+const (
+	substringMatch = `SELECT %s AS order_id, similarity(%s, $1) AS rank FROM %s WHERE %[2]s ILIKE $2`
+	indexMatch     = `SELECT %s AS order_id, 1 AS rank FROM %s WHERE %s = %s`
+	wordMatch      = `SELECT order_id, ts_rank(search, websearch_to_tsquery('simple', $1)) AS rank
+	    FROM %s WHERE search @@ websearch_to_tsquery('simple', $1)`
+)
+
+// searchMatches returns the queries of the matches of the field or of all
+// fields, the arguments of the blind indexes are appended to args.
+func (p *Postgres) searchMatches(field, text string, args []any) (string, []any, error) {
+	if field != "" {
+		match, ok, args := p.searchMatch(searchColumns[field], text, args)
+		if !ok {
+			return "", nil, fmt.Errorf("%w: %s", repo.ErrEncryptedField, field)
+		}
+
+		return match, args, nil
+	}
+
+	matches := make([]string, 0, len(repo.SearchFields)+2)
+
+	for _, field := range repo.SearchFields {
+		var (
+			match string
+			ok    bool
+		)
+
+		match, ok, args = p.searchMatch(searchColumns[field.(string)], text, args)
+		if ok {
+			matches = append(matches, match)
+		}
+	}
+
+	matches = append(matches,
+		fmt.Sprintf(wordMatch, "deliveries"),
+		fmt.Sprintf(wordMatch, "items"),
+	)
+
+	return strings.Join(matches, "\n        UNION ALL\n        "), args, nil
+}
+
+// searchMatch reports false for encrypted columns without a blind index.
+func (p *Postgres) searchMatch(c searchColumn, text string, args []any) (string, bool, []any) {
+	if p.keys == nil || c.field == "" || !p.keys.Encrypts(c.field) {
+		return fmt.Sprintf(substringMatch, c.key, c.column, c.table), true, args
+	}
+
+	if c.index == "" {
+		return "", false, args
+	}
+
+	args = append(args, p.keys.Index(c.field, text))
+
+	return fmt.Sprintf(indexMatch, c.key, c.table, c.index, fmt.Sprintf("$%d", len(args))), true, args
+}
+
+// likePattern matches the text anywhere in the value, wildcards in the text
+// are matched literally.
+func likePattern(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return "%" + replacer.Replace(text) + "%"
+}
+
+func (p *Postgres) Search(ctx context.Context, q repo.SearchQuery) (repo.SearchPage, error) {
+	if _, ok := searchColumns[q.Field]; q.Field != "" && !ok {
+		return repo.SearchPage{}, fmt.Errorf("unknown search field %q", q.Field)
+	}
+
+	limit := q.Limit
+	if limit <= 0 {
+		limit = repo.DefaultLimit
+	}
+
+	args := []any{q.Text, likePattern(q.Text), limit + 1, q.Offset}
+
+	matches, args, err := p.searchMatches(q.Field, q.Text, args)
+	if err != nil {
+		return repo.SearchPage{}, err
+	}
+
+	query := fmt.Sprintf(`
+        WITH matches AS (
+        %s
+        ), ranked AS (
+            SELECT order_id, sum(rank) AS rank FROM matches GROUP BY order_id
+        )
+        SELECT %s
+        FROM ranked r
+        JOIN orders o ON o.id = r.order_id
+        LEFT JOIN deliveries d ON d.order_id = o.id
+        LEFT JOIN payments p ON p.order_id = o.id
+        ORDER BY r.rank DESC, o.date_created DESC, o.id DESC
+        LIMIT $3 OFFSET $4`, matches, listColumns,
+	)
+
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		return repo.SearchPage{}, fmt.Errorf("run search query: %w", err)
+	}
+
+	orders, err := pgx.CollectRows(rows, scanListRow)
+	if err != nil {
+		return repo.SearchPage{}, fmt.Errorf("collect orders: %w", err)
+	}
+
+	err = p.openOrders(orders)
+	if err != nil {
+		return repo.SearchPage{}, err
+	}
+
+	page := repo.SearchPage{Orders: orders}
+
+	if len(orders) > limit {
+		page.Orders = orders[:limit]
+		page.Next = q.Offset + limit
+	}
+
+	return page, nil
+}
@@ -0,0 +1,171 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"github.com/imotkin/L0/internal/stats"
+)
+
+var statsDimensions = map[string]string{
+	stats.DimensionDeliveryService: "delivery_service",
+	stats.DimensionRegion:          "region",
+	stats.DimensionProvider:        "provider",
+	stats.DimensionBank:            "bank",
+}
+
+func (p *Postgres) RefreshStats(ctx context.Context) error {
+	for _, view := range []string{"order_daily_stats", "item_daily_stats"} {
+		_, err := p.pool.Exec(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+view)
+		if err != nil {
+			return fmt.Errorf("refresh %s: %w", view, err)
+		}
+	}
+
+	return nil
+}
+
+// statsFilter returns the condition on the day column of the stats views,
+// the arguments start from $1.
+func statsFilter(q stats.Query) (string, []any) {
+	var (
+		conds = []string{"TRUE"}
+		args  []any
+	)
+
+	if !q.From.IsZero() {
+		args = append(args, day(q.From))
+		conds = append(conds, fmt.Sprintf("day >= $%d", len(args)))
+	}
+
+	if !q.To.IsZero() {
+		args = append(args, day(q.To))
+		conds = append(conds, fmt.Sprintf("day < $%d", len(args)))
+	}
+
+	return strings.Join(conds, " AND "), args
+}
+
+func day(t time.Time) time.Time {
+	return t.UTC().Truncate(24 * time.Hour)
+}
+
+func statsLimit(q stats.Query) int {
+	if q.Limit <= 0 {
+		return stats.DefaultLimit
+	}
+
+	return q.Limit
+}
+
+func (p *Postgres) Timeline(ctx context.Context, q stats.Query) ([]stats.Point, error) {
+	period := q.Period
+	if period == "" {
+		period = stats.PeriodDay
+	}
+
+	where, args := statsFilter(q)
+	args = append(args, period)
+
+	query := fmt.Sprintf(`
+		SELECT date_trunc($%d, day::TIMESTAMP)::date, sum(orders)::BIGINT, sum(items)::BIGINT, sum(gmv)::BIGINT
+		  FROM order_daily_stats
+		 WHERE %s
+		 GROUP BY 1
+		 ORDER BY 1`, len(args), where,
+	)
+
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("run timeline query: %w", err)
+	}
+
+	points, err := pgx.CollectRows(rows, pgx.RowToStructByPos[stats.Point])
+	if err != nil {
+		return nil, fmt.Errorf("collect timeline: %w", err)
+	}
+
+	return points, nil
+}
+
+func (p *Postgres) Basket(ctx context.Context, q stats.Query) (stats.Basket, error) {
+	where, args := statsFilter(q)
+
+	query := fmt.Sprintf(`
+		SELECT COALESCE(sum(orders), 0)::BIGINT, COALESCE(sum(items), 0)::BIGINT, COALESCE(sum(gmv), 0)::BIGINT
+		  FROM order_daily_stats
+		 WHERE %s`, where,
+	)
+
+	var orders, items, gmv int64
+
+	err := p.pool.QueryRow(ctx, query, args...).Scan(&orders, &items, &gmv)
+	if err != nil {
+		return stats.Basket{}, fmt.Errorf("run basket query: %w", err)
+	}
+
+	return stats.NewBasket(orders, items, gmv), nil
+}
+
+func (p *Postgres) Breakdown(ctx context.Context, q stats.Query) ([]stats.Entry, error) {
+	column, ok := statsDimensions[q.Dimension]
+	if !ok {
+		return nil, fmt.Errorf("unknown stats dimension %q", q.Dimension)
+	}
+
+	where, args := statsFilter(q)
+	args = append(args, statsLimit(q))
+
+	query := fmt.Sprintf(`
+		SELECT %s, sum(orders)::BIGINT, sum(items)::BIGINT, sum(gmv)::BIGINT
+		  FROM order_daily_stats
+		 WHERE %s
+		 GROUP BY 1
+		 ORDER BY 4 DESC, 1
+		 LIMIT $%d`, column, where, len(args),
+	)
+
+	return p.statsEntries(ctx, query, args...)
+}
+
+func (p *Postgres) TopBrands(ctx context.Context, q stats.Query) ([]stats.Entry, error) {
+	return p.topItems(ctx, q, "brand")
+}
+
+func (p *Postgres) TopProducts(ctx context.Context, q stats.Query) ([]stats.Entry, error) {
+	return p.topItems(ctx, q, "nm_id::TEXT")
+}
+
+func (p *Postgres) topItems(ctx context.Context, q stats.Query, key string) ([]stats.Entry, error) {
+	where, args := statsFilter(q)
+	args = append(args, statsLimit(q))
+
+	query := fmt.Sprintf(`
+		SELECT %s, 0::BIGINT, sum(items)::BIGINT, sum(revenue)::BIGINT
+		  FROM item_daily_stats
+		 WHERE %s
+		 GROUP BY 1
+		 ORDER BY 4 DESC, 1
+		 LIMIT $%d`, key, where, len(args),
+	)
+
+	return p.statsEntries(ctx, query, args...)
+}
+
+func (p *Postgres) statsEntries(ctx context.Context, query string, args ...any) ([]stats.Entry, error) {
+	rows, err := p.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("run stats query: %w", err)
+	}
+
+	entries, err := pgx.CollectRows(rows, pgx.RowToStructByPos[stats.Entry])
+	if err != nil {
+		return nil, fmt.Errorf("collect stats: %w", err)
+	}
+
+	return entries, nil
+}
@@ -0,0 +1,130 @@
+package repo
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+
+	"github.com/imotkin/L0/internal/entity"
+)
+
+const (
+	DefaultLimit = 50
+	MaxLimit     = 500
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	// ErrEncryptedField is returned for substring searches in a field that
+	// is stored encrypted.
+	ErrEncryptedField = errors.New("field is encrypted")
+)
+
+type ListQuery struct {
+	Limit           int
+	After           *Cursor
+	IDs             []uuid.UUID
+	CustomerID      string
+	TrackNumber     string
+	DeliveryService string
+	Locale          string
+	From            time.Time
+	To              time.Time
+}
+
+func (q ListQuery) Validate() error {
+	return validation.ValidateStruct(&q,
+		validation.Field(&q.Limit, validation.Min(0), validation.Max(MaxLimit)),
+		validation.Field(&q.To, validation.When(
+			!q.From.IsZero() && !q.To.IsZero(),
+			validation.Min(q.From).Error("must be after from"),
+		)),
+	)
+}
+
+type Cursor struct {
+	DateCreated time.Time
+	ID          uuid.UUID
+}
+
+func CursorOf(order entity.Order) *Cursor {
+	return &Cursor{DateCreated: order.DateCreated, ID: order.UID}
+}
+
+func (c Cursor) Encode() string {
+	raw := c.DateCreated.UTC().Format(time.RFC3339Nano) + "|" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseCursor(s string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	date, id, ok := strings.Cut(string(raw), "|")
+	if !ok {
+		return nil, ErrInvalidCursor
+	}
+
+	created, err := time.Parse(time.RFC3339Nano, date)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	uid, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	return &Cursor{DateCreated: created, ID: uid}, nil
+}
+
+type Page struct {
+	Orders []entity.Order
+	Next   *Cursor
+}
+
+const (
+	SearchPhone       = "phone"
+	SearchEmail       = "email"
+	SearchName        = "name"
+	SearchCity        = "city"
+	SearchTrackNumber = "track_number"
+	SearchItemName    = "item_name"
+	SearchBrand       = "brand"
+)
+
+var SearchFields = []any{
+	SearchPhone, SearchEmail, SearchName, SearchCity,
+	SearchTrackNumber, SearchItemName, SearchBrand,
+}
+
+// SearchQuery finds orders by Text in all searchable fields or only in
+// Field when it's set. Results are ranked, so pages are addressed by offset.
+type SearchQuery struct {
+	Text   string
+	Field  string
+	Limit  int
+	Offset int
+}
+
+func (q SearchQuery) Validate() error {
+	return validation.ValidateStruct(&q,
+		validation.Field(&q.Text, validation.Required, validation.RuneLength(2, 200)),
+		validation.Field(&q.Field, validation.In(SearchFields...)),
+		validation.Field(&q.Limit, validation.Min(0), validation.Max(MaxLimit)),
+		validation.Field(&q.Offset, validation.Min(0)),
+	)
+}
+
+type SearchPage struct {
+	Orders []entity.Order
+	// Next is the offset of the next page, zero when there are no more orders.
+	Next int
+}
@@ -6,10 +6,16 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/repo"
 )
 
 type Service interface {
 	Add(ctx context.Context, order entity.Order) (bool, error)
+	Submit(ctx context.Context, order entity.Order) (Result, error)
 	Get(ctx context.Context, id uuid.UUID) (entity.Order, error)
-	List(ctx context.Context) ([]entity.Order, error)
+	List(ctx context.Context, query repo.ListQuery) (repo.Page, error)
+	Export(ctx context.Context, query repo.ListQuery, fn func(entity.Order) error) error
+	Search(ctx context.Context, query repo.SearchQuery) (repo.SearchPage, error)
+	UpdateStatus(ctx context.Context, change entity.StatusChange) (entity.StatusChange, error)
+	StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.StatusChange, error)
 }
@@ -0,0 +1,22 @@
+package service
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/google/uuid"
+)
+
+type SubmitStatus string
+
+const (
+	SubmitCreated   SubmitStatus = "created"
+	SubmitDuplicate SubmitStatus = "duplicate"
+	SubmitInvalid   SubmitStatus = "invalid"
+	SubmitFailed    SubmitStatus = "failed"
+)
+
+type Result struct {
+	UID    uuid.UUID         `json:"order_uid"`
+	Status SubmitStatus      `json:"status"`
+	Errors validation.Errors `json:"errors,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"github.com/imotkin/L0/internal/broker"
+	"github.com/imotkin/L0/internal/entity"
+)
+
+// OrderSchema returns the versions of order messages accepted from Kafka:
+//
+//   - v1: the original format without the order status
+//   - v2: the status field is added
+func OrderSchema() *broker.Schema {
+	return broker.NewSchema(upcastOrderV1)
+}
+
+func upcastOrderV1(payload map[string]any) error {
+	if _, ok := payload["status"]; !ok {
+		payload["status"] = string(entity.StatusCreated)
+	}
+
+	return nil
+}
@@ -2,9 +2,14 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
+	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/google/uuid"
+	"golang.org/x/sync/singleflight"
 
 	"github.com/imotkin/L0/internal/broker"
 	"github.com/imotkin/L0/internal/cache"
@@ -14,11 +19,36 @@ import (
 	"github.com/imotkin/L0/internal/repo"
 )
 
+// group coalesces concurrent calls with the same key, see singleflight.Group.
+type group interface {
+	DoChan(key string, fn func() (any, error)) <-chan singleflight.Result
+}
+
 type OrderService struct {
-	cache cache.Cache[uuid.UUID, entity.Order]
-	repo  repo.Repository
-	log   logger.Logger
-	mc    metrics.Metrics
+	cache    cache.Cache[uuid.UUID, entity.Order]
+	notFound *cache.MemoryCache[uuid.UUID, struct{}]
+	warmup   *cache.WarmupConfig
+	loads    group
+	repo     repo.Repository
+	log      logger.Logger
+	mc       metrics.Metrics
+}
+
+const (
+	statsInterval = 10 * time.Second
+	// loadTimeout bounds the shared repository call, which outlives the
+	// callers waiting for it
+	loadTimeout = 5 * time.Second
+)
+
+type Option func(*OrderService)
+
+// WithNegativeCache remembers missing orders in notFound, so repeated
+// requests for them don't reach the repository until the entries expire.
+func WithNegativeCache(notFound *cache.MemoryCache[uuid.UUID, struct{}]) Option {
+	return func(s *OrderService) {
+		s.notFound = notFound
+	}
 }
 
 func New(
@@ -26,13 +56,22 @@ func New(
 	repo repo.Repository,
 	cache cache.Cache[uuid.UUID, entity.Order],
 	mc metrics.Metrics,
+	opts ...Option,
 ) *OrderService {
-	return &OrderService{
-		cache: cache,
-		repo:  repo,
-		log:   log.With("source", "order-service"),
-		mc:    mc,
+	s := &OrderService{
+		cache:  cache,
+		warmup: defaultWarmup(),
+		loads:  &singleflight.Group{},
+		repo:   repo,
+		log:    log.With("source", "order-service"),
+		mc:     mc,
 	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
 }
 
 func (s *OrderService) Get(ctx context.Context, id uuid.UUID) (entity.Order, error) {
@@ -43,10 +82,51 @@ func (s *OrderService) Get(ctx context.Context, id uuid.UUID) (entity.Order, err
 		return order, nil
 	}
 
+	if s.notFound != nil {
+		if _, ok := s.notFound.Get(id); ok {
+			s.mc.IncCacheNegativeHits()
+			return entity.Order{}, fmt.Errorf("get from repository: %w", entity.ErrOrderNotFound)
+		}
+	}
+
+	var leader bool
+
+	// concurrent requests for the same order share one repository call,
+	// which is not cancelled when the caller that started it goes away
+	loaded := s.loads.DoChan(id.String(), func() (any, error) {
+		leader = true
+
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), loadTimeout)
+		defer cancel()
+
+		return s.load(ctx, id)
+	})
+
+	select {
+	case <-ctx.Done():
+		return entity.Order{}, ctx.Err()
+	case res := <-loaded:
+		if !leader {
+			s.mc.IncCacheCoalesced()
+		}
+
+		if res.Err != nil {
+			return entity.Order{}, res.Err
+		}
+
+		return res.Val.(entity.Order), nil
+	}
+}
+
+func (s *OrderService) load(ctx context.Context, id uuid.UUID) (entity.Order, error) {
 	order, err := s.repo.GetOrder(ctx, id)
 	s.mc.IncPostgresGet()
 
 	if err != nil {
+		if s.notFound != nil && errors.Is(err, entity.ErrOrderNotFound) {
+			s.forget(id)
+		}
+
 		return entity.Order{}, fmt.Errorf("get from repository: %w", err)
 	}
 
@@ -56,72 +136,263 @@ func (s *OrderService) Get(ctx context.Context, id uuid.UUID) (entity.Order, err
 	return order, nil
 }
 
-func (s *OrderService) List(ctx context.Context) ([]entity.Order, error) {
-	return s.repo.List(ctx)
+// forget remembers that the order is missing. The order can be added while
+// it is loaded: remember caches it before dropping the negative entry, so
+// either the entry is dropped there or the order is found in the cache here.
+func (s *OrderService) forget(id uuid.UUID) {
+	s.notFound.Set(id, struct{}{})
+
+	if _, ok := s.cache.Peek(id); ok {
+		s.notFound.Delete(id)
+	}
+}
+
+// remember stores a new order in the cache and drops its negative entry.
+func (s *OrderService) remember(order entity.Order) {
+	s.cache.Set(order.UID, order)
+	s.mc.IncCacheSet()
+
+	if s.notFound != nil {
+		s.notFound.Delete(order.UID)
+	}
+}
+
+func (s *OrderService) List(ctx context.Context, query repo.ListQuery) (repo.Page, error) {
+	return s.repo.List(ctx, query)
+}
+
+func (s *OrderService) Export(ctx context.Context, query repo.ListQuery, fn func(entity.Order) error) error {
+	return s.repo.Export(ctx, query, fn)
+}
+
+func (s *OrderService) Search(ctx context.Context, query repo.SearchQuery) (repo.SearchPage, error) {
+	page, err := s.repo.Search(ctx, query)
+	if err != nil {
+		return repo.SearchPage{}, fmt.Errorf("search in repository: %w", err)
+	}
+
+	return page, nil
+}
+
+func (s *OrderService) UpdateStatus(ctx context.Context, change entity.StatusChange) (entity.StatusChange, error) {
+	change, err := s.repo.UpdateStatus(ctx, change)
+	if err != nil {
+		return entity.StatusChange{}, fmt.Errorf("update status in repository: %w", err)
+	}
+
+	// the update isn't a read of the order, so it isn't counted as a hit
+	if order, ok := s.cache.Peek(change.OrderUID); ok {
+		order.Status = change.To
+
+		// the items are shared with the readers of the cached order
+		order.Items = slices.Clone(order.Items)
+		for i := range order.Items {
+			order.Items[i].Status = entity.ItemStatusOf(change.To)
+		}
+
+		// shared caches drop the copies of other instances on Delete
+		s.cache.Delete(change.OrderUID)
+		s.cache.Set(change.OrderUID, order)
+		s.mc.IncCacheSet()
+	}
+
+	s.log.Info(
+		"order status was changed",
+		"uid", change.OrderUID,
+		"from", change.From,
+		"to", change.To,
+	)
+
+	return change, nil
+}
+
+func (s *OrderService) StatusHistory(ctx context.Context, id uuid.UUID) ([]entity.StatusChange, error) {
+	history, err := s.repo.StatusHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("get status history from repository: %w", err)
+	}
+
+	return history, nil
 }
 
 func (s *OrderService) Add(ctx context.Context, order entity.Order) (bool, error) {
 	return s.repo.AddOrder(ctx, order)
 }
 
-func (s *OrderService) initCache(ctx context.Context) {
-	orders, err := s.repo.List(ctx)
+func (s *OrderService) Submit(ctx context.Context, order entity.Order) (Result, error) {
+	err := order.Validate()
 	if err != nil {
-		s.log.Error(err, "failed to init cache from database")
-		return
+		var errs validation.Errors
+		if !errors.As(err, &errs) {
+			return Result{}, fmt.Errorf("validate order: %w", err)
+		}
+
+		return Result{UID: order.UID, Status: SubmitInvalid, Errors: errs}, nil
 	}
 
-	for _, order := range orders {
-		s.cache.Set(order.UID, order)
+	status, err := s.store(ctx, order)
+	if err != nil {
+		return Result{}, fmt.Errorf("store order: %w", err)
 	}
 
-	s.log.Info("cache was inited", "size", s.cache.Len())
+	return Result{UID: order.UID, Status: status}, nil
 }
 
-func (s *OrderService) processOrder(ctx context.Context, order entity.Order) {
+func (s *OrderService) store(ctx context.Context, order entity.Order) (SubmitStatus, error) {
 	_, ok := s.cache.Get(order.UID)
 	s.mc.IncCacheGet()
 
 	if ok {
-		s.log.Warn("duplicate order was sent", "uid", order.UID)
-		return
+		return SubmitDuplicate, nil
+	}
+
+	if order.Status == "" {
+		order.Status = entity.StatusCreated
 	}
 
 	inserted, err := s.Add(ctx, order)
 	if err != nil {
-		s.log.Error(err, "failed to add order", "uid", order.UID)
-		return
+		return "", fmt.Errorf("add order: %w", err)
 	}
 
 	if !inserted {
-		s.log.Warn("duplicate order was sent", "uid", order.UID)
-		return
+		return SubmitDuplicate, nil
 	}
 
-	s.log.Info("order was added", "uid", order.UID)
 	s.mc.IncOrders()
 
-	s.cache.Set(order.UID, order)
-	s.mc.IncCacheSet()
+	s.remember(order)
+
+	return SubmitCreated, nil
+}
+
+func (s *OrderService) processOrders(ctx context.Context, orders []entity.Order) error {
+	var (
+		seen  = make(map[uuid.UUID]struct{}, len(orders))
+		fresh = make([]entity.Order, 0, len(orders))
+		// positions of the fresh orders in the batch
+		positions = make([]int, 0, len(orders))
+	)
+
+	for i, order := range orders {
+		if _, ok := seen[order.UID]; ok {
+			s.log.Warn("duplicate order was sent", "uid", order.UID)
+			continue
+		}
+
+		seen[order.UID] = struct{}{}
+
+		_, ok := s.cache.Get(order.UID)
+		s.mc.IncCacheGet()
+
+		if ok {
+			s.log.Warn("duplicate order was sent", "uid", order.UID)
+			continue
+		}
+
+		if order.Status == "" {
+			order.Status = entity.StatusCreated
+		}
+
+		fresh = append(fresh, order)
+		positions = append(positions, i)
+	}
+
+	if len(fresh) == 0 {
+		return nil
+	}
+
+	var failed map[int]error
+
+	ids, err := s.repo.AddOrders(ctx, fresh)
+	if errors.Is(err, repo.ErrInvalidOrder) {
+		s.log.Warn("batch was rejected, adding orders one by one", "count", len(fresh), "error", err)
+
+		ids, failed, err = s.addEach(ctx, fresh, positions)
+	}
+
+	if err != nil {
+		return fmt.Errorf("add orders: %w", err)
+	}
+
+	inserted := make(map[uuid.UUID]struct{}, len(ids))
+	for _, id := range ids {
+		inserted[id] = struct{}{}
+	}
+
+	for i, order := range fresh {
+		if _, ok := failed[positions[i]]; ok {
+			continue
+		}
+
+		if _, ok := inserted[order.UID]; !ok {
+			s.log.Warn("duplicate order was sent", "uid", order.UID)
+			continue
+		}
+
+		s.mc.IncOrders()
+
+		s.remember(order)
+	}
+
+	s.log.Info("orders were added", "count", len(ids), "batch", len(orders))
+
+	if len(failed) > 0 {
+		return &broker.BatchError{Failed: failed}
+	}
+
+	return nil
+}
+
+// addEach stores the orders of a rejected batch one by one, so only the
+// orders the database rejects fail. They are returned by their positions in
+// the batch. Orders stored before a transient error are stored again on the
+// retry as duplicates.
+func (s *OrderService) addEach(ctx context.Context, orders []entity.Order, positions []int) ([]uuid.UUID, map[int]error, error) {
+	var (
+		ids    = make([]uuid.UUID, 0, len(orders))
+		failed = make(map[int]error)
+	)
+
+	for i, order := range orders {
+		inserted, err := s.repo.AddOrder(ctx, order)
+		if errors.Is(err, repo.ErrInvalidOrder) {
+			s.log.Error(err, "order was rejected", "uid", order.UID)
+			failed[positions[i]] = err
+
+			continue
+		}
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if inserted {
+			ids = append(ids, order.UID)
+		}
+	}
+
+	return ids, failed, nil
 }
 
 func (s *OrderService) Run(ctx context.Context, sub *broker.Subscriber[entity.Order]) {
-	s.initCache(ctx)
-
-	orders := sub.Subscribe(ctx)
-
-	go func() {
-		for {
-			select {
-			case <-ctx.Done():
-				return
-			case order, ok := <-orders:
-				if !ok {
-					return
-				}
-
-				s.processOrder(ctx, order)
-			}
+	go s.warmUp(ctx)
+	go s.reportStats(ctx, statsInterval)
+
+	sub.Subscribe(ctx, s.processOrders)
+}
+
+// reportStats exports the cache statistics to metrics until ctx is done.
+func (s *OrderService) reportStats(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.mc.SetCacheStats(s.cache.Stats())
 		}
-	}()
+	}
 }
@@ -2,14 +2,21 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
+	"golang.org/x/sync/singleflight"
 
+	"github.com/imotkin/L0/internal/broker"
 	"github.com/imotkin/L0/internal/cache"
 	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/entity/entitytest"
 	"github.com/imotkin/L0/internal/logger"
 	"github.com/imotkin/L0/internal/metrics"
 	"github.com/imotkin/L0/internal/repo"
@@ -81,3 +88,487 @@ func TestOrderNotFound(t *testing.T) {
 
 	require.ErrorIs(t, err, entity.ErrOrderNotFound)
 }
+
+func TestGetCoalesced(t *testing.T) {
+	const callers = 10
+
+	var (
+		ctrl    = gomock.NewController(t)
+		id      = uuid.New()
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+		loads   = &joinedGroup{joined: make(chan struct{})}
+		release = make(chan struct{})
+	)
+
+	service.loads = loads
+
+	cache.EXPECT().Get(id).Return(entity.Order{}, false).Times(callers)
+	mc.EXPECT().IncCacheGet().Times(callers)
+
+	repo.EXPECT().GetOrder(gomock.Any(), id).DoAndReturn(func(context.Context, uuid.UUID) (entity.Order, error) {
+		<-release
+		return entity.Order{UID: id}, nil
+	})
+	mc.EXPECT().IncPostgresGet()
+
+	cache.EXPECT().Set(id, entity.Order{UID: id})
+	mc.EXPECT().IncCacheSet()
+	mc.EXPECT().IncCacheCoalesced().Times(callers - 1)
+
+	var wg sync.WaitGroup
+
+	for range callers {
+		wg.Go(func() {
+			got, err := service.Get(context.Background(), id)
+			require.NoError(t, err)
+			require.Equal(t, id, got.UID)
+		})
+	}
+
+	// the load is blocked until every caller has joined it
+	for range callers {
+		<-loads.joined
+	}
+
+	close(release)
+
+	wg.Wait()
+}
+
+// joinedGroup reports every caller that has joined a load.
+type joinedGroup struct {
+	singleflight.Group
+	joined chan struct{}
+}
+
+func (g *joinedGroup) DoChan(key string, fn func() (any, error)) <-chan singleflight.Result {
+	ch := g.Group.DoChan(key, fn)
+	g.joined <- struct{}{}
+
+	return ch
+}
+
+func TestGetCallerCancelled(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		id      = uuid.New()
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+		loaded  = make(chan struct{})
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	cache.EXPECT().Get(id).Return(entity.Order{}, false)
+	mc.EXPECT().IncCacheGet()
+
+	repo.EXPECT().GetOrder(gomock.Any(), id).DoAndReturn(func(ctx context.Context, _ uuid.UUID) (entity.Order, error) {
+		cancel()
+		time.Sleep(10 * time.Millisecond)
+		return entity.Order{UID: id}, ctx.Err()
+	})
+	mc.EXPECT().IncPostgresGet()
+
+	cache.EXPECT().Set(id, entity.Order{UID: id}).Do(func(uuid.UUID, entity.Order) { close(loaded) })
+	mc.EXPECT().IncCacheSet()
+
+	_, err := service.Get(ctx, id)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// the load finishes for other callers even though the first one left
+	<-loaded
+}
+
+func TestGetNegativeCache(t *testing.T) {
+	notFound, err := cache.New(10, cache.WithTTL[uuid.UUID, struct{}](time.Minute))
+	require.NoError(t, err)
+
+	var (
+		ctrl    = gomock.NewController(t)
+		order   = entitytest.Order()
+		id      = order.UID
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc, WithNegativeCache(notFound))
+	)
+
+	cache.EXPECT().Get(id).Return(entity.Order{}, false).Times(2)
+	mc.EXPECT().IncCacheGet().Times(2)
+
+	repo.EXPECT().GetOrder(gomock.Any(), id).Return(entity.Order{}, entity.ErrOrderNotFound)
+	mc.EXPECT().IncPostgresGet()
+	cache.EXPECT().Peek(id).Return(entity.Order{}, false)
+
+	_, err = service.Get(context.Background(), id)
+	require.ErrorIs(t, err, entity.ErrOrderNotFound)
+
+	mc.EXPECT().IncCacheNegativeHits()
+
+	_, err = service.Get(context.Background(), id)
+	require.ErrorIs(t, err, entity.ErrOrderNotFound)
+
+	stored := order
+	stored.Status = entity.StatusCreated
+
+	repo.EXPECT().AddOrder(gomock.Any(), stored).Return(true, nil)
+	mc.EXPECT().IncOrders()
+
+	cache.EXPECT().Set(id, stored)
+	mc.EXPECT().IncCacheSet()
+
+	cache.EXPECT().Get(id).Return(entity.Order{}, false)
+	mc.EXPECT().IncCacheGet()
+
+	_, err = service.Submit(context.Background(), order)
+	require.NoError(t, err)
+
+	_, ok := service.notFound.Get(id)
+	require.False(t, ok)
+}
+
+func TestGetNegativeCacheAdded(t *testing.T) {
+	orders, err := cache.New[uuid.UUID, entity.Order](10)
+	require.NoError(t, err)
+
+	notFound, err := cache.New(10, cache.WithTTL[uuid.UUID, struct{}](time.Minute))
+	require.NoError(t, err)
+
+	var (
+		ctrl    = gomock.NewController(t)
+		order   = entitytest.Order()
+		id      = order.UID
+		repo    = repo.NewMockRepository(ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, orders, mc, WithNegativeCache(notFound))
+		loading = make(chan struct{})
+		added   = make(chan struct{})
+	)
+
+	mc.EXPECT().IncCacheGet().AnyTimes()
+	mc.EXPECT().IncCacheSet().AnyTimes()
+	mc.EXPECT().IncPostgresGet()
+	mc.EXPECT().IncOrders()
+
+	// the order is added after the repository has missed it, but before
+	// the miss is remembered
+	repo.EXPECT().GetOrder(gomock.Any(), id).DoAndReturn(func(context.Context, uuid.UUID) (entity.Order, error) {
+		close(loading)
+		<-added
+
+		return entity.Order{}, entity.ErrOrderNotFound
+	})
+	repo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(true, nil)
+
+	go func() {
+		<-loading
+
+		_, err := service.Submit(context.Background(), order)
+		require.NoError(t, err)
+
+		close(added)
+	}()
+
+	_, err = service.Get(context.Background(), id)
+	require.ErrorIs(t, err, entity.ErrOrderNotFound)
+
+	_, ok := service.notFound.Peek(id)
+	require.False(t, ok, "the added order isn't remembered as missing")
+
+	got, err := service.Get(context.Background(), id)
+	require.NoError(t, err)
+	require.Equal(t, id, got.UID)
+}
+
+func TestSubmitInvalid(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		id      = uuid.New()
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	got, err := service.Submit(context.Background(), entity.Order{UID: id})
+
+	require.NoError(t, err)
+	require.Equal(t, id, got.UID)
+	require.Equal(t, SubmitInvalid, got.Status)
+	require.Contains(t, got.Errors, "track_number")
+}
+
+func TestSubmitCreated(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		order   = entitytest.Order()
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	stored := order
+	stored.Status = entity.StatusCreated
+
+	cache.EXPECT().Get(order.UID).Return(entity.Order{}, false)
+	mc.EXPECT().IncCacheGet()
+
+	repo.EXPECT().AddOrder(gomock.Any(), stored).Return(true, nil)
+	mc.EXPECT().IncOrders()
+
+	cache.EXPECT().Set(order.UID, stored).Return()
+	mc.EXPECT().IncCacheSet()
+
+	got, err := service.Submit(context.Background(), order)
+
+	require.NoError(t, err)
+	require.Equal(t, Result{UID: order.UID, Status: SubmitCreated}, got)
+}
+
+func TestSubmitDuplicate(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		order   = entitytest.Order()
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	cache.EXPECT().Get(order.UID).Return(entity.Order{}, false)
+	mc.EXPECT().IncCacheGet()
+
+	repo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(false, nil)
+
+	got, err := service.Submit(context.Background(), order)
+
+	require.NoError(t, err)
+	require.Equal(t, Result{UID: order.UID, Status: SubmitDuplicate}, got)
+}
+
+func TestSubmitRepositoryError(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		order   = entitytest.Order()
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	cache.EXPECT().Get(order.UID).Return(entity.Order{}, false)
+	mc.EXPECT().IncCacheGet()
+
+	repo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(false, errors.New("connection refused"))
+
+	_, err := service.Submit(context.Background(), order)
+
+	require.Error(t, err)
+}
+
+func TestUpdateStatus(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		id      = uuid.New()
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	change := entity.StatusChange{OrderUID: id, To: entity.StatusPaid}
+
+	expected := change
+	expected.From = entity.StatusCreated
+	expected.ChangedAt = time.Now()
+
+	repo.EXPECT().UpdateStatus(gomock.Any(), change).Return(expected, nil)
+
+	cached := entity.Order{
+		UID:    id,
+		Status: entity.StatusCreated,
+		Items:  []entity.Item{{Name: "Mascaras", Status: entity.ItemStatusCreated}},
+	}
+
+	cache.EXPECT().Peek(id).Return(cached, true)
+	cache.EXPECT().Delete(id).Return(true)
+	cache.EXPECT().Set(id, entity.Order{
+		UID:    id,
+		Status: entity.StatusPaid,
+		Items:  []entity.Item{{Name: "Mascaras", Status: entity.ItemStatusPaid}},
+	})
+	mc.EXPECT().IncCacheSet()
+
+	got, err := service.UpdateStatus(context.Background(), change)
+
+	require.NoError(t, err)
+	require.Equal(t, expected, got)
+	require.Equal(t, entity.ItemStatusCreated, cached.Items[0].Status, "the cached items aren't changed in place")
+}
+
+func TestUpdateStatusInvalidTransition(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		id      = uuid.New()
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	change := entity.StatusChange{OrderUID: id, To: entity.StatusDelivered}
+
+	repo.EXPECT().UpdateStatus(gomock.Any(), change).Return(entity.StatusChange{}, entity.ErrInvalidTransition)
+
+	_, err := service.UpdateStatus(context.Background(), change)
+
+	require.ErrorIs(t, err, entity.ErrInvalidTransition)
+}
+
+func TestProcessOrders(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	var (
+		created   = entitytest.Order()
+		cached    = entitytest.Order()
+		duplicate = entitytest.Order()
+	)
+
+	created.Status = entity.StatusCreated
+	duplicate.Status = entity.StatusCreated
+
+	cache.EXPECT().Get(created.UID).Return(entity.Order{}, false)
+	cache.EXPECT().Get(cached.UID).Return(cached, true)
+	cache.EXPECT().Get(duplicate.UID).Return(entity.Order{}, false)
+	mc.EXPECT().IncCacheGet().Times(3)
+
+	repo.EXPECT().
+		AddOrders(gomock.Any(), []entity.Order{created, duplicate}).
+		Return([]uuid.UUID{created.UID}, nil)
+
+	mc.EXPECT().IncOrders()
+	cache.EXPECT().Set(created.UID, created)
+	mc.EXPECT().IncCacheSet()
+
+	orders := []entity.Order{created, cached, duplicate, created}
+
+	err := service.processOrders(context.Background(), orders)
+
+	require.NoError(t, err)
+}
+
+func TestProcessOrdersRepositoryError(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		order   = entitytest.Order()
+		repo    = repo.NewMockRepository(ctrl)
+		cache   = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		service = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	cache.EXPECT().Get(order.UID).Return(entity.Order{}, false)
+	mc.EXPECT().IncCacheGet()
+
+	repo.EXPECT().AddOrders(gomock.Any(), gomock.Any()).Return(nil, errors.New("connection refused"))
+
+	err := service.processOrders(context.Background(), []entity.Order{order})
+
+	require.Error(t, err)
+	require.NotErrorIs(t, err, broker.ErrPermanent, "database errors are retried")
+}
+
+func TestProcessOrdersRejected(t *testing.T) {
+	var (
+		ctrl     = gomock.NewController(t)
+		rejected = fmt.Errorf("%w: value out of range", repo.ErrInvalidOrder)
+		repo     = repo.NewMockRepository(ctrl)
+		cache    = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc       = metrics.NewMockMetrics(ctrl)
+		service  = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	var (
+		cached = entitytest.Order()
+		valid  = entitytest.Order()
+		bad    = entitytest.Order()
+	)
+
+	valid.Status = entity.StatusCreated
+	bad.Status = entity.StatusCreated
+
+	cache.EXPECT().Get(cached.UID).Return(cached, true)
+	cache.EXPECT().Get(valid.UID).Return(entity.Order{}, false)
+	cache.EXPECT().Get(bad.UID).Return(entity.Order{}, false)
+	mc.EXPECT().IncCacheGet().Times(3)
+
+	gomock.InOrder(
+		repo.EXPECT().AddOrders(gomock.Any(), []entity.Order{valid, bad}).Return(nil, rejected),
+		repo.EXPECT().AddOrder(gomock.Any(), valid).Return(true, nil),
+		repo.EXPECT().AddOrder(gomock.Any(), bad).Return(false, rejected),
+	)
+
+	mc.EXPECT().IncOrders()
+	cache.EXPECT().Set(valid.UID, valid)
+	mc.EXPECT().IncCacheSet()
+
+	err := service.processOrders(context.Background(), []entity.Order{cached, valid, bad})
+
+	var batchErr *broker.BatchError
+
+	require.ErrorAs(t, err, &batchErr)
+	require.ErrorIs(t, err, broker.ErrPermanent)
+	require.Equal(t, map[int]error{2: rejected}, batchErr.Failed, "only the rejected order fails")
+}
+
+func TestProcessOrdersRejectedTransient(t *testing.T) {
+	var (
+		ctrl     = gomock.NewController(t)
+		order    = entitytest.Order()
+		rejected = fmt.Errorf("%w: value out of range", repo.ErrInvalidOrder)
+		repo     = repo.NewMockRepository(ctrl)
+		cache    = cache.NewMockCache[uuid.UUID, entity.Order](ctrl)
+		mc       = metrics.NewMockMetrics(ctrl)
+		service  = New(logger.NewNoOp(), repo, cache, mc)
+	)
+
+	cache.EXPECT().Get(order.UID).Return(entity.Order{}, false)
+	mc.EXPECT().IncCacheGet()
+
+	repo.EXPECT().AddOrders(gomock.Any(), gomock.Any()).Return(nil, rejected)
+	repo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(false, errors.New("connection refused"))
+
+	err := service.processOrders(context.Background(), []entity.Order{order})
+
+	require.Error(t, err)
+	require.NotErrorIs(t, err, broker.ErrPermanent, "the batch is retried")
+}
+
+func TestOrderSchema(t *testing.T) {
+	schema := OrderSchema()
+	require.Equal(t, 2, schema.Current())
+
+	payload := map[string]any{"order_uid": uuid.NewString()}
+
+	err := upcastOrderV1(payload)
+	require.NoError(t, err)
+	require.Equal(t, string(entity.StatusCreated), payload["status"])
+
+	payload["status"] = string(entity.StatusPaid)
+
+	err = upcastOrderV1(payload)
+	require.NoError(t, err)
+	require.Equal(t, string(entity.StatusPaid), payload["status"])
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"github.com/imotkin/L0/internal/cache"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/repo"
+)
+
+func defaultWarmup() *cache.WarmupConfig {
+	return &cache.WarmupConfig{Strategy: cache.WarmupRecent}
+}
+
+// WithWarmup selects how the cache is filled on start, the most recent
+// orders are loaded by default.
+func WithWarmup(cfg *cache.WarmupConfig) Option {
+	return func(s *OrderService) {
+		s.warmup = cfg
+	}
+}
+
+func (s *OrderService) warmUp(ctx context.Context) {
+	var (
+		loaded int
+		err    error
+	)
+
+	switch s.warmup.Strategy {
+	case cache.WarmupNone:
+		return
+	case cache.WarmupSnapshot:
+		loaded, err = s.warmUpSnapshot(ctx)
+	default:
+		loaded, err = s.warmUpRecent(ctx)
+	}
+
+	if err != nil {
+		s.log.Error(err, "failed to warm up cache", "strategy", s.warmup.Strategy, "loaded", loaded)
+		return
+	}
+
+	s.log.Info("cache was warmed up", "strategy", s.warmup.Strategy, "loaded", loaded, "size", s.cache.Len())
+}
+
+func (s *OrderService) warmupSize() int {
+	if s.warmup.Size > 0 {
+		return min(s.warmup.Size, s.cache.Cap())
+	}
+
+	return s.cache.Cap()
+}
+
+func (s *OrderService) warmupBatch() int {
+	if s.warmup.BatchSize > 0 {
+		return min(s.warmup.BatchSize, repo.MaxLimit)
+	}
+
+	return repo.MaxLimit
+}
+
+// warmUpRecent loads the most recent orders by date_created page by page.
+func (s *OrderService) warmUpRecent(ctx context.Context) (int, error) {
+	var (
+		total  = s.warmupSize()
+		loaded int
+		query  = repo.ListQuery{}
+	)
+
+	for loaded < total {
+		query.Limit = min(s.warmupBatch(), total-loaded)
+
+		page, err := s.repo.List(ctx, query)
+		if err != nil {
+			return loaded, fmt.Errorf("list orders: %w", err)
+		}
+
+		loaded += s.fill(page.Orders)
+
+		s.log.Debug("cache warm-up progress", "loaded", loaded, "total", total)
+
+		if page.Next == nil {
+			break
+		}
+
+		query.After = page.Next
+	}
+
+	return loaded, nil
+}
+
+// warmUpSnapshot loads the orders saved by SaveSnapshot.
+func (s *OrderService) warmUpSnapshot(ctx context.Context) (int, error) {
+	f, err := os.Open(s.warmup.SnapshotPath)
+	if errors.Is(err, fs.ErrNotExist) {
+		s.log.Info("cache snapshot was not found", "path", s.warmup.SnapshotPath)
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	ids, err := cache.ReadSnapshot[uuid.UUID](f)
+	if err != nil {
+		return 0, fmt.Errorf("read snapshot: %w", err)
+	}
+
+	ids = ids[:min(len(ids), s.warmupSize())]
+
+	var loaded int
+
+	for chunk := range slices.Chunk(ids, s.warmupBatch()) {
+		page, err := s.repo.List(ctx, repo.ListQuery{Limit: len(chunk), IDs: chunk})
+		if err != nil {
+			return loaded, fmt.Errorf("list orders: %w", err)
+		}
+
+		loaded += s.fill(page.Orders)
+
+		s.log.Debug("cache warm-up progress", "loaded", loaded, "total", len(ids))
+	}
+
+	return loaded, nil
+}
+
+// fill stores the orders which are not cached yet, the oldest first. Orders
+// added by the subscriber in the meantime are left untouched.
+func (s *OrderService) fill(orders []entity.Order) int {
+	var n int
+
+	for _, order := range slices.Backward(orders) {
+		// Peek doesn't count the check as a use of the order
+		if _, ok := s.cache.Peek(order.UID); ok {
+			continue
+		}
+
+		s.cache.Set(order.UID, order)
+		n++
+	}
+
+	return n
+}
+
+// SaveSnapshot writes the cached keys to the snapshot file when the snapshot
+// warm-up is used. The most recently used keys go first, so they are loaded
+// even when the warm-up size is smaller than the snapshot.
+func (s *OrderService) SaveSnapshot() error {
+	if s.warmup.Strategy != cache.WarmupSnapshot {
+		return nil
+	}
+
+	keys := s.cache.Keys()
+
+	// the file is replaced at once, so a crash doesn't leave a partial snapshot
+	tmp, err := os.CreateTemp(filepath.Dir(s.warmup.SnapshotPath), ".snapshot-*")
+	if err != nil {
+		return fmt.Errorf("create snapshot: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	err = cache.WriteSnapshot(tmp, keys)
+	if err != nil {
+		tmp.Close()
+		return fmt.Errorf("write snapshot: %w", err)
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return fmt.Errorf("close snapshot: %w", err)
+	}
+
+	err = os.Rename(tmp.Name(), s.warmup.SnapshotPath)
+	if err != nil {
+		return fmt.Errorf("rename snapshot: %w", err)
+	}
+
+	s.log.Info("cache snapshot was saved", "path", s.warmup.SnapshotPath, "keys", len(keys))
+
+	return nil
+}
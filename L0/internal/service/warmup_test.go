@@ -0,0 +1,142 @@
+package service
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/cache"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/entity/entitytest"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/repo"
+)
+
+func TestWarmUpRecent(t *testing.T) {
+	c, err := cache.New[uuid.UUID, entity.Order](10)
+	require.NoError(t, err)
+
+	var (
+		ctrl    = gomock.NewController(t)
+		orders  = entitytest.Orders(5)
+		repo    = repo.NewMockRepository(ctrl)
+		service = New(logger.NewNoOp(), repo, c, metrics.NewMockMetrics(ctrl), WithWarmup(&cache.WarmupConfig{
+			Strategy:  cache.WarmupRecent,
+			Size:      5,
+			BatchSize: 2,
+		}))
+	)
+
+	gomock.InOrder(
+		repo.EXPECT().List(gomock.Any(), repoQuery(2, nil)).
+			Return(pageOf(orders[:2], &orders[1]), nil),
+		repo.EXPECT().List(gomock.Any(), repoQuery(2, &orders[1])).
+			Return(pageOf(orders[2:4], &orders[3]), nil),
+		repo.EXPECT().List(gomock.Any(), repoQuery(1, &orders[3])).
+			Return(pageOf(orders[4:], nil), nil),
+	)
+
+	loaded, err := service.warmUpRecent(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 5, loaded)
+	require.Equal(t, 5, c.Len())
+}
+
+func TestWarmUpSnapshot(t *testing.T) {
+	var (
+		ctrl    = gomock.NewController(t)
+		orders  = entitytest.Orders(3)
+		storage = repo.NewMockRepository(ctrl)
+		mc      = metrics.NewMockMetrics(ctrl)
+		cfg     = &cache.WarmupConfig{
+			Strategy:     cache.WarmupSnapshot,
+			SnapshotPath: filepath.Join(t.TempDir(), "cache.snapshot"),
+		}
+	)
+
+	empty, err := cache.New[uuid.UUID, entity.Order](10)
+	require.NoError(t, err)
+
+	// nothing is loaded before the first snapshot is saved
+	loaded, err := New(logger.NewNoOp(), storage, empty, mc, WithWarmup(cfg)).
+		warmUpSnapshot(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, loaded)
+
+	saved, err := cache.New[uuid.UUID, entity.Order](10)
+	require.NoError(t, err)
+
+	for _, order := range orders {
+		saved.Set(order.UID, order)
+	}
+
+	err = New(logger.NewNoOp(), storage, saved, mc, WithWarmup(cfg)).SaveSnapshot()
+	require.NoError(t, err)
+
+	storage.EXPECT().List(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(_ context.Context, q repo.ListQuery) (repo.Page, error) {
+			require.ElementsMatch(t, saved.Keys(), q.IDs)
+			return pageOf(orders, nil), nil
+		},
+	)
+
+	c, err := cache.New[uuid.UUID, entity.Order](10)
+	require.NoError(t, err)
+
+	service := New(logger.NewNoOp(), storage, c, mc, WithWarmup(cfg))
+
+	loaded, err = service.warmUpSnapshot(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 3, loaded)
+	require.ElementsMatch(t, saved.Keys(), c.Keys())
+}
+
+func TestWarmUpKeepsFreshOrders(t *testing.T) {
+	c, err := cache.New[uuid.UUID, entity.Order](10)
+	require.NoError(t, err)
+
+	var (
+		ctrl    = gomock.NewController(t)
+		repo    = repo.NewMockRepository(ctrl)
+		service = New(logger.NewNoOp(), repo, c, metrics.NewMockMetrics(ctrl))
+		order   = entity.Order{UID: uuid.New(), Status: entity.StatusCreated}
+		fresh   = entity.Order{UID: order.UID, Status: entity.StatusPaid}
+	)
+
+	c.Set(fresh.UID, fresh)
+
+	repo.EXPECT().List(gomock.Any(), gomock.Any()).Return(pageOf([]entity.Order{order}, nil), nil)
+
+	loaded, err := service.warmUpRecent(context.Background())
+	require.NoError(t, err)
+	require.Zero(t, loaded)
+
+	require.Zero(t, c.Stats().Hits+c.Stats().Misses, "the warm-up doesn't use the cached orders")
+
+	got, ok := c.Get(order.UID)
+	require.True(t, ok)
+	require.Equal(t, fresh, got)
+}
+
+func repoQuery(limit int, after *entity.Order) repo.ListQuery {
+	q := repo.ListQuery{Limit: limit}
+	if after != nil {
+		q.After = repo.CursorOf(*after)
+	}
+
+	return q
+}
+
+func pageOf(orders []entity.Order, next *entity.Order) repo.Page {
+	page := repo.Page{Orders: orders}
+	if next != nil {
+		page.Next = repo.CursorOf(*next)
+	}
+
+	return page
+}
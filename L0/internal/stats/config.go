@@ -0,0 +1,23 @@
+package stats
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const defaultRefreshInterval = 5 * time.Minute
+
+type Config struct {
+	RefreshInterval time.Duration `koanf:"refresh_interval"`
+}
+
+func (c *Config) Validate() error {
+	return validation.ValidateStruct(c,
+		validation.Field(&c.RefreshInterval, validation.Required, validation.Min(time.Second)),
+	)
+}
+
+func defaultConfig() *Config {
+	return &Config{RefreshInterval: defaultRefreshInterval}
+}
@@ -0,0 +1,12 @@
+package stats
+
+import "context"
+
+type Store interface {
+	RefreshStats(ctx context.Context) error
+	Timeline(ctx context.Context, query Query) ([]Point, error)
+	Basket(ctx context.Context, query Query) (Basket, error)
+	Breakdown(ctx context.Context, query Query) ([]Entry, error)
+	TopBrands(ctx context.Context, query Query) ([]Entry, error)
+	TopProducts(ctx context.Context, query Query) ([]Entry, error)
+}
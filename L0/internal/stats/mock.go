@@ -0,0 +1,130 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: internal/stats/contract.go
+//
+// Generated by this command:
+//
+//	mockgen -source=internal/stats/contract.go -destination=internal/stats/mock.go -package=stats
+//
+
+// Package stats is a generated GoMock package.
+package stats
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockStore is a mock of Store interface.
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+	isgomock struct{}
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore.
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance.
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Basket mocks base method.
+func (m *MockStore) Basket(ctx context.Context, query Query) (Basket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Basket", ctx, query)
+	ret0, _ := ret[0].(Basket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Basket indicates an expected call of Basket.
+func (mr *MockStoreMockRecorder) Basket(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Basket", reflect.TypeOf((*MockStore)(nil).Basket), ctx, query)
+}
+
+// Breakdown mocks base method.
+func (m *MockStore) Breakdown(ctx context.Context, query Query) ([]Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Breakdown", ctx, query)
+	ret0, _ := ret[0].([]Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Breakdown indicates an expected call of Breakdown.
+func (mr *MockStoreMockRecorder) Breakdown(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Breakdown", reflect.TypeOf((*MockStore)(nil).Breakdown), ctx, query)
+}
+
+// RefreshStats mocks base method.
+func (m *MockStore) RefreshStats(ctx context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RefreshStats", ctx)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RefreshStats indicates an expected call of RefreshStats.
+func (mr *MockStoreMockRecorder) RefreshStats(ctx any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshStats", reflect.TypeOf((*MockStore)(nil).RefreshStats), ctx)
+}
+
+// Timeline mocks base method.
+func (m *MockStore) Timeline(ctx context.Context, query Query) ([]Point, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Timeline", ctx, query)
+	ret0, _ := ret[0].([]Point)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Timeline indicates an expected call of Timeline.
+func (mr *MockStoreMockRecorder) Timeline(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Timeline", reflect.TypeOf((*MockStore)(nil).Timeline), ctx, query)
+}
+
+// TopBrands mocks base method.
+func (m *MockStore) TopBrands(ctx context.Context, query Query) ([]Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopBrands", ctx, query)
+	ret0, _ := ret[0].([]Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopBrands indicates an expected call of TopBrands.
+func (mr *MockStoreMockRecorder) TopBrands(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopBrands", reflect.TypeOf((*MockStore)(nil).TopBrands), ctx, query)
+}
+
+// TopProducts mocks base method.
+func (m *MockStore) TopProducts(ctx context.Context, query Query) ([]Entry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TopProducts", ctx, query)
+	ret0, _ := ret[0].([]Entry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// TopProducts indicates an expected call of TopProducts.
+func (mr *MockStoreMockRecorder) TopProducts(ctx, query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TopProducts", reflect.TypeOf((*MockStore)(nil).TopProducts), ctx, query)
+}
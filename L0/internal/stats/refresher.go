@@ -0,0 +1,62 @@
+package stats
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/imotkin/L0/internal/logger"
+)
+
+// Refresher recomputes the materialized views the statistics are read from.
+type Refresher struct {
+	store Store
+	cfg   *Config
+	log   logger.Logger
+}
+
+// NewRefresher creates a refresher, the views are refreshed every five
+// minutes when cfg is nil.
+func NewRefresher(log logger.Logger, cfg *Config, store Store) *Refresher {
+	if cfg == nil {
+		cfg = defaultConfig()
+	}
+
+	return &Refresher{
+		store: store,
+		cfg:   cfg,
+		log:   log.With("source", "stats-refresher"),
+	}
+}
+
+// Run refreshes the statistics right away and then on every interval until
+// ctx is done.
+func (r *Refresher) Run(ctx context.Context) {
+	r.log.Info("stats refresher was started", slog.Duration("interval", r.cfg.RefreshInterval))
+	defer r.log.Info("stats refresher was stopped")
+
+	ticker := time.NewTicker(r.cfg.RefreshInterval)
+	defer ticker.Stop()
+
+	for {
+		r.refresh(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (r *Refresher) refresh(ctx context.Context) {
+	start := time.Now()
+
+	err := r.store.RefreshStats(ctx)
+	if err != nil {
+		r.log.Error(err, "failed to refresh stats")
+		return
+	}
+
+	r.log.Debug("stats were refreshed", slog.Duration("took", time.Since(start)))
+}
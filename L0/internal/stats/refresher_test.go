@@ -0,0 +1,50 @@
+package stats
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/logger"
+)
+
+func TestRefresher(t *testing.T) {
+	var (
+		ctrl        = gomock.NewController(t)
+		store       = NewMockStore(ctrl)
+		ctx, cancel = context.WithCancel(context.Background())
+		refresher   = NewRefresher(logger.NewNoOp(), &Config{RefreshInterval: time.Millisecond}, store)
+		done        = make(chan struct{})
+	)
+
+	defer cancel()
+
+	gomock.InOrder(
+		store.EXPECT().RefreshStats(gomock.Any()).Return(errors.New("connection refused")),
+		store.EXPECT().RefreshStats(gomock.Any()).DoAndReturn(func(context.Context) error {
+			cancel()
+			return nil
+		}),
+		store.EXPECT().RefreshStats(gomock.Any()).Return(context.Canceled).AnyTimes(),
+	)
+
+	go func() {
+		refresher.Run(ctx)
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		require.FailNow(t, "refresher wasn't stopped")
+	}
+}
+
+func TestRefresherDefault(t *testing.T) {
+	refresher := NewRefresher(logger.NewNoOp(), nil, nil)
+	require.Equal(t, 5*time.Minute, refresher.cfg.RefreshInterval)
+}
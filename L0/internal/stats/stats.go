@@ -0,0 +1,87 @@
+package stats
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+const (
+	PeriodDay  = "day"
+	PeriodWeek = "week"
+)
+
+var Periods = []any{PeriodDay, PeriodWeek}
+
+const (
+	DimensionDeliveryService = "delivery_service"
+	DimensionRegion          = "region"
+	DimensionProvider        = "provider"
+	DimensionBank            = "bank"
+)
+
+var Dimensions = []any{DimensionDeliveryService, DimensionRegion, DimensionProvider, DimensionBank}
+
+const (
+	DefaultLimit = 10
+	MaxLimit     = 100
+)
+
+// Query selects orders created from From inclusive to To exclusive, zero
+// bounds are not applied. Dates are taken in UTC. Dimension is used only by
+// breakdowns.
+type Query struct {
+	From      time.Time
+	To        time.Time
+	Period    string
+	Dimension string
+	Limit     int
+}
+
+func (q Query) Validate() error {
+	return validation.ValidateStruct(&q,
+		validation.Field(&q.To, validation.When(
+			!q.From.IsZero() && !q.To.IsZero(),
+			validation.Min(q.From).Error("must be after from"),
+		)),
+		validation.Field(&q.Period, validation.In(Periods...)),
+		validation.Field(&q.Dimension, validation.In(Dimensions...)),
+		validation.Field(&q.Limit, validation.Min(0), validation.Max(MaxLimit)),
+	)
+}
+
+// Point holds the orders created during the period starting at Date.
+type Point struct {
+	Date   time.Time `json:"date"`
+	Orders int64     `json:"orders"`
+	Items  int64     `json:"items"`
+	GMV    int64     `json:"gmv"`
+}
+
+type Basket struct {
+	Orders    int64   `json:"orders"`
+	Items     int64   `json:"items"`
+	GMV       int64   `json:"gmv"`
+	AvgAmount float64 `json:"avg_amount"`
+	AvgItems  float64 `json:"avg_items"`
+}
+
+func NewBasket(orders, items, gmv int64) Basket {
+	b := Basket{Orders: orders, Items: items, GMV: gmv}
+
+	if orders > 0 {
+		b.AvgAmount = float64(gmv) / float64(orders)
+		b.AvgItems = float64(items) / float64(orders)
+	}
+
+	return b
+}
+
+// Entry is a group of a breakdown or a top list. Orders are not counted for
+// the top lists, since an order may contain several brands and products.
+type Entry struct {
+	Key     string `json:"key"`
+	Orders  int64  `json:"orders,omitempty"`
+	Items   int64  `json:"items"`
+	Revenue int64  `json:"revenue"`
+}
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE INDEX IF NOT EXISTS orders_date_created_id_idx
+    ON orders (date_created DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS orders_customer_id_date_created_idx
+    ON orders (customer_id, date_created DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS orders_delivery_service_date_created_idx
+    ON orders (delivery_service, date_created DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS orders_locale_date_created_idx
+    ON orders (locale, date_created DESC, id DESC);
+
+CREATE INDEX IF NOT EXISTS orders_track_number_idx
+    ON orders (track_number);
+
+CREATE INDEX IF NOT EXISTS items_order_id_idx
+    ON items (order_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS orders_date_created_id_idx;
+DROP INDEX IF EXISTS orders_customer_id_date_created_idx;
+DROP INDEX IF EXISTS orders_delivery_service_date_created_idx;
+DROP INDEX IF EXISTS orders_locale_date_created_idx;
+DROP INDEX IF EXISTS orders_track_number_idx;
+DROP INDEX IF EXISTS items_order_id_idx;
+
+-- +goose StatementEnd
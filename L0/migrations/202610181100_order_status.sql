@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE orders
+    ADD COLUMN status TEXT NOT NULL DEFAULT 'created',
+    ADD COLUMN updated_at TIMESTAMPTZ;
+
+CREATE TABLE order_status_history (
+    id BIGSERIAL PRIMARY KEY,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    from_status TEXT,
+    to_status TEXT NOT NULL,
+    comment TEXT,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX order_status_history_order_id_idx
+    ON order_status_history (order_id, changed_at);
+
+INSERT INTO order_status_history (order_id, to_status, changed_at)
+SELECT id, status, COALESCE(date_created, now()) FROM orders;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP TABLE order_status_history;
+
+ALTER TABLE orders
+    DROP COLUMN status,
+    DROP COLUMN updated_at;
+
+-- +goose StatementEnd
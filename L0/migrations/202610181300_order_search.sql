@@ -0,0 +1,44 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+ALTER TABLE deliveries ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple',
+        coalesce(name, '') || ' ' || coalesce(city, '') || ' ' ||
+        coalesce(email, '') || ' ' || coalesce(phone, '')
+    )
+) STORED;
+
+ALTER TABLE items ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple', coalesce(name, '') || ' ' || coalesce(brand, ''))
+) STORED;
+
+CREATE INDEX IF NOT EXISTS deliveries_search_idx ON deliveries USING GIN (search);
+CREATE INDEX IF NOT EXISTS items_search_idx ON items USING GIN (search);
+
+CREATE INDEX IF NOT EXISTS deliveries_name_trgm_idx ON deliveries USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS deliveries_phone_trgm_idx ON deliveries USING GIN (phone gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS deliveries_email_trgm_idx ON deliveries USING GIN (email gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS deliveries_city_trgm_idx ON deliveries USING GIN (city gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS orders_track_number_trgm_idx ON orders USING GIN (track_number gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS items_name_trgm_idx ON items USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS items_brand_trgm_idx ON items USING GIN (brand gin_trgm_ops);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS deliveries_name_trgm_idx;
+DROP INDEX IF EXISTS deliveries_phone_trgm_idx;
+DROP INDEX IF EXISTS deliveries_email_trgm_idx;
+DROP INDEX IF EXISTS deliveries_city_trgm_idx;
+DROP INDEX IF EXISTS orders_track_number_trgm_idx;
+DROP INDEX IF EXISTS items_name_trgm_idx;
+DROP INDEX IF EXISTS items_brand_trgm_idx;
+
+ALTER TABLE deliveries DROP COLUMN IF EXISTS search;
+ALTER TABLE items DROP COLUMN IF EXISTS search;
+
+-- +goose StatementEnd
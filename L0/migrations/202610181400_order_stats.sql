@@ -0,0 +1,50 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE MATERIALIZED VIEW order_daily_stats AS
+SELECT
+    (o.date_created AT TIME ZONE 'UTC')::date AS day,
+    COALESCE(o.delivery_service, '') AS delivery_service,
+    COALESCE(d.region, '') AS region,
+    COALESCE(p.provider, '') AS provider,
+    COALESCE(p.bank, '') AS bank,
+    count(*) AS orders,
+    COALESCE(sum(i.items), 0)::BIGINT AS items,
+    COALESCE(sum(p.amount), 0)::BIGINT AS gmv
+FROM orders o
+LEFT JOIN deliveries d ON d.order_id = o.id
+LEFT JOIN payments p ON p.order_id = o.id
+LEFT JOIN (
+    SELECT order_id, count(*) AS items FROM items GROUP BY order_id
+) i ON i.order_id = o.id
+WHERE o.date_created IS NOT NULL
+GROUP BY 1, 2, 3, 4, 5;
+
+-- the unique index is required to refresh the view concurrently
+CREATE UNIQUE INDEX order_daily_stats_key_idx
+    ON order_daily_stats (day, delivery_service, region, provider, bank);
+
+CREATE MATERIALIZED VIEW item_daily_stats AS
+SELECT
+    (o.date_created AT TIME ZONE 'UTC')::date AS day,
+    COALESCE(i.brand, '') AS brand,
+    COALESCE(i.nm_id, 0) AS nm_id,
+    count(*) AS items,
+    COALESCE(sum(i.total_price), 0)::BIGINT AS revenue
+FROM items i
+JOIN orders o ON o.id = i.order_id
+WHERE o.date_created IS NOT NULL
+GROUP BY 1, 2, 3;
+
+CREATE UNIQUE INDEX item_daily_stats_key_idx
+    ON item_daily_stats (day, brand, nm_id);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP MATERIALIZED VIEW IF EXISTS order_daily_stats;
+DROP MATERIALIZED VIEW IF EXISTS item_daily_stats;
+
+-- +goose StatementEnd
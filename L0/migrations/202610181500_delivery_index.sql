@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Blind indexes of encrypted phones and emails, keyed hashes of the values
+-- that allow exact lookups without decrypting the rows.
+ALTER TABLE deliveries ADD COLUMN phone_index TEXT;
+ALTER TABLE deliveries ADD COLUMN email_index TEXT;
+
+CREATE INDEX IF NOT EXISTS deliveries_phone_index_idx ON deliveries (phone_index);
+CREATE INDEX IF NOT EXISTS deliveries_email_index_idx ON deliveries (email_index);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+ALTER TABLE deliveries DROP COLUMN IF EXISTS phone_index;
+ALTER TABLE deliveries DROP COLUMN IF EXISTS email_index;
+
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Published events are deleted by the relay once they're older than the
+-- retention.
+CREATE INDEX IF NOT EXISTS outbox_published_idx
+    ON outbox (published_at)
+    WHERE published_at IS NOT NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS outbox_published_idx;
+
+-- +goose StatementEnd
@@ -0,0 +1,42 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- The personal data of deliveries can be encrypted, so the words of names,
+-- emails and phones are kept out of the search column, and their trigram
+-- indexes are dropped: built over the ciphertext they are never used, and
+-- encrypted emails and phones are matched by the blind indexes.
+DROP INDEX IF EXISTS deliveries_name_trgm_idx;
+DROP INDEX IF EXISTS deliveries_phone_trgm_idx;
+DROP INDEX IF EXISTS deliveries_email_trgm_idx;
+
+DROP INDEX IF EXISTS deliveries_search_idx;
+ALTER TABLE deliveries DROP COLUMN IF EXISTS search;
+
+ALTER TABLE deliveries ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple', coalesce(city, ''))
+) STORED;
+
+CREATE INDEX IF NOT EXISTS deliveries_search_idx ON deliveries USING GIN (search);
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS deliveries_search_idx;
+ALTER TABLE deliveries DROP COLUMN IF EXISTS search;
+
+ALTER TABLE deliveries ADD COLUMN search tsvector GENERATED ALWAYS AS (
+    to_tsvector('simple',
+        coalesce(name, '') || ' ' || coalesce(city, '') || ' ' ||
+        coalesce(email, '') || ' ' || coalesce(phone, '')
+    )
+) STORED;
+
+CREATE INDEX IF NOT EXISTS deliveries_search_idx ON deliveries USING GIN (search);
+
+CREATE INDEX IF NOT EXISTS deliveries_name_trgm_idx ON deliveries USING GIN (name gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS deliveries_phone_trgm_idx ON deliveries USING GIN (phone gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS deliveries_email_trgm_idx ON deliveries USING GIN (email gin_trgm_ops);
+
+-- +goose StatementEnd
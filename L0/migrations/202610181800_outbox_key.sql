@@ -0,0 +1,16 @@
+-- +goose Up
+-- +goose StatementBegin
+
+-- Events are claimed only when no earlier event of their key is pending.
+CREATE INDEX IF NOT EXISTS outbox_key_pending_idx
+    ON outbox (key, id)
+    WHERE published_at IS NULL;
+
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+
+DROP INDEX IF EXISTS outbox_key_pending_idx;
+
+-- +goose StatementEnd
@@ -0,0 +1,66 @@
+package client
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+
+	"github.com/imotkin/L0/internal/api/handler"
+	"github.com/imotkin/L0/internal/api/router"
+	"github.com/imotkin/L0/internal/auth"
+	"github.com/imotkin/L0/internal/entity"
+	"github.com/imotkin/L0/internal/logger"
+	"github.com/imotkin/L0/internal/metrics"
+	"github.com/imotkin/L0/internal/repo"
+	"github.com/imotkin/L0/internal/service"
+)
+
+func TestClient(t *testing.T) {
+	var (
+		ctrl  = gomock.NewController(t)
+		s     = service.NewMockService(ctrl)
+		mc    = metrics.NewMockMetrics(ctrl)
+		log   = logger.NewNoOp()
+		order = entity.Order{UID: uuid.New(), TrackNumber: "WBILMTESTTRACK", Status: entity.StatusPaid}
+		srv   = httptest.NewServer(router.New(handler.New(log, s, mc), nil, nil, handler.NewAuth(log, &auth.Config{}, nil), "../../template/index.html"))
+		ctx   = context.Background()
+	)
+
+	defer srv.Close()
+
+	mc.EXPECT().IncRequests().AnyTimes()
+
+	c, err := NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
+
+	s.EXPECT().Get(gomock.Any(), order.UID).Return(order, nil)
+
+	resp, err := c.GetOrderWithResponse(ctx, order.UID)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, order.UID, *resp.JSON200.OrderUid)
+	require.Equal(t, StatusPaid, *resp.JSON200.Status)
+
+	s.EXPECT().Get(gomock.Any(), gomock.Any()).Return(entity.Order{}, entity.ErrOrderNotFound)
+
+	resp, err = c.GetOrderWithResponse(ctx, uuid.New())
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.JSON404.StatusCode)
+
+	locale, limit := "en", 1
+
+	s.EXPECT().List(gomock.Any(), repo.ListQuery{Limit: 1, Locale: "en"}).Return(repo.Page{
+		Orders: []entity.Order{order},
+		Next:   repo.CursorOf(order),
+	}, nil)
+
+	list, err := c.ListOrdersWithResponse(ctx, &ListOrdersParams{Locale: &locale, Limit: &limit})
+	require.NoError(t, err)
+	require.Len(t, list.JSON200.Orders, 1)
+	require.NotNil(t, list.JSON200.NextCursor)
+}
@@ -0,0 +1,5 @@
+// Package client is a typed client of the HTTP API generated from the OpenAPI
+// document served at /openapi.json.
+package client
+
+//go:generate oapi-codegen -config oapi-codegen.yaml ../../internal/api/openapi/openapi.json